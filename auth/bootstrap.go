@@ -0,0 +1,42 @@
+package auth
+
+import (
+    "errors"
+    "log"
+
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+)
+
+// EnsureAdmin crea la cuenta de administrador inicial cuando no existe ningún usuario.
+// Sin contraseña configurada devuelve un error, porque nadie podría iniciar sesión.
+func EnsureAdmin(users repositories.UserRepository, username, password string) error {
+    total, err := users.Count()
+    if err != nil {
+        return err
+    }
+    if total > 0 {
+        return nil
+    }
+
+    if password == "" {
+        return errors.New("no hay usuarios registrados; defina ADMIN_PASSWORD para crear el administrador inicial")
+    }
+
+    hash, err := HashPassword(password)
+    if err != nil {
+        return err
+    }
+
+    admin := models.User{
+        Username:     username,
+        PasswordHash: hash,
+        Role:         models.RoleAdmin,
+    }
+    if err := users.Create(&admin); err != nil {
+        return err
+    }
+
+    log.Printf("✅ Usuario administrador %q creado\n", username)
+    return nil
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+
+    "ControlEscolar/models"
+    "ControlEscolar/utils"
+)
+
+// claimsKey es la llave del contexto de Gin donde se guardan los claims del usuario
+const claimsKey = "auth.claims"
+
+// RequireAuth exige un token Bearer válido en el header Authorization
+func RequireAuth(tokens *TokenService) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        header := c.GetHeader("Authorization")
+        scheme, token, found := strings.Cut(header, " ")
+        if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+            utils.RespondWithError(c, http.StatusUnauthorized, utils.CodeAuthRequired, "auth.token_required")
+            c.Abort()
+            return
+        }
+
+        claims, err := tokens.Parse(strings.TrimSpace(token))
+        if err != nil {
+            utils.RespondWithError(c, http.StatusUnauthorized, utils.CodeInvalidToken, "auth.invalid_token")
+            c.Abort()
+            return
+        }
+
+        c.Set(claimsKey, claims)
+        c.Next()
+    }
+}
+
+// RequireRoles permite continuar solo a los usuarios con alguno de los roles indicados
+func RequireRoles(roles ...string) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        claims := CurrentUser(c)
+        if claims == nil || !claims.HasRole(roles...) {
+            utils.RespondWithError(c, http.StatusForbidden, utils.CodeForbidden, "auth.forbidden")
+            c.Abort()
+            return
+        }
+        c.Next()
+    }
+}
+
+// RequireOwnStudent limita a los alumnos al estudiante vinculado a su cuenta,
+// comparándolo con el parámetro de ruta indicado. Los demás roles deben estar en roles.
+func RequireOwnStudent(param string, roles ...string) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        claims := CurrentUser(c)
+        if claims == nil {
+            utils.RespondWithError(c, http.StatusForbidden, utils.CodeForbidden, "auth.forbidden")
+            c.Abort()
+            return
+        }
+
+        if claims.HasRole(roles...) {
+            c.Next()
+            return
+        }
+
+        if claims.Role == models.RoleStudent && claims.StudentID != nil &&
+            c.Param(param) == strconv.Itoa(*claims.StudentID) {
+            c.Next()
+            return
+        }
+
+        utils.RespondWithError(c, http.StatusForbidden, utils.CodeOwnDataOnly, "auth.own_data_only")
+        c.Abort()
+    }
+}
+
+// CurrentUser devuelve los claims del usuario autenticado, o nil si no hay sesión
+func CurrentUser(c *gin.Context) *Claims {
+    value, ok := c.Get(claimsKey)
+    if !ok {
+        return nil
+    }
+    claims, _ := value.(*Claims)
+    return claims
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+    "golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword genera el hash bcrypt de una contraseña
+func HashPassword(password string) (string, error) {
+    hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+    if err != nil {
+        return "", err
+    }
+    return string(hash), nil
+}
+
+// CheckPassword compara una contraseña con su hash bcrypt
+func CheckPassword(hash, password string) bool {
+    return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/golang-jwt/jwt/v5"
+
+    "ControlEscolar/models"
+)
+
+// ErrInvalidToken indica que el token no es válido o ya expiró
+var ErrInvalidToken = errors.New("token inválido o expirado")
+
+// Claims son los datos del usuario incluidos en el JWT
+type Claims struct {
+    UserID    int    `json:"user_id"`
+    Username  string `json:"username"`
+    Role      string `json:"role"`
+    StudentID *int   `json:"student_id,omitempty"`
+    jwt.RegisteredClaims
+}
+
+// HasRole indica si el usuario tiene alguno de los roles indicados
+func (c *Claims) HasRole(roles ...string) bool {
+    for _, role := range roles {
+        if c.Role == role {
+            return true
+        }
+    }
+    return false
+}
+
+// TokenService firma y valida los JWT de la API con HMAC-SHA256
+type TokenService struct {
+    secret []byte
+    ttl    time.Duration
+    issuer string
+}
+
+// NewTokenService crea un TokenService con el secreto y la vigencia indicados
+func NewTokenService(secret []byte, ttl time.Duration) *TokenService {
+    return &TokenService{
+        secret: secret,
+        ttl:    ttl,
+        issuer: "control-escolar-api",
+    }
+}
+
+// Generate emite un token firmado para el usuario y devuelve su fecha de expiración
+func (s *TokenService) Generate(user *models.User) (string, time.Time, error) {
+    now := time.Now()
+    expiresAt := now.Add(s.ttl)
+
+    claims := Claims{
+        UserID:    user.UserID,
+        Username:  user.Username,
+        Role:      user.Role,
+        StudentID: user.StudentID,
+        RegisteredClaims: jwt.RegisteredClaims{
+            Issuer:    s.issuer,
+            Subject:   fmt.Sprint(user.UserID),
+            IssuedAt:  jwt.NewNumericDate(now),
+            ExpiresAt: jwt.NewNumericDate(expiresAt),
+        },
+    }
+
+    token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+    if err != nil {
+        return "", time.Time{}, err
+    }
+    return token, expiresAt, nil
+}
+
+// Parse valida la firma y la vigencia de un token y devuelve sus claims
+func (s *TokenService) Parse(tokenString string) (*Claims, error) {
+    claims := &Claims{}
+    _, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+        return s.secret, nil
+    },
+        jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+        jwt.WithIssuer(s.issuer),
+        jwt.WithExpirationRequired(),
+    )
+    if err != nil {
+        return nil, ErrInvalidToken
+    }
+    return claims, nil
+}
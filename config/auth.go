@@ -0,0 +1,63 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "log"
+    "time"
+)
+
+// Valores de ejemplo de .env.example y de versiones anteriores del repositorio.
+// Son públicos, así que el servidor no arranca si siguen configurados
+var insecureDefaults = map[string]bool{
+    "cambia_este_secreto":    true,
+    "cambia_esta_contraseña": true,
+    "admin12345":             true,
+}
+
+// minJWTSecretBytes es la longitud mínima de JWT_SECRET. Los tokens se firman con HS256, cuya
+// llave debe tener al menos los 32 bytes del hash para no ser más débil que la firma
+const minJWTSecretBytes = 32
+
+// AuthConfig contiene los parámetros de autenticación
+type AuthConfig struct {
+    JWTSecret     []byte
+    TokenTTL      time.Duration
+    AdminUsername string
+    AdminPassword string
+}
+
+// LoadAuthConfig lee la configuración de autenticación desde variables de entorno.
+// Devuelve un error si JWT_SECRET o ADMIN_PASSWORD faltan o conservan un valor de ejemplo, o si
+// JWT_SECRET tiene menos de minJWTSecretBytes bytes
+func LoadAuthConfig() (AuthConfig, error) {
+    secret := getEnv("JWT_SECRET", "")
+    if secret == "" {
+        return AuthConfig{}, errors.New("JWT_SECRET no está configurado")
+    }
+    if insecureDefaults[secret] {
+        return AuthConfig{}, errors.New("JWT_SECRET conserva el valor de ejemplo; configure un secreto propio")
+    }
+    if len(secret) < minJWTSecretBytes {
+        return AuthConfig{}, fmt.Errorf("JWT_SECRET tiene %d bytes y debe tener al menos %d; genere uno con: openssl rand -hex 32", len(secret), minJWTSecretBytes)
+    }
+
+    // ADMIN_PASSWORD solo es obligatorio para crear el administrador inicial (ver auth.EnsureAdmin)
+    password := getEnv("ADMIN_PASSWORD", "")
+    if insecureDefaults[password] {
+        return AuthConfig{}, errors.New("ADMIN_PASSWORD conserva el valor de ejemplo; configure una contraseña propia")
+    }
+
+    ttl, err := time.ParseDuration(getEnv("JWT_TTL", "24h"))
+    if err != nil || ttl <= 0 {
+        log.Println("⚠️  Advertencia: JWT_TTL inválido, se usarán 24h")
+        ttl = 24 * time.Hour
+    }
+
+    return AuthConfig{
+        JWTSecret:     []byte(secret),
+        TokenTTL:      ttl,
+        AdminUsername: getEnv("ADMIN_USERNAME", "admin"),
+        AdminPassword: password,
+    }, nil
+}
@@ -4,41 +4,126 @@ import (
     "fmt"
     "log"
     "os"
-    
+    "strings"
+
+    "github.com/glebarez/sqlite"
     "gorm.io/driver/mysql"
+    "gorm.io/driver/postgres"
     "gorm.io/gorm"
     "gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
 
-// InitDatabase inicializa la conexión a MySQL
-func InitDatabase() {
-    var err error
-    
-    // Configuración de conexión MySQL
-    // Formato: usuario:contraseña@tcp(host:puerto)/nombre_base_datos?charset=utf8mb4&parseTime=True&loc=Local
-    
-    // Obtener credenciales desde variables de entorno (recomendado)
-    dbUser := getEnv("DB_USER", "root")
-    dbPassword := getEnv("DB_PASSWORD", "")
-    dbHost := getEnv("DB_HOST", "localhost")
-    dbPort := getEnv("DB_PORT", "3306")
-    dbName := getEnv("DB_NAME", "control_escolar")
-    
-    dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-        dbUser, dbPassword, dbHost, dbPort, dbName)
-    
-    // Conectar a MySQL
-    DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-        Logger: logger.Default.LogMode(logger.Info),
+// Drivers de base de datos soportados
+const (
+    DriverMySQL    = "mysql"
+    DriverPostgres = "postgres"
+    DriverSQLite   = "sqlite"
+)
+
+// DatabaseConfig contiene los parámetros de conexión a la base de datos
+type DatabaseConfig struct {
+    Driver   string
+    User     string
+    Password string
+    Host     string
+    Port     string
+    Name     string
+    // Path es el archivo de SQLite; ":memory:" crea una base de datos en memoria
+    Path     string
+    LogLevel logger.LogLevel
+}
+
+// LoadDatabaseConfig lee la configuración de la base de datos desde variables de entorno
+func LoadDatabaseConfig() DatabaseConfig {
+    driver := strings.ToLower(getEnv("DB_DRIVER", DriverMySQL))
+
+    defaultPort := "3306"
+    if driver == DriverPostgres {
+        defaultPort = "5432"
+    }
+
+    defaultUser := "root"
+    if driver == DriverPostgres {
+        defaultUser = "postgres"
+    }
+
+    return DatabaseConfig{
+        Driver:   driver,
+        User:     getEnv("DB_USER", defaultUser),
+        Password: getEnv("DB_PASSWORD", ""),
+        Host:     getEnv("DB_HOST", "localhost"),
+        Port:     getEnv("DB_PORT", defaultPort),
+        Name:     getEnv("DB_NAME", "control_escolar"),
+        Path:     getEnv("DB_PATH", "control_escolar.db"),
+        LogLevel: logger.Info,
+    }
+}
+
+// Dialector construye el dialecto de GORM correspondiente al driver configurado
+func (cfg DatabaseConfig) Dialector() (gorm.Dialector, error) {
+    switch cfg.Driver {
+    case DriverMySQL:
+        // Formato: usuario:contraseña@tcp(host:puerto)/nombre_base_datos?charset=utf8mb4&parseTime=True&loc=Local
+        dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+            cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+        return mysql.Open(dsn), nil
+    case DriverPostgres:
+        dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
+            cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port)
+        return postgres.Open(dsn), nil
+    case DriverSQLite:
+        // SQLite no valida llaves foráneas a menos que se active explícitamente.
+        // El driver está escrito en Go puro, así que no requiere CGO
+        return sqlite.Open(cfg.Path + sqliteSeparator(cfg.Path) + "_pragma=foreign_keys(1)"), nil
+    default:
+        return nil, fmt.Errorf("driver de base de datos no soportado: %q (use %s, %s o %s)",
+            cfg.Driver, DriverMySQL, DriverPostgres, DriverSQLite)
+    }
+}
+
+// OpenDatabase abre una conexión con la configuración indicada
+func OpenDatabase(cfg DatabaseConfig) (*gorm.DB, error) {
+    dialector, err := cfg.Dialector()
+    if err != nil {
+        return nil, err
+    }
+
+    db, err := gorm.Open(dialector, &gorm.Config{
+        Logger: logger.Default.LogMode(cfg.LogLevel),
+        // Traduce los errores del driver (p. ej. llaves duplicadas) a errores de GORM
+        TranslateError: true,
     })
-    
     if err != nil {
-        log.Fatal("Error al conectar a la base de datos MySQL:", err)
+        return nil, err
+    }
+
+    if cfg.Driver == DriverSQLite && strings.Contains(cfg.Path, ":memory:") {
+        // Cada conexión a ":memory:" es una base de datos distinta,
+        // así que el pool debe limitarse a una sola conexión
+        sqlDB, err := db.DB()
+        if err != nil {
+            return nil, err
+        }
+        sqlDB.SetMaxOpenConns(1)
+    }
+
+    return db, nil
+}
+
+// InitDatabase inicializa la conexión global usando la configuración del entorno
+func InitDatabase() error {
+    cfg := LoadDatabaseConfig()
+
+    db, err := OpenDatabase(cfg)
+    if err != nil {
+        return fmt.Errorf("error al conectar a la base de datos (%s): %w", cfg.Driver, err)
     }
-    
-    log.Println("✅ Conexión a MySQL establecida exitosamente")
+
+    DB = db
+    log.Printf("✅ Conexión a la base de datos (%s) establecida exitosamente\n", cfg.Driver)
+    return nil
 }
 
 // GetDB retorna la instancia de la base de datos
@@ -53,4 +138,12 @@ func getEnv(key, defaultValue string) string {
         return defaultValue
     }
     return value
-}
\ No newline at end of file
+}
+
+// sqliteSeparator devuelve el carácter para agregar parámetros al DSN de SQLite
+func sqliteSeparator(path string) string {
+    if strings.Contains(path, "?") {
+        return "&"
+    }
+    return "?"
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+    "log"
+    "strconv"
+
+    "ControlEscolar/models"
+)
+
+// GradingConfig contiene los parámetros de evaluación
+type GradingConfig struct {
+    // PassingGrade es la calificación mínima aprobatoria (escala 0-100)
+    PassingGrade float64
+    // MaxAbsencePercentage es el porcentaje máximo de faltas sin justificar en una materia
+    MaxAbsencePercentage float64
+}
+
+// LoadGradingConfig lee la configuración de evaluación desde variables de entorno
+func LoadGradingConfig() GradingConfig {
+    passingGrade := models.DefaultPassingGrade
+    if value := getEnv("PASSING_GRADE", ""); value != "" {
+        parsed, err := strconv.ParseFloat(value, 64)
+        if err != nil || parsed < 0 || parsed > 100 {
+            log.Printf("⚠️  Advertencia: PASSING_GRADE inválido, se usará %.0f\n", passingGrade)
+        } else {
+            passingGrade = parsed
+        }
+    }
+
+    maxAbsencePercentage := models.DefaultMaxAbsencePercentage
+    if value := getEnv("MAX_ABSENCE_PERCENTAGE", ""); value != "" {
+        parsed, err := strconv.ParseFloat(value, 64)
+        if err != nil || parsed < 0 || parsed > 100 {
+            log.Printf("⚠️  Advertencia: MAX_ABSENCE_PERCENTAGE inválido, se usará %.0f\n", maxAbsencePercentage)
+        } else {
+            maxAbsencePercentage = parsed
+        }
+    }
+
+    return GradingConfig{
+        PassingGrade:         passingGrade,
+        MaxAbsencePercentage: maxAbsencePercentage,
+    }
+}
@@ -1,4 +1,5 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -22,33 +23,57 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/grades": {
-            "post": {
-                "description": "Registra una nueva calificación para un estudiante en una materia",
-                "consumes": [
-                    "application/json"
+        "/analytics/distribution": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Devuelve el histograma de calificaciones finales por materia en intervalos de bucket_size puntos, junto con la tasa de aprobación. Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del grupo (PASSING_GRADE si no tiene escala asignada), salvo que se indique passing_grade.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "grades"
+                    "analytics"
                 ],
-                "summary": "Crear una nueva calificación",
+                "summary": "Distribución de calificaciones",
                 "parameters": [
                     {
-                        "description": "Información de la calificación",
-                        "name": "grade",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateGradeRequest"
-                        }
+                        "type": "integer",
+                        "description": "Grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Calificación mínima aprobatoria para todas las materias (por defecto la de la escala de cada materia)",
+                        "name": "passing_grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Tamaño de cada intervalo (1-50)",
+                        "name": "bucket_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -58,7 +83,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.GradeResponse"
+                                            "$ref": "#/definitions/models.GradeDistribution"
                                         }
                                     }
                                 }
@@ -86,33 +111,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/grades/student/{student_id}": {
+        "/analytics/groups": {
             "get": {
-                "description": "Obtiene todas las calificaciones registradas para un estudiante específico",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve, para cada grupo y materia, el número de estudiantes, el promedio, la mínima, la máxima y la tasa de aprobación. Se calcula sobre la calificación final de cada estudiante en la materia (promedio de sus calificaciones). Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del grupo (PASSING_GRADE si no tiene escala asignada), salvo que se indique passing_grade.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "grades"
+                    "analytics"
                 ],
-                "summary": "Obtener todas las calificaciones de un estudiante",
+                "summary": "Promedios por grupo y materia",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del estudiante",
-                        "name": "student_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Calificación mínima aprobatoria para todas las materias (por defecto la de la escala de cada materia)",
+                        "name": "passing_grade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.GradeResponse"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GroupSubjectStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -136,35 +195,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/grades/{grade_id}": {
-            "put": {
-                "description": "Actualiza el valor de una calificación existente",
-                "consumes": [
-                    "application/json"
+        "/analytics/ranking": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Ordena a los estudiantes de mayor a menor promedio de sus calificaciones finales por materia. Los empates comparten posición (1, 2, 2, 4). Las materias reprobadas se cuentan con el valor aprobatorio de la escala de cada materia en el grado escolar del grupo (PASSING_GRADE si no tiene escala asignada), salvo que se indique passing_grade.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "grades"
+                    "analytics"
                 ],
-                "summary": "Actualizar una calificación",
+                "summary": "Ranking de estudiantes por promedio",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la calificación",
-                        "name": "grade_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Grupo",
+                        "name": "group_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Nueva calificación",
-                        "name": "grade",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateGradeRequest"
-                        }
+                        "type": "integer",
+                        "description": "Materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Calificación mínima aprobatoria para todas las materias (por defecto la de la escala de cada materia)",
+                        "name": "passing_grade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -179,7 +248,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.GradeResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StudentRanking"
+                                            }
                                         }
                                     }
                                 }
@@ -205,84 +277,106 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Elimina una calificación del sistema",
+            }
+        },
+        "/assignments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de asignaciones, filtrable por maestro, materia, grupo o periodo",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "grades"
+                    "assignments"
                 ],
-                "summary": "Eliminar una calificación",
+                "summary": "Listar asignaciones",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la calificación",
-                        "name": "grade_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
-                        }
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
+                    {
+                        "enum": [
+                            "assignment_id",
+                            "teacher_id",
+                            "subject_id",
+                            "group_id",
+                            "term_id"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/grades/{grade_id}/student/{student_id}": {
-            "get": {
-                "description": "Obtiene una calificación específica de un estudiante por grade_id y student_id",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "grades"
-                ],
-                "summary": "Obtener calificación específica",
-                "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la calificación",
-                        "name": "grade_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filtrar por maestro",
+                        "name": "teacher_id",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "ID del estudiante",
-                        "name": "student_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filtrar por materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo",
+                        "name": "term_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GradeResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TeachingAssignment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -291,35 +385,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/students": {
-            "get": {
-                "description": "Obtiene la lista completa de estudiantes registrados",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "students"
-                ],
-                "summary": "Listar todos los estudiantes",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Student"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -329,7 +394,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Registra un nuevo estudiante en el sistema",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Asigna el maestro que imparte la materia al grupo durante el periodo (y sus subperiodos). Solo el maestro asignado puede capturar esas calificaciones. El periodo debe ser del mismo ciclo escolar que el grupo.",
                 "consumes": [
                     "application/json"
                 ],
@@ -337,17 +407,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "students"
+                    "assignments"
                 ],
-                "summary": "Crear un nuevo estudiante",
+                "summary": "Asignar un maestro a una materia de un grupo",
                 "parameters": [
                     {
-                        "description": "Información del estudiante",
-                        "name": "student",
+                        "description": "Asignación",
+                        "name": "assignment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Student"
+                            "$ref": "#/definitions/models.AssignmentRequest"
                         }
                     }
                 ],
@@ -363,7 +433,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Student"
+                                            "$ref": "#/definitions/models.TeachingAssignment"
                                         }
                                     }
                                 }
@@ -376,6 +446,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -385,21 +467,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{student_id}": {
-            "get": {
-                "description": "Obtiene la información detallada de un estudiante específico",
+        "/assignments/{assignment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Quita la asignación del maestro; sus calificaciones capturadas se conservan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "students"
+                    "assignments"
                 ],
-                "summary": "Obtener un estudiante por ID",
+                "summary": "Eliminar una asignación",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del estudiante",
-                        "name": "student_id",
+                        "description": "ID de la asignación",
+                        "name": "assignment_id",
                         "in": "path",
                         "required": true
                     }
@@ -408,7 +495,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Student"
+                            "$ref": "#/definitions/utils.SuccessResponse"
                         }
                     },
                     "400": {
@@ -422,37 +509,115 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Actualiza la información de un estudiante existente",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/attendance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Obtiene una página de registros de asistencia, filtrable por grupo, estudiante, materia, estado y rango de fechas",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "students"
+                    "attendance"
                 ],
-                "summary": "Actualizar un estudiante",
+                "summary": "Listar registros de asistencia",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del estudiante",
-                        "name": "student_id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Información actualizada del estudiante",
-                        "name": "student",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Student"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "attendance_id",
+                            "date",
+                            "student_id",
+                            "status"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por estudiante",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Solo las listas diarias de grupo, sin materia",
+                        "name": "daily",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "present",
+                            "absent",
+                            "late",
+                            "justified"
+                        ],
+                        "type": "string",
+                        "description": "Filtrar por estado",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Desde la fecha (AAAA-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hasta la fecha (AAAA-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -461,13 +626,16 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Student"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Attendance"
+                                            }
                                         }
                                     }
                                 }
@@ -480,12 +648,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -493,34 +655,76 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Elimina un estudiante del sistema (también elimina sus calificaciones por CASCADE)",
+            }
+        },
+        "/attendance/roll-call": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra en una sola operación la asistencia de los estudiantes de un grupo (hasta 200) en una fecha. Con subject_id es la asistencia a esa materia y solo puede tomarla el maestro asignado a ella en el grupo; sin subject_id es la lista diaria y solo puede tomarla el titular del grupo. Si un estudiante ya tiene registro en la misma materia y fecha se actualiza. Si algún elemento es rechazado no se guarda ningún registro y la respuesta lista los rechazados. Si otra lista guarda al mismo tiempo el registro de un estudiante en la misma materia y fecha responde 409 y no se guarda ninguno; basta con volver a enviarla.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "students"
+                    "attendance"
                 ],
-                "summary": "Eliminar un estudiante",
+                "summary": "Tomar lista a un grupo",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID del estudiante",
-                        "name": "student_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Grupo, materia, fecha y asistencia de cada estudiante",
+                        "name": "roll",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RollCallRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RollCallResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.RollCallResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -531,6 +735,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -540,9 +750,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/subjects": {
-            "post": {
-                "description": "Registra una nueva materia en el sistema",
+        "/attendance/{attendance_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cambia el estado o las notas de un registro, por ejemplo para justificar una falta. Aplican los mismos permisos que para tomar la lista.",
                 "consumes": [
                     "application/json"
                 ],
@@ -550,23 +765,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "attendance"
                 ],
-                "summary": "Crear una nueva materia",
+                "summary": "Corregir un registro de asistencia",
                 "parameters": [
                     {
-                        "description": "Información de la materia",
-                        "name": "subject",
+                        "type": "integer",
+                        "description": "ID del registro de asistencia",
+                        "name": "attendance_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estado y notas",
+                        "name": "attendance",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Subject"
+                            "$ref": "#/definitions/models.AttendanceUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -576,7 +798,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Subject"
+                                            "$ref": "#/definitions/models.Attendance"
                                         }
                                     }
                                 }
@@ -589,6 +811,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -596,23 +830,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/subjects/{subject_id}": {
-            "get": {
-                "description": "Obtiene la información de una materia específica",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un registro de asistencia capturado por error",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "attendance"
                 ],
-                "summary": "Obtener una materia por ID",
+                "summary": "Eliminar un registro de asistencia",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la materia",
-                        "name": "subject_id",
+                        "description": "ID del registro de asistencia",
+                        "name": "attendance_id",
                         "in": "path",
                         "required": true
                     }
@@ -621,7 +858,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Subject"
+                            "$ref": "#/definitions/utils.SuccessResponse"
                         }
                     },
                     "400": {
@@ -635,11 +872,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Actualiza la información de una materia existente",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Valida usuario y contraseña y emite un JWT firmado",
                 "consumes": [
                     "application/json"
                 ],
@@ -647,24 +892,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "auth"
                 ],
-                "summary": "Actualizar una materia",
+                "summary": "Iniciar sesión",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID de la materia",
-                        "name": "subject_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Información actualizada de la materia",
-                        "name": "subject",
+                        "description": "Credenciales",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Subject"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
@@ -680,7 +918,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Subject"
+                                            "$ref": "#/definitions/models.LoginResponse"
                                         }
                                     }
                                 }
@@ -693,8 +931,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -706,30 +944,154 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Elimina una materia del sistema (también elimina sus calificaciones por CASCADE)",
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve la cuenta asociada al token enviado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "auth"
                 ],
-                "summary": "Eliminar una materia",
+                "summary": "Obtener el usuario autenticado",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de inscripciones, filtrable por estudiante, materia, periodo o grupo del estudiante",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollments"
+                ],
+                "summary": "Listar inscripciones",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la materia",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "enrollment_id",
+                            "student_id",
+                            "subject_id",
+                            "term_id"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por estudiante",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por materia",
                         "name": "subject_id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grupo del estudiante",
+                        "name": "group_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/utils.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Enrollment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -738,12 +1100,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -751,142 +1107,8126 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Inscribe al estudiante en la materia durante el periodo (y sus subperiodos). Solo se pueden registrar calificaciones de materias inscritas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollments"
+                ],
+                "summary": "Inscribir a un estudiante en una materia",
+                "parameters": [
+                    {
+                        "description": "Inscripción",
+                        "name": "enrollment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EnrollmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Enrollment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments/group": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Inscribe en la materia a todos los estudiantes del grupo que todavía no estén inscritos en ese periodo. El periodo debe ser del mismo ciclo escolar que el grupo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollments"
+                ],
+                "summary": "Inscribir a un grupo completo en una materia",
+                "parameters": [
+                    {
+                        "description": "Grupo, materia y periodo",
+                        "name": "enrollment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupEnrollmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GroupEnrollmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina las inscripciones de los estudiantes del grupo en la materia y periodo; los que ya tienen calificaciones conservan la inscripción",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollments"
+                ],
+                "summary": "Dar de baja a un grupo completo de una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del grupo",
+                        "name": "group_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GroupUnenrollmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments/{enrollment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina la inscripción si el estudiante todavía no tiene calificaciones de la materia en el periodo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "enrollments"
+                ],
+                "summary": "Dar de baja a un estudiante de una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la inscripción",
+                        "name": "enrollment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/evaluation-criteria/subject/{subject_id}/term/{term_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene los criterios de evaluación de la materia en el periodo y su peso en la calificación final. Si no tiene criterios la lista está vacía y la calificación se captura directamente.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "evaluation-criteria"
+                ],
+                "summary": "Obtener los criterios de evaluación de una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EvaluationCriteriaResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reemplaza los criterios de evaluación de la materia en el periodo (hasta 20); sus pesos deben sumar 100 y sus nombres no se pueden repetir. Los criterios existentes se identifican por nombre y conservan sus calificaciones; quitar uno que ya tiene calificaciones responde 409, igual que definirlos cuando algún estudiante tiene una calificación capturada directamente en el periodo (hay que eliminarla antes). Desde ese momento la calificación final se calcula con los criterios y se recalcula la de los estudiantes que ya tienen calificaciones por criterio. Los maestros solo pueden definir los criterios de las materias que tienen asignadas en el periodo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "evaluation-criteria"
+                ],
+                "summary": "Definir los criterios de evaluación de una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Criterios y pesos",
+                        "name": "criteria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EvaluationCriteriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EvaluationCriteriaResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina los criterios de evaluación de la materia en el periodo para volver a capturar la calificación directamente. Solo es posible si ningún criterio tiene calificaciones. Los maestros solo pueden eliminar los criterios de las materias que tienen asignadas en el periodo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "evaluation-criteria"
+                ],
+                "summary": "Eliminar los criterios de evaluación de una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grade-locks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de las materias con calificaciones cerradas, filtrable por materia, grupo o periodo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grade-locks"
+                ],
+                "summary": "Listar cierres de calificaciones",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "lock_id",
+                            "subject_id",
+                            "group_id",
+                            "term_id",
+                            "closed_at"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo",
+                        "name": "term_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradeLock"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cierra las calificaciones de la materia en el grupo durante el periodo y sus subperiodos. Mientras estén cerradas, crearlas, modificarlas, eliminarlas o restaurarlas responde 423. Los maestros solo pueden cerrar las materias que tienen asignadas en el grupo. El periodo debe ser del mismo ciclo escolar que el grupo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grade-locks"
+                ],
+                "summary": "Cerrar las calificaciones de una materia",
+                "parameters": [
+                    {
+                        "description": "Materia, grupo y periodo",
+                        "name": "lock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GradeLockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeLock"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grade-locks/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de los cierres y reaperturas registrados, con la cuenta que los hizo, la fecha y el motivo. Los registros se conservan aunque el cierre se reabra.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grade-locks"
+                ],
+                "summary": "Bitácora de cierres de calificaciones",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "event_id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo",
+                        "name": "term_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradeLockEvent"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grade-locks/{lock_id}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina el cierre para que las calificaciones puedan modificarse de nuevo. El motivo es obligatorio y queda en la bitácora de cierres junto con la cuenta que reabrió.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grade-locks"
+                ],
+                "summary": "Reabrir calificaciones cerradas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del cierre",
+                        "name": "lock_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo de la reapertura",
+                        "name": "reopen",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReopenGradeLockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeLock"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra una nueva calificación para un estudiante en una materia durante un periodo. Solo el maestro asignado a la materia en el grupo del estudiante puede registrarla, y el estudiante debe estar inscrito en la materia en el periodo. La calificación se captura en la escala de la materia en el grado escolar del estudiante (de 0 a 100 si no tiene una asignada) y se guarda de 0 a 100. Si la materia tiene criterios de evaluación en el periodo la calificación se calcula con ellos y no se puede capturar directamente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Crear una nueva calificación",
+                "parameters": [
+                    {
+                        "description": "Información de la calificación",
+                        "name": "grade",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateGradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.GradeConflictDetails"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra o actualiza en una sola operación las calificaciones de varios estudiantes (hasta 200) en una materia y periodo. Cada elemento se valida como en la captura individual, incluida la escala de cada estudiante; si alguno es rechazado no se guarda ninguna calificación y la respuesta lista los rechazados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Capturar las calificaciones de varios estudiantes",
+                "parameters": [
+                    {
+                        "description": "Materia, periodo y calificaciones",
+                        "name": "grades",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchGradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BatchGradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.BatchGradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.BatchGradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/components": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra o actualiza la calificación de varios estudiantes (hasta 200) en un criterio de evaluación y recalcula su calificación final en la materia y periodo del criterio como la suma ponderada de sus criterios; los criterios sin capturar cuentan como 0. Cada elemento se valida como en la captura masiva de calificaciones; si alguno es rechazado no se guarda ninguna calificación y la respuesta lista los rechazados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Capturar las calificaciones de un criterio de evaluación",
+                "parameters": [
+                    {
+                        "description": "Criterio y calificaciones",
+                        "name": "scores",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ComponentScoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ComponentScoreResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.BatchGradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.BatchGradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Descarga las calificaciones con los nombres del estudiante, grupo, materia y periodo en CSV, XLSX o NDJSON según el header Accept o el parámetro format. Los registros se leen y escriben por partes, sin cargarlos todos en memoria.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Exportar calificaciones",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Formato; tiene prioridad sobre Accept",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Grupo del estudiante",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/student/{student_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene las calificaciones registradas para un estudiante específico. Con term_id solo devuelve las de ese periodo y sus subperiodos. Las calificaciones eliminadas y las de materias eliminadas solo se incluyen con include_deleted (administradores). En el portal de padres el tutor solo puede consultar a los estudiantes vinculados a su cuenta.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Obtener todas las calificaciones de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Incluir calificaciones eliminadas (solo administradores)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/student/{student_id}/subject/{subject_id}/term/{term_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea la calificación del estudiante en la materia y periodo indicados, o la actualiza si ya existe; si estaba eliminada la restaura con el nuevo valor. Solo el maestro asignado a la materia en el grupo del estudiante puede hacerlo, y el estudiante debe estar inscrito en la materia en el periodo. La calificación se captura en la escala de la materia en el grado escolar del estudiante.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Registrar o actualizar la calificación de un periodo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Calificación",
+                        "name": "grade",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateGradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/student/{student_id}/subject/{subject_id}/term/{term_id}/components": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la calificación del estudiante en cada criterio de evaluación de la materia en el periodo, los puntos que aporta cada uno y la calificación final. Los criterios sin capturar cuentan como 0.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Desglose de la calificación por criterio",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeBreakdown"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/{grade_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza el valor de una calificación existente. Solo el maestro asignado a la materia en el grupo del estudiante puede actualizarla. La calificación se captura en la escala de la materia en el grado escolar del estudiante. El valor anterior, la cuenta que hizo el cambio y el motivo quedan en el historial.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Actualizar una calificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la calificación",
+                        "name": "grade_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nueva calificación",
+                        "name": "grade",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateGradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca una calificación como eliminada; se puede restaurar después. Su historial incluye la eliminación. Un maestro solo puede eliminar las calificaciones de la materia en los grupos y periodos que tiene asignados; no se pueden eliminar calificaciones cerradas ni calculadas con criterios de evaluación.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Eliminar una calificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la calificación",
+                        "name": "grade_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Motivo de la eliminación (máximo 255 caracteres)",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/{grade_id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los cambios de una calificación del más antiguo al más reciente: valor anterior, valor nuevo, cuenta que hizo el cambio, fecha y motivo. El historial se conserva aunque la calificación se elimine.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Historial de una calificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la calificación",
+                        "name": "grade_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradeHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/{grade_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Quita la marca de eliminada de una calificación y registra la restauración en su historial. El estudiante y la materia deben estar activos. Un maestro solo puede restaurar las calificaciones de la materia en los grupos y periodos que tiene asignados; no se pueden restaurar calificaciones cerradas ni de materias calculadas con criterios de evaluación.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Restaurar una calificación eliminada",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la calificación",
+                        "name": "grade_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grades/{grade_id}/student/{student_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una calificación específica de un estudiante por grade_id y student_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Obtener calificación específica",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la calificación",
+                        "name": "grade_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading-scales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de escalas de calificación con sus letras",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Listar escalas de calificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scale_id",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradingScale"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra una escala con su rango, valor aprobatorio, decimales, regla de redondeo y, opcionalmente, letras o valores cualitativos. Si tiene letras, la de menor valor mínimo debe empezar en min_value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Crear una escala de calificación",
+                "parameters": [
+                    {
+                        "description": "Información de la escala",
+                        "name": "scale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GradingScaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradingScale"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading-scales/assignments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de asignaciones de escalas, filtrable por escala, grado escolar o materia",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Listar asignaciones de escalas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "assignment_id",
+                            "scale_id",
+                            "grade_level",
+                            "subject_id"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por escala",
+                        "name": "scale_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grado escolar",
+                        "name": "grade_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por materia",
+                        "name": "subject_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradingScaleAssignment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Asigna la escala a un grado escolar, a una materia o a una materia en un grado escolar. Cada calificación usa la asignación más específica: materia y grado, luego materia, luego grado; sin asignación se usa la escala de 0 a 100.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Asignar una escala de calificación",
+                "parameters": [
+                    {
+                        "description": "Escala, grado escolar y materia",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GradingScaleAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradingScaleAssignment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading-scales/assignments/{assignment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Quita la asignación; las calificaciones afectadas pasan a mostrarse con la siguiente asignación que les aplique o con la escala de 0 a 100",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Quitar una asignación de escala",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la asignación",
+                        "name": "assignment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading-scales/{scale_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Obtener una escala de calificación por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la escala",
+                        "name": "scale_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradingScale"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reemplaza la definición de la escala y sus letras. Las calificaciones se guardan de 0 a 100, así que el cambio se refleja en todas las calificaciones que usan la escala.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Actualizar una escala de calificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la escala",
+                        "name": "scale_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información de la escala",
+                        "name": "scale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GradingScaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GradingScale"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina la escala con sus letras; primero deben quitarse sus asignaciones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading-scales"
+                ],
+                "summary": "Eliminar una escala de calificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la escala",
+                        "name": "scale_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de grupos, filtrable por ciclo escolar, grado o turno",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Listar grupos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "group_id",
+                            "name",
+                            "grade_level",
+                            "school_year"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-2026",
+                        "description": "Filtrar por ciclo escolar",
+                        "name": "school_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grado",
+                        "name": "grade_level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "morning",
+                            "afternoon",
+                            "evening"
+                        ],
+                        "type": "string",
+                        "description": "Filtrar por turno",
+                        "name": "shift",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Group"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un grupo de un ciclo escolar. El nombre se forma con el grado y la sección (p. ej. 5A); el turno por defecto es morning.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Crear un grupo",
+                "parameters": [
+                    {
+                        "description": "Información del grupo",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información de un grupo específico",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Obtener un grupo por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del grupo",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza la información de un grupo existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Actualizar un grupo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del grupo",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información actualizada del grupo",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un grupo que no tenga estudiantes asignados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Eliminar un grupo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del grupo",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de tutores, con búsqueda por nombre o email y filtro por estudiante",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Listar tutores",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "guardian_id",
+                            "name",
+                            "email"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Buscar texto en nombre o email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Solo los tutores del estudiante",
+                        "name": "student_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Guardian"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un padre, madre o tutor. Para que pueda consultar a sus estudiantes en el portal de padres debe vincularse con user_id a una cuenta con rol parent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Crear un tutor",
+                "parameters": [
+                    {
+                        "description": "Información del tutor",
+                        "name": "guardian",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GuardianRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Guardian"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Portal de padres: obtiene el tutor vinculado a la cuenta autenticada con sus estudiantes. Las calificaciones y la asistencia de cada estudiante se consultan en /guardians/me/students/{student_id}/grades y /guardians/me/students/{student_id}/attendance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Mi información de tutor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GuardianResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians/me/students/{student_id}/attendance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cuenta las asistencias, faltas, retardos y faltas justificadas del estudiante, en total y por materia (sin subject_id es la lista diaria del grupo). El porcentaje de faltas considera solo las faltas sin justificar; exceeds_limit indica si supera MAX_ABSENCE_PERCENTAGE. Con term_id solo considera las fechas del periodo; from y to acotan el rango. En el portal de padres el tutor solo puede consultar a los estudiantes vinculados a su cuenta.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Resumen de asistencia de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo a considerar",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Desde la fecha (AAAA-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hasta la fecha (AAAA-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AttendanceSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians/me/students/{student_id}/grades": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene las calificaciones registradas para un estudiante específico. Con term_id solo devuelve las de ese periodo y sus subperiodos. Las calificaciones eliminadas y las de materias eliminadas solo se incluyen con include_deleted (administradores). En el portal de padres el tutor solo puede consultar a los estudiantes vinculados a su cuenta.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grades"
+                ],
+                "summary": "Obtener todas las calificaciones de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Incluir calificaciones eliminadas (solo administradores)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GradeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians/{guardian_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información de un tutor con los estudiantes que tiene vinculados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Obtener un tutor por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del tutor",
+                        "name": "guardian_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GuardianResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza la información de un tutor existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Actualizar un tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del tutor",
+                        "name": "guardian_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información actualizada del tutor",
+                        "name": "guardian",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GuardianRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Guardian"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un tutor junto con sus vínculos con estudiantes; su cuenta de acceso se conserva",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Eliminar un tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del tutor",
+                        "name": "guardian_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians/{guardian_id}/students": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Vincula al tutor con un estudiante indicando el parentesco. Un estudiante puede tener varios tutores y un tutor varios estudiantes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Vincular un estudiante a un tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del tutor",
+                        "name": "guardian_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estudiante y parentesco",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GuardianStudentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GuardianStudent"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardians/{guardian_id}/students/{student_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Desvincular un estudiante de un tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del tutor",
+                        "name": "guardian_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de estudiantes, con filtros por grupo o texto y orden configurable. Los estudiantes eliminados solo se incluyen con include_deleted (administradores).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Listar estudiantes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "student_id",
+                            "name",
+                            "group",
+                            "group_id",
+                            "email"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden (group ordena por el nombre del grupo)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "5A",
+                        "description": "Filtrar por el nombre exacto del grupo",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Buscar texto en nombre o email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Incluir estudiantes eliminados (solo administradores)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Student"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un nuevo estudiante en el sistema dentro de un grupo existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Crear un nuevo estudiante",
+                "parameters": [
+                    {
+                        "description": "Información del estudiante",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Student"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Student"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.StudentConflictDetails"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Descarga los estudiantes en CSV, XLSX o NDJSON según el header Accept o el parámetro format. Los registros se leen y escriben por partes, sin cargarlos todos en memoria.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Exportar estudiantes",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Formato; tiene prioridad sobre Accept",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Grupo",
+                        "name": "group_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea estudiantes a partir de un archivo .csv o .xlsx con las columnas name, email y group_id en el encabezado. Cada fila se valida con las mismas reglas que al crear un estudiante; si alguna tiene errores no se importa ninguna. Con dry_run=true solo se valida el archivo.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Importar estudiantes desde CSV o Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Archivo .csv o .xlsx (máximo 5 MB y 5000 filas)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validar sin guardar",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{student_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información detallada de un estudiante específico",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Obtener un estudiante por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Student"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza la información de un estudiante existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Actualizar un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información actualizada del estudiante",
+                        "name": "student",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Student"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Student"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.StudentConflictDetails"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca un estudiante como eliminado. Sus calificaciones se conservan, pero dejan de aparecer en listados, estadísticas y exportaciones hasta que se restaure.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Eliminar un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{student_id}/attendance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cuenta las asistencias, faltas, retardos y faltas justificadas del estudiante, en total y por materia (sin subject_id es la lista diaria del grupo). El porcentaje de faltas considera solo las faltas sin justificar; exceeds_limit indica si supera MAX_ABSENCE_PERCENTAGE. Con term_id solo considera las fechas del periodo; from y to acotan el rango. En el portal de padres el tutor solo puede consultar a los estudiantes vinculados a su cuenta.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Resumen de asistencia de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo a considerar",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Desde la fecha (AAAA-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hasta la fecha (AAAA-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AttendanceSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{student_id}/guardians": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene los tutores de un estudiante con su parentesco",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Tutores de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StudentGuardian"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{student_id}/report-card": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Genera en PDF la boleta con el nombre y grupo del estudiante, la calificación de cada materia, el promedio y si aprueba, junto con el porcentaje de faltas de cada materia. Con term_id solo considera ese periodo y sus parciales, y la asistencia entre sus fechas; si una materia tiene varias calificaciones se promedian. Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del estudiante (PASSING_GRADE si no tiene escala asignada). Las calificaciones, el promedio y el mínimo aprobatorio se imprimen en la escala de las materias; si usan escalas distintas el promedio se imprime de 0 a 100.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Generar la boleta de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo de la boleta (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{student_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Quita la marca de eliminado de un estudiante; sus calificaciones vuelven a estar visibles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "students"
+                ],
+                "summary": "Restaurar un estudiante eliminado",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Student"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{student_id}/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Calcula el promedio, la mínima, la máxima y el número de materias reprobadas a partir de la calificación final de cada materia (promedio de sus calificaciones). Si las materias tienen créditos también devuelve el promedio ponderado por créditos. Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del estudiante (PASSING_GRADE si no tiene escala asignada) e incluye su porcentaje de faltas y si supera MAX_ABSENCE_PERCENTAGE. Con term_id solo considera ese periodo y sus parciales, y la asistencia entre sus fechas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Obtener estadísticas de un estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estudiante",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Periodo a considerar (incluye sus parciales)",
+                        "name": "term_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.StudentSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de materias con búsqueda por nombre y, opcionalmente, conteos de estudiantes y calificaciones. Las materias eliminadas solo se incluyen con include_deleted (administradores).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Listar materias",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "subject_id",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Buscar texto en el nombre",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Incluir conteos de estudiantes inscritos y calificaciones",
+                        "name": "include_counts",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Incluir materias eliminadas (solo administradores)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SubjectListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra una nueva materia en el sistema",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Crear una nueva materia",
+                "parameters": [
+                    {
+                        "description": "Información de la materia",
+                        "name": "subject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Subject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Subject"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.SubjectConflictDetails"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Descarga las materias en CSV, XLSX o NDJSON según el header Accept o el parámetro format",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Exportar materias",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Formato; tiene prioridad sobre Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects/{subject_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información de una materia específica",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Obtener una materia por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Subject"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza la información de una materia existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Actualizar una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información actualizada de la materia",
+                        "name": "subject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Subject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Subject"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/models.SubjectConflictDetails"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca una materia como eliminada. Sus calificaciones se conservan, pero dejan de aparecer en listados, estadísticas y exportaciones hasta que se restaure.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Eliminar una materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects/{subject_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Quita la marca de eliminada de una materia; sus calificaciones vuelven a estar visibles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Restaurar una materia eliminada",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la materia",
+                        "name": "subject_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Subject"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de maestros, con búsqueda por nombre o email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Listar maestros",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "teacher_id",
+                            "name",
+                            "email"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Buscar texto en nombre o email",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Teacher"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un maestro. Para que pueda capturar calificaciones debe vincularse con user_id a una cuenta con rol teacher.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Crear un maestro",
+                "parameters": [
+                    {
+                        "description": "Información del maestro",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeacherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Teacher"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{teacher_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información de un maestro específico",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Obtener un maestro por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del maestro",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Teacher"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza la información de un maestro existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Actualizar un maestro",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del maestro",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información actualizada del maestro",
+                        "name": "teacher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeacherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Teacher"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un maestro junto con sus asignaciones; los grupos de los que era titular quedan sin titular",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teachers"
+                ],
+                "summary": "Eliminar un maestro",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del maestro",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de periodos, filtrable por ciclo escolar, tipo o periodo padre",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "terms"
+                ],
+                "summary": "Listar periodos académicos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "term_id",
+                            "name",
+                            "school_year",
+                            "start_date"
+                        ],
+                        "type": "string",
+                        "description": "Campo de orden",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Dirección del orden",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-2026",
+                        "description": "Filtrar por ciclo escolar",
+                        "name": "school_year",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "school_year",
+                            "semester",
+                            "partial"
+                        ],
+                        "type": "string",
+                        "description": "Filtrar por tipo",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filtrar por periodo padre",
+                        "name": "parent_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Term"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un ciclo escolar, un semestre (dentro de un ciclo) o un parcial (dentro de un semestre)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "terms"
+                ],
+                "summary": "Crear un periodo académico",
+                "parameters": [
+                    {
+                        "description": "Información del periodo",
+                        "name": "term",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Term"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{term_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene la información de un periodo específico",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "terms"
+                ],
+                "summary": "Obtener un periodo académico por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Term"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza la información de un periodo existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "terms"
+                ],
+                "summary": "Actualizar un periodo académico",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Información actualizada del periodo",
+                        "name": "term",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Term"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un periodo que no tenga subperiodos ni calificaciones registradas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "terms"
+                ],
+                "summary": "Eliminar un periodo académico",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del periodo",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una página de cuentas registradas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Listar cuentas de usuario",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (desde 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Registros por página (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra una cuenta con rol admin, teacher, student o parent. Las cuentas de alumno deben indicar student_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Crear una cuenta de usuario",
+                "parameters": [
+                    {
+                        "description": "Información de la cuenta",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "models.AssignmentRequest": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "subject_id",
+                "teacher_id",
+                "term_id"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "models.Attendance": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-10-17T00:00:00Z"
+                },
+                "group_id": {
+                    "description": "GroupID es el grupo en el que se tomó la lista",
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Presentó justificante médico"
+                },
+                "recorded_by": {
+                    "description": "RecordedBy es la cuenta que tomó la lista o modificó el registro por última vez; el nombre de\nusuario se guarda aparte para conservarlo aunque la cuenta se elimine",
+                    "type": "integer",
+                    "example": 2
+                },
+                "recorded_by_name": {
+                    "type": "string",
+                    "example": "maestra.lopez"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "absent"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AttendanceSummary": {
+            "type": "object",
+            "properties": {
+                "absence_percentage": {
+                    "type": "number",
+                    "example": 10
+                },
+                "absent": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "exceeds_limit": {
+                    "description": "ExceedsLimit indica si el porcentaje de faltas supera el máximo permitido",
+                    "type": "boolean",
+                    "example": false
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-08-25"
+                },
+                "justified": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "late": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "max_absence_percentage": {
+                    "type": "number",
+                    "example": 20
+                },
+                "present": {
+                    "type": "integer",
+                    "example": 33
+                },
+                "student": {
+                    "$ref": "#/definitions/models.StudentBasic"
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubjectAttendance"
+                    }
+                },
+                "term": {
+                    "$ref": "#/definitions/models.TermBasic"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-10-10"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "models.AttendanceUpdateRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Presentó justificante médico"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "present",
+                        "absent",
+                        "late",
+                        "justified"
+                    ],
+                    "example": "justified"
+                }
+            }
+        },
+        "models.BatchGradeFailure": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code es el código estable del motivo del rechazo, el mismo de la captura individual",
+                    "type": "string",
+                    "example": "NOT_ENROLLED"
+                },
+                "index": {
+                    "description": "Index es la posición del elemento en grades, desde 0",
+                    "type": "integer",
+                    "example": 3
+                },
+                "message": {
+                    "type": "string",
+                    "example": "El estudiante no está inscrito en la materia en ese periodo"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "models.BatchGradeItem": {
+            "type": "object",
+            "required": [
+                "grade",
+                "student_id"
+            ],
+            "properties": {
+                "grade": {
+                    "description": "Grade es un puntero para que required acepte 0 y solo rechace el campo ausente",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 95.5
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.BatchGradeRequest": {
+            "type": "object",
+            "required": [
+                "grades",
+                "subject_id",
+                "term_id"
+            ],
+            "properties": {
+                "grades": {
+                    "description": "Grades se valida elemento por elemento para reportar cada falla por separado",
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeItem"
+                    }
+                },
+                "reason": {
+                    "description": "Reason es el motivo que queda en el historial de cada calificación modificada",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Captura del primer parcial"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "models.BatchGradeResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeResult"
+                    }
+                },
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeFailure"
+                    }
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeResult"
+                    }
+                }
+            }
+        },
+        "models.BatchGradeResult": {
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "type": "number",
+                    "example": 95.5
+                },
+                "grade_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "scaled": {
+                    "$ref": "#/definitions/models.ScaledGrade"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ComponentBreakdown": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "type": "number",
+                    "example": 44
+                },
+                "criterion_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Examen"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 88
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "models.ComponentScore": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "criterion_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recorded_by": {
+                    "description": "RecordedBy es la cuenta que capturó la calificación por última vez; el nombre de usuario se\nguarda aparte para conservarlo aunque la cuenta se elimine",
+                    "type": "integer",
+                    "example": 2
+                },
+                "recorded_by_name": {
+                    "type": "string",
+                    "example": "maestra.lopez"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 88.5
+                },
+                "score_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "student_id": {
+                    "description": "Un estudiante tiene a lo sumo una calificación por criterio",
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComponentScoreRequest": {
+            "type": "object",
+            "required": [
+                "criterion_id",
+                "scores"
+            ],
+            "properties": {
+                "criterion_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "reason": {
+                    "description": "Reason es el motivo que queda en el historial de cada calificación final modificada",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Captura del examen del primer parcial"
+                },
+                "scores": {
+                    "description": "Scores se valida elemento por elemento para reportar cada falla por separado; como en la\ncaptura masiva, una calificación de 0 es válida y solo se rechaza la calificación ausente",
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeItem"
+                    }
+                }
+            }
+        },
+        "models.ComponentScoreResponse": {
+            "type": "object",
+            "properties": {
+                "criterion": {
+                    "$ref": "#/definitions/models.EvaluationCriterion"
+                },
+                "grades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeResult"
+                    }
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComponentScore"
+                    }
+                }
+            }
+        },
         "models.CreateGradeRequest": {
             "type": "object",
             "required": [
-                "grade",
-                "student_id",
-                "subject_id"
+                "grade",
+                "student_id",
+                "subject_id",
+                "term_id"
+            ],
+            "properties": {
+                "grade": {
+                    "description": "Grade se captura en la escala de la materia y el grado escolar del estudiante.\nEs un puntero para que required acepte 0 y solo rechace el campo ausente",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 95.5
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "models.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "secreto123"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "teacher",
+                        "student",
+                        "parent"
+                    ],
+                    "example": "teacher"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "maestra.lopez"
+                }
+            }
+        },
+        "models.DistributionBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "from": {
+                    "type": "number",
+                    "example": 80
+                },
+                "to": {
+                    "type": "number",
+                    "example": 90
+                }
+            }
+        },
+        "models.Enrollment": {
+            "type": "object",
+            "properties": {
+                "enrollment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "student_id": {
+                    "description": "Un estudiante se inscribe una sola vez en cada materia por periodo",
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.EnrollmentRequest": {
+            "type": "object",
+            "required": [
+                "student_id",
+                "subject_id",
+                "term_id"
+            ],
+            "properties": {
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "models.EvaluationCriteriaRequest": {
+            "type": "object",
+            "required": [
+                "criteria"
+            ],
+            "properties": {
+                "criteria": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.EvaluationCriterionItem"
+                    }
+                },
+                "reason": {
+                    "description": "Reason es el motivo que queda en el historial de las calificaciones recalculadas",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Se agregó el proyecto final"
+                }
+            }
+        },
+        "models.EvaluationCriteriaResponse": {
+            "type": "object",
+            "properties": {
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EvaluationCriterion"
+                    }
+                },
+                "grades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchGradeResult"
+                    }
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.EvaluationCriterion": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "criterion_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Examen"
+                },
+                "subject_id": {
+                    "description": "Un criterio no se repite en la misma materia y periodo",
+                    "type": "integer",
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Weight es el porcentaje de la calificación final que aporta el criterio",
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "models.EvaluationCriterionItem": {
+            "type": "object",
+            "required": [
+                "name",
+                "weight"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Examen"
+                },
+                "weight": {
+                    "type": "number",
+                    "maximum": 100,
+                    "example": 50
+                }
+            }
+        },
+        "models.GradeBreakdown": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComponentBreakdown"
+                    }
+                },
+                "grade": {
+                    "description": "Grade es la calificación final; nula si todavía no se captura ningún criterio",
+                    "type": "number",
+                    "example": 86.5
+                },
+                "student": {
+                    "$ref": "#/definitions/models.StudentBasic"
+                },
+                "subject": {
+                    "$ref": "#/definitions/models.SubjectBasic"
+                },
+                "term": {
+                    "$ref": "#/definitions/models.TermBasic"
+                }
+            }
+        },
+        "models.GradeConflictDetails": {
+            "type": "object",
+            "properties": {
+                "grade_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "models.GradeDistribution": {
+            "type": "object",
+            "properties": {
+                "bucket_size": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DistributionBucket"
+                    }
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pass_rate": {
+                    "type": "number",
+                    "example": 89.29
+                },
+                "passed_count": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "passing_grade": {
+                    "description": "PassingGrade es el passing_grade indicado o, sin él, el de las materias sin escala asignada",
+                    "type": "number",
+                    "example": 60
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 168
+                }
+            }
+        },
+        "models.GradeHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "changed_at": {
+                    "type": "string",
+                    "example": "2025-10-14T16:30:00Z"
+                },
+                "changed_by": {
+                    "description": "ChangedBy es la cuenta que hizo el cambio; el nombre de usuario se guarda aparte para\nconservarlo aunque la cuenta se elimine",
+                    "type": "integer",
+                    "example": 2
+                },
+                "changed_by_name": {
+                    "type": "string",
+                    "example": "maestra.lopez"
+                },
+                "grade_id": {
+                    "description": "GradeID no tiene llave foránea para que el historial se conserve al eliminar la calificación",
+                    "type": "integer",
+                    "example": 7
+                },
+                "history_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "new_grade": {
+                    "type": "number",
+                    "example": 82.5
+                },
+                "old_grade": {
+                    "description": "OldGrade es nulo al crear o restaurar la calificación y NewGrade al eliminarla",
+                    "type": "number",
+                    "example": 75
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Revisión del examen solicitada por el tutor"
+                }
+            }
+        },
+        "models.GradeLock": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "2025-10-17T18:00:00Z"
+                },
+                "closed_by": {
+                    "description": "ClosedBy es la cuenta que cerró las calificaciones; el nombre de usuario se guarda aparte\npara conservarlo aunque la cuenta se elimine",
+                    "type": "integer",
+                    "example": 2
+                },
+                "closed_by_name": {
+                    "type": "string",
+                    "example": "maestra.lopez"
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lock_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_id": {
+                    "description": "Una materia de un grupo se cierra una sola vez por periodo",
+                    "type": "integer",
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.GradeLockEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "reopen"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-20T09:15:00Z"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lock_id": {
+                    "description": "LockID y la materia, grupo y periodo no tienen llave foránea para que la bitácora se\nconserve al reabrir o al eliminar los registros",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Corrección de la calificación de un examen extraordinario"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "models.GradeLockRequest": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "subject_id",
+                "term_id"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Calificaciones entregadas a control escolar"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "models.GradeResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt solo aparece en las calificaciones eliminadas, que se listan con include_deleted",
+                    "type": "string",
+                    "example": "2025-10-14T16:30:00Z"
+                },
+                "grade": {
+                    "type": "number",
+                    "example": 95.5
+                },
+                "grade_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "scaled": {
+                    "description": "Scaled es la calificación en la escala de la materia y el grado escolar del estudiante",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ScaledGrade"
+                        }
+                    ]
+                },
+                "student": {
+                    "$ref": "#/definitions/models.StudentBasic"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject": {
+                    "$ref": "#/definitions/models.SubjectBasic"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "term": {
+                    "$ref": "#/definitions/models.TermBasic"
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.GradingScale": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScaleLetter"
+                    }
+                },
+                "max_value": {
+                    "type": "number",
+                    "example": 10
+                },
+                "min_value": {
+                    "type": "number",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Primaria 5-10"
+                },
+                "passing_value": {
+                    "type": "number",
+                    "example": 6
+                },
+                "rounding": {
+                    "type": "string",
+                    "example": "half_up"
+                },
+                "scale_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GradingScaleAssignment": {
+            "type": "object",
+            "properties": {
+                "assignment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "grade_level": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "scale_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.GradingScaleAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "scale_id"
+            ],
+            "properties": {
+                "grade_level": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "scale_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.GradingScaleRequest": {
+            "type": "object",
+            "required": [
+                "max_value",
+                "name"
+            ],
+            "properties": {
+                "decimals": {
+                    "type": "integer",
+                    "maximum": 2,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "letters": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/models.ScaleLetterRequest"
+                    }
+                },
+                "max_value": {
+                    "type": "number",
+                    "maximum": 100,
+                    "example": 10
+                },
+                "min_value": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "Primaria 5-10"
+                },
+                "passing_value": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 6
+                },
+                "rounding": {
+                    "description": "Rounding es half_up si se omite",
+                    "type": "string",
+                    "enum": [
+                        "half_up",
+                        "down",
+                        "up"
+                    ],
+                    "example": "half_up"
+                }
+            }
+        },
+        "models.Group": {
+            "type": "object",
+            "properties": {
+                "grade_level": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "homeroom_teacher_id": {
+                    "description": "HomeroomTeacherID es el maestro titular del grupo",
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "description": "Name se deriva del grado y la sección (p. ej. \"5A\")",
+                    "type": "string",
+                    "example": "5A"
+                },
+                "school_year": {
+                    "description": "Un ciclo escolar no puede tener dos grupos con el mismo grado, sección y turno",
+                    "type": "string",
+                    "example": "2025-2026"
+                },
+                "section": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "shift": {
+                    "type": "string",
+                    "example": "morning"
+                }
+            }
+        },
+        "models.GroupEnrollmentRequest": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "subject_id",
+                "term_id"
+            ],
+            "properties": {
+                "group_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "models.GroupEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "already_enrolled": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "enrolled": {
+                    "type": "integer",
+                    "example": 28
+                }
+            }
+        },
+        "models.GroupRequest": {
+            "type": "object",
+            "required": [
+                "grade_level",
+                "school_year",
+                "section"
+            ],
+            "properties": {
+                "grade_level": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 5
+                },
+                "homeroom_teacher_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "school_year": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 4,
+                    "example": "2025-2026"
+                },
+                "section": {
+                    "type": "string",
+                    "maxLength": 5,
+                    "example": "A"
+                },
+                "shift": {
+                    "type": "string",
+                    "enum": [
+                        "morning",
+                        "afternoon",
+                        "evening"
+                    ],
+                    "example": "morning"
+                }
+            }
+        },
+        "models.GroupSubjectStats": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 81.25
+                },
+                "group": {
+                    "type": "string",
+                    "example": "5A"
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max": {
+                    "type": "number",
+                    "example": 100
+                },
+                "min": {
+                    "type": "number",
+                    "example": 45
+                },
+                "pass_rate": {
+                    "type": "number",
+                    "example": 89.29
+                },
+                "passed_count": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "student_count": {
+                    "type": "integer",
+                    "example": 28
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matemáticas"
+                }
+            }
+        },
+        "models.GroupUnenrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "unenrolled": {
+                    "type": "integer",
+                    "example": 28
+                },
+                "with_grades": {
+                    "description": "WithGrades son los estudiantes que conservan la inscripción por tener calificaciones",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Guardian": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "rosa.martinez@correo.com"
+                },
+                "guardian_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Rosa Martínez"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "5551234567"
+                },
+                "user_id": {
+                    "description": "UserID vincula al tutor con su cuenta de acceso con rol parent; sin cuenta no puede\nconsultar las calificaciones ni la asistencia de sus estudiantes",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.GuardianChild": {
+            "type": "object",
+            "properties": {
+                "relationship": {
+                    "type": "string",
+                    "example": "mother"
+                },
+                "student": {
+                    "$ref": "#/definitions/models.StudentBasic"
+                }
+            }
+        },
+        "models.GuardianRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "rosa.martinez@correo.com"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Rosa Martínez"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "5551234567"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5
+                }
+            }
+        },
+        "models.GuardianResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "rosa.martinez@correo.com"
+                },
+                "guardian_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Rosa Martínez"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "5551234567"
+                },
+                "students": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GuardianChild"
+                    }
+                },
+                "user_id": {
+                    "description": "UserID vincula al tutor con su cuenta de acceso con rol parent; sin cuenta no puede\nconsultar las calificaciones ni la asistencia de sus estudiantes",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.GuardianStudent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "guardian_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "mother"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.GuardianStudentRequest": {
+            "type": "object",
+            "required": [
+                "relationship",
+                "student_id"
+            ],
+            "properties": {
+                "relationship": {
+                    "type": "string",
+                    "enum": [
+                        "mother",
+                        "father",
+                        "guardian",
+                        "other"
+                    ],
+                    "example": "mother"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "description": "Imported es el número de registros creados; siempre es 0 en modo de prueba o si hubo errores",
+                    "type": "integer",
+                    "example": 0
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "valid_rows": {
+                    "type": "integer",
+                    "example": 118
+                }
+            }
+        },
+        "models.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "ya existe un estudiante con ese email"
+                },
+                "row": {
+                    "description": "Row es el número de fila en el archivo; el encabezado es la fila 1",
+                    "type": "integer",
+                    "example": 4
+                },
+                "value": {
+                    "type": "string",
+                    "example": "maria.garcia@escuela.com"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "secreto123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "maestra.lopez"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "integer",
+                    "example": 1767225600
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.ReopenGradeLockRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Corrección de la calificación de un examen extraordinario"
+                }
+            }
+        },
+        "models.RollCallFailure": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "STUDENT_NOT_IN_GROUP"
+                },
+                "index": {
+                    "description": "Index es la posición del elemento en records, desde 0",
+                    "type": "integer",
+                    "example": 2
+                },
+                "message": {
+                    "type": "string",
+                    "example": "El estudiante no pertenece al grupo"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "models.RollCallItem": {
+            "type": "object",
+            "required": [
+                "status",
+                "student_id"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": ""
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "present",
+                        "absent",
+                        "late",
+                        "justified"
+                    ],
+                    "example": "absent"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.RollCallRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "group_id",
+                "records"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-10-17"
+                },
+                "group_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "records": {
+                    "description": "Records se valida elemento por elemento para reportar cada falla por separado",
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.RollCallItem"
+                    }
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.RollCallResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attendance"
+                    }
+                },
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RollCallFailure"
+                    }
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attendance"
+                    }
+                }
+            }
+        },
+        "models.ScaleLetter": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Excelente"
+                },
+                "letter": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "min_value": {
+                    "type": "number",
+                    "example": 90
+                }
+            }
+        },
+        "models.ScaleLetterRequest": {
+            "type": "object",
+            "required": [
+                "letter"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Excelente"
+                },
+                "letter": {
+                    "type": "string",
+                    "maxLength": 5,
+                    "example": "A"
+                },
+                "min_value": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 90
+                }
+            }
+        },
+        "models.ScaledGrade": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Muy bien"
+                },
+                "letter": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "passed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "scale": {
+                    "type": "string",
+                    "example": "Primaria 5-10"
+                },
+                "scale_id": {
+                    "description": "ScaleID es nulo con la escala predeterminada de 0 a 100",
+                    "type": "integer",
+                    "example": 1
+                },
+                "value": {
+                    "type": "number",
+                    "example": 8.5
+                }
+            }
+        },
+        "models.Student": {
+            "type": "object",
+            "required": [
+                "email",
+                "group_id",
+                "name"
+            ],
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt marca al estudiante como eliminado sin borrar sus calificaciones; se ignora al crear o actualizar",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "maria.garcia@escuela.com"
+                },
+                "group": {
+                    "description": "Group se incluye en las respuestas; se ignora al crear o actualizar",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Group"
+                        }
+                    ]
+                },
+                "group_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "María García"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.StudentBasic": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "maria@escuela.com"
+                },
+                "group": {
+                    "type": "string",
+                    "example": "5A"
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "María García"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.StudentConflictDetails": {
+            "type": "object",
+            "properties": {
+                "student_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.StudentGuardian": {
+            "type": "object",
+            "properties": {
+                "guardian": {
+                    "$ref": "#/definitions/models.Guardian"
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "mother"
+                }
+            }
+        },
+        "models.StudentRanking": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 94.5
+                },
+                "failed_subjects": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "group": {
+                    "type": "string",
+                    "example": "5A"
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_count": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.StudentSummary": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 84.3
+                },
+                "failed_subjects": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max": {
+                    "type": "number",
+                    "example": 98.5
+                },
+                "max_absence_percentage": {
+                    "type": "number",
+                    "example": 20
+                },
+                "min": {
+                    "type": "number",
+                    "example": 58
+                },
+                "passing_grade": {
+                    "description": "PassingGrade es la calificación aprobatoria de las materias sin escala asignada",
+                    "type": "number",
+                    "example": 60
+                },
+                "scaled_average": {
+                    "description": "ScaledAverage es el promedio de las calificaciones en la escala de las materias; es nulo si\nno hay calificaciones o si las materias usan escalas distintas",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ScaledGrade"
+                        }
+                    ]
+                },
+                "student": {
+                    "$ref": "#/definitions/models.StudentBasic"
+                },
+                "subject_count": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "subjects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubjectSummary"
+                    }
+                },
+                "subjects_over_absence_limit": {
+                    "description": "SubjectsOverAbsenceLimit es el número de materias con más faltas de las permitidas",
+                    "type": "integer",
+                    "example": 0
+                },
+                "term": {
+                    "$ref": "#/definitions/models.TermBasic"
+                },
+                "total_credits": {
+                    "type": "number",
+                    "example": 40
+                },
+                "weighted_average": {
+                    "type": "number",
+                    "example": 86.1
+                }
+            }
+        },
+        "models.Subject": {
+            "type": "object",
+            "required": [
+                "name"
             ],
             "properties": {
-                "grade": {
+                "credits": {
+                    "description": "Credits pondera la materia en el promedio; 0 indica que no tiene créditos asignados",
                     "type": "number",
-                    "maximum": 100,
+                    "maximum": 99,
                     "minimum": 0,
-                    "example": 95.5
+                    "example": 8
                 },
-                "student_id": {
-                    "type": "integer",
-                    "minimum": 1,
-                    "example": 1
+                "deleted_at": {
+                    "description": "DeletedAt marca la materia como eliminada sin borrar sus calificaciones; se ignora al crear o actualizar",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Matemáticas"
                 },
                 "subject_id": {
                     "type": "integer",
-                    "minimum": 1,
                     "example": 1
                 }
             }
         },
-        "models.GradeResponse": {
+        "models.SubjectAttendance": {
             "type": "object",
             "properties": {
-                "grade": {
+                "absence_percentage": {
                     "type": "number",
-                    "example": 95.5
+                    "example": 10
                 },
-                "grade_id": {
+                "absent": {
                     "type": "integer",
-                    "example": 1
+                    "example": 4
                 },
-                "student": {
-                    "$ref": "#/definitions/models.StudentBasic"
+                "exceeds_limit": {
+                    "description": "ExceedsLimit indica si el porcentaje de faltas supera el máximo permitido",
+                    "type": "boolean",
+                    "example": false
                 },
-                "student_id": {
+                "justified": {
                     "type": "integer",
                     "example": 1
                 },
-                "subject": {
-                    "$ref": "#/definitions/models.SubjectBasic"
+                "late": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matemáticas"
+                },
+                "present": {
+                    "type": "integer",
+                    "example": 33
                 },
                 "subject_id": {
                     "type": "integer",
                     "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 40
                 }
             }
         },
-        "models.Student": {
+        "models.SubjectBasic": {
             "type": "object",
-            "required": [
-                "email",
-                "group",
-                "name"
-            ],
             "properties": {
-                "email": {
+                "name": {
                     "type": "string",
-                    "example": "maria.garcia@escuela.com"
+                    "example": "Matemáticas"
                 },
-                "group": {
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.SubjectConflictDetails": {
+            "type": "object",
+            "properties": {
+                "subject_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.SubjectCounts": {
+            "type": "object",
+            "properties": {
+                "grade_count": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "student_count": {
+                    "type": "integer",
+                    "example": 32
+                }
+            }
+        },
+        "models.SubjectListItem": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "$ref": "#/definitions/models.SubjectCounts"
+                },
+                "credits": {
+                    "type": "number",
+                    "example": 8
+                },
+                "deleted_at": {
+                    "description": "DeletedAt solo aparece en las materias eliminadas, que se listan con include_deleted",
                     "type": "string",
-                    "maxLength": 10,
-                    "minLength": 1,
-                    "example": "5A"
+                    "example": "2025-10-14T16:30:00Z"
                 },
                 "name": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2,
-                    "example": "María García"
+                    "example": "Matemáticas"
                 },
-                "student_id": {
+                "subject_id": {
                     "type": "integer",
                     "example": 1
                 }
             }
         },
-        "models.StudentBasic": {
+        "models.SubjectSummary": {
             "type": "object",
             "properties": {
-                "email": {
+                "absence_percentage": {
+                    "description": "AbsencePercentage es nulo si no hay asistencia registrada en la materia",
+                    "type": "number",
+                    "example": 12.5
+                },
+                "credits": {
+                    "type": "number",
+                    "example": 8
+                },
+                "exceeds_absence_limit": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "grade": {
+                    "type": "number",
+                    "example": 85.5
+                },
+                "grade_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
                     "type": "string",
-                    "example": "maria@escuela.com"
+                    "example": "Matemáticas"
                 },
-                "group": {
+                "passed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "passing_grade": {
+                    "description": "PassingGrade es el valor aprobatorio de la escala de la materia, de 0 a 100",
+                    "type": "number",
+                    "example": 60
+                },
+                "passing_value": {
+                    "description": "PassingValue es el valor aprobatorio en la escala de la materia",
+                    "type": "number",
+                    "example": 6
+                },
+                "scaled": {
+                    "description": "Scaled es la calificación en la escala de la materia",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ScaledGrade"
+                        }
+                    ]
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.Teacher": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string",
-                    "example": "5A"
+                    "example": "laura.lopez@escuela.com"
                 },
                 "name": {
                     "type": "string",
-                    "example": "María García"
+                    "example": "Laura López"
                 },
-                "student_id": {
+                "teacher_id": {
                     "type": "integer",
                     "example": 1
+                },
+                "user_id": {
+                    "description": "UserID vincula al maestro con su cuenta de acceso; sin cuenta no puede capturar calificaciones",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "models.Subject": {
+        "models.TeacherRequest": {
             "type": "object",
             "required": [
                 "name"
             ],
             "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "laura.lopez@escuela.com"
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2,
-                    "example": "Matemáticas"
+                    "example": "Laura López"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "models.TeachingAssignment": {
+            "type": "object",
+            "properties": {
+                "assignment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "subject_id": {
+                    "description": "Cada materia de un grupo tiene un solo maestro por periodo",
+                    "type": "integer",
+                    "example": 1
+                },
+                "teacher_id": {
                     "type": "integer",
                     "example": 1
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "models.SubjectBasic": {
+        "models.Term": {
             "type": "object",
             "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-10-10T00:00:00Z"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "partial"
+                },
                 "name": {
                     "type": "string",
-                    "example": "Matemáticas"
+                    "example": "Primer parcial"
                 },
-                "subject_id": {
+                "parent_id": {
+                    "description": "ParentID apunta al semestre de un parcial o al ciclo escolar de un semestre",
                     "type": "integer",
-                    "example": 1
+                    "example": 2
+                },
+                "school_year": {
+                    "type": "string",
+                    "example": "2025-2026"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-08-25T00:00:00Z"
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.TermBasic": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "example": "partial"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Primer parcial"
+                },
+                "school_year": {
+                    "type": "string",
+                    "example": "2025-2026"
+                },
+                "term_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.TermRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "kind",
+                "name",
+                "school_year",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-10-10"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "school_year",
+                        "semester",
+                        "partial"
+                    ],
+                    "example": "partial"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Primer parcial"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "school_year": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 4,
+                    "example": "2025-2026"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-08-25"
                 }
             }
         },
@@ -897,21 +9237,133 @@ const docTemplate = `{
             ],
             "properties": {
                 "grade": {
+                    "description": "Grade se captura en la escala de la materia y el grado escolar del estudiante;\nes un puntero para que required acepte 0",
                     "type": "number",
                     "maximum": 100,
                     "minimum": 0,
                     "example": 98
+                },
+                "reason": {
+                    "description": "Reason es el motivo del cambio que queda en el historial de la calificación",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Revisión del examen solicitada por el tutor"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "teacher"
+                },
+                "student_id": {
+                    "description": "StudentID vincula la cuenta de un alumno con su registro de estudiante",
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "example": "maestra.lopez"
                 }
             }
         },
         "utils.ErrorResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "code": {
+                    "type": "string",
+                    "example": "STUDENT_NOT_FOUND"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Estudiante no encontrado"
+                },
+                "details": {},
+                "errors": {
+                    "description": "Errors enumera los campos que no pasaron la validación",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/students/99"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "utils.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "grade"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "grade debe ser 100 o menos"
                 },
+                "rule": {
+                    "type": "string",
+                    "example": "max"
+                }
+            }
+        },
+        "utils.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
                 "message": {
                     "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/utils.PaginationMeta"
+                }
+            }
+        },
+        "utils.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next": {
+                    "type": "string",
+                    "example": "/api/students?limit=20\u0026page=2"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 135
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 7
                 }
             }
         },
@@ -924,6 +9376,14 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Token JWT con el formato \"Bearer {token}\", obtenido en /auth/login",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -934,7 +9394,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api",
 	Schemes:          []string{"http"},
 	Title:            "API de Control Escolar",
-	Description:      "API REST para la gestión de estudiantes, materias y calificaciones en un sistema escolar",
+	Description:      "API REST para la gestión de estudiantes, materias y calificaciones en un sistema escolar. Los mensajes se devuelven en español o inglés según el header Accept-Language",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 }
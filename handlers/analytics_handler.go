@@ -0,0 +1,187 @@
+package handlers
+
+import (
+    "log"
+    "net/http"
+    "strconv"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// Tamaño de los intervalos del histograma de calificaciones
+const (
+    defaultBucketSize = 10
+    maxBucketSize     = 50
+)
+
+// AnalyticsHandler agrupa los endpoints de estadísticas por grupo
+type AnalyticsHandler struct {
+    analytics repositories.AnalyticsRepository
+    groups    repositories.GroupRepository
+    subjects  repositories.SubjectRepository
+    terms     repositories.TermRepository
+    // passingGrade es la calificación mínima aprobatoria de las materias sin escala asignada
+    passingGrade float64
+}
+
+// NewAnalyticsHandler crea un AnalyticsHandler con los repositorios indicados
+func NewAnalyticsHandler(analytics repositories.AnalyticsRepository, groups repositories.GroupRepository, subjects repositories.SubjectRepository, terms repositories.TermRepository, passingGrade float64) *AnalyticsHandler {
+    return &AnalyticsHandler{
+        analytics:    analytics,
+        groups:       groups,
+        subjects:     subjects,
+        terms:        terms,
+        passingGrade: passingGrade,
+    }
+}
+
+// GetGroupSubjectStats godoc
+// @Summary      Promedios por grupo y materia
+// @Description  Devuelve, para cada grupo y materia, el número de estudiantes, el promedio, la mínima, la máxima y la tasa de aprobación. Se calcula sobre la calificación final de cada estudiante en la materia (promedio de sus calificaciones). Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del grupo (PASSING_GRADE si no tiene escala asignada), salvo que se indique passing_grade.
+// @Tags         analytics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id       query     int     false  "Grupo"
+// @Param        subject_id     query     int     false  "Materia"
+// @Param        term_id        query     int     false  "Periodo (incluye sus parciales)"
+// @Param        passing_grade  query     number  false  "Calificación mínima aprobatoria para todas las materias (por defecto la de la escala de cada materia)"
+// @Success      200            {object}  utils.SuccessResponse{data=[]models.GroupSubjectStats}
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /analytics/groups [get]
+func (h *AnalyticsHandler) GetGroupSubjectStats(c *gin.Context) {
+    filter, passing, ok := h.parseFilter(c)
+    if !ok {
+        return
+    }
+    
+    stats, err := h.analytics.GroupSubjectStats(filter, passing)
+    if err != nil {
+        log.Printf("Error calculando estadísticas por grupo: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "analytics.stats_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "analytics.stats_retrieved", stats)
+}
+
+// GetRanking godoc
+// @Summary      Ranking de estudiantes por promedio
+// @Description  Ordena a los estudiantes de mayor a menor promedio de sus calificaciones finales por materia. Los empates comparten posición (1, 2, 2, 4). Las materias reprobadas se cuentan con el valor aprobatorio de la escala de cada materia en el grado escolar del grupo (PASSING_GRADE si no tiene escala asignada), salvo que se indique passing_grade.
+// @Tags         analytics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id       query     int     false  "Grupo"
+// @Param        subject_id     query     int     false  "Materia"
+// @Param        term_id        query     int     false  "Periodo (incluye sus parciales)"
+// @Param        passing_grade  query     number  false  "Calificación mínima aprobatoria para todas las materias (por defecto la de la escala de cada materia)"
+// @Success      200            {object}  utils.SuccessResponse{data=[]models.StudentRanking}
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /analytics/ranking [get]
+func (h *AnalyticsHandler) GetRanking(c *gin.Context) {
+    filter, passing, ok := h.parseFilter(c)
+    if !ok {
+        return
+    }
+    
+    ranking, err := h.analytics.Ranking(filter, passing)
+    if err != nil {
+        log.Printf("Error calculando ranking: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "analytics.ranking_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "analytics.ranking_retrieved", ranking)
+}
+
+// GetDistribution godoc
+// @Summary      Distribución de calificaciones
+// @Description  Devuelve el histograma de calificaciones finales por materia en intervalos de bucket_size puntos, junto con la tasa de aprobación. Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del grupo (PASSING_GRADE si no tiene escala asignada), salvo que se indique passing_grade.
+// @Tags         analytics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id       query     int     false  "Grupo"
+// @Param        subject_id     query     int     false  "Materia"
+// @Param        term_id        query     int     false  "Periodo (incluye sus parciales)"
+// @Param        passing_grade  query     number  false  "Calificación mínima aprobatoria para todas las materias (por defecto la de la escala de cada materia)"
+// @Param        bucket_size    query     int     false  "Tamaño de cada intervalo (1-50)"  default(10)
+// @Success      200            {object}  utils.SuccessResponse{data=models.GradeDistribution}
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /analytics/distribution [get]
+func (h *AnalyticsHandler) GetDistribution(c *gin.Context) {
+    filter, passing, ok := h.parseFilter(c)
+    if !ok {
+        return
+    }
+    
+    bucketSize := defaultBucketSize
+    if value := c.Query("bucket_size"); value != "" {
+        size, err := strconv.Atoi(value)
+        if err != nil || size < 1 || size > maxBucketSize {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidParameter, "analytics.invalid_bucket_size")
+            return
+        }
+        bucketSize = size
+    }
+    
+    distribution, err := h.analytics.Distribution(filter, bucketSize, passing)
+    if err != nil {
+        log.Printf("Error calculando distribución: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "analytics.distribution_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "analytics.distribution_retrieved", distribution)
+}
+
+// parseFilter lee los filtros comunes de las estadísticas y el passing_grade opcional, que
+// reemplaza al valor aprobatorio de la escala de cada materia.
+// Si hay un error ya respondió al cliente y ok es false.
+func (h *AnalyticsHandler) parseFilter(c *gin.Context) (filter repositories.AnalyticsFilter, passing repositories.PassingCriteria, ok bool) {
+    if value := c.Query("group_id"); value != "" {
+        groupID, err := strconv.Atoi(value)
+        if err != nil {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "group.invalid_id")
+            return filter, passing, false
+        }
+        if _, err := h.groups.FindByID(groupID); err != nil {
+            respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+            return filter, passing, false
+        }
+        filter.GroupID = groupID
+    }
+    
+    if value := c.Query("subject_id"); value != "" {
+        subjectID, err := strconv.Atoi(value)
+        if err != nil {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "subject.invalid_id")
+            return filter, passing, false
+        }
+        if _, err := h.subjects.FindByID(subjectID); err != nil {
+            respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+            return filter, passing, false
+        }
+        filter.SubjectID = subjectID
+    }
+    
+    if _, filter.TermIDs, ok = parseTermFilter(c, h.terms); !ok {
+        return filter, passing, false
+    }
+    
+    passing.Default = h.passingGrade
+    if c.Query("passing_grade") != "" {
+        passingGrade, ok := parsePassingGrade(c, h.passingGrade)
+        if !ok {
+            return filter, passing, false
+        }
+        passing.Override = &passingGrade
+    }
+    return filter, passing, true
+}
@@ -0,0 +1,31 @@
+package handlers_test
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "testing"
+    
+    "ControlEscolar/models"
+    "ControlEscolar/utils"
+)
+
+func TestGetGroupSubjectStatsIsForStaff(t *testing.T) {
+    f := newSchoolFixture(t)
+    path := fmt.Sprintf("/api/analytics/groups?group_id=%d", f.group.GroupID)
+    
+    recorder := performRequest(f.router, http.MethodGet, path, f.assigned, nil)
+    assertStatus(t, recorder, http.StatusOK)
+    var response struct {
+        Data []models.GroupSubjectStats `json:"data"`
+    }
+    if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+        t.Fatalf("respuesta no es JSON: %v", err)
+    }
+    // La calificación de 80 de la fixture aprueba con la calificación mínima por defecto
+    if len(response.Data) != 1 || response.Data[0].PassedCount != 1 {
+        t.Errorf("stats = %+v, se esperaba un aprobado en %s", response.Data, f.subject.Name)
+    }
+    
+    assertError(t, performRequest(f.router, http.MethodGet, path, f.newStudentAccount(t), nil), http.StatusForbidden, utils.CodeForbidden)
+}
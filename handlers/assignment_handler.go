@@ -0,0 +1,186 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// AssignmentHandler agrupa los endpoints de asignaciones de maestros a materias y grupos
+type AssignmentHandler struct {
+    assignments repositories.AssignmentRepository
+    teachers    repositories.TeacherRepository
+    subjects    repositories.SubjectRepository
+    groups      repositories.GroupRepository
+    terms       repositories.TermRepository
+}
+
+// NewAssignmentHandler crea un AssignmentHandler con los repositorios indicados
+func NewAssignmentHandler(assignments repositories.AssignmentRepository, teachers repositories.TeacherRepository, subjects repositories.SubjectRepository, groups repositories.GroupRepository, terms repositories.TermRepository) *AssignmentHandler {
+    return &AssignmentHandler{
+        assignments: assignments,
+        teachers:    teachers,
+        subjects:    subjects,
+        groups:      groups,
+        terms:       terms,
+    }
+}
+
+// CreateAssignment godoc
+// @Summary      Asignar un maestro a una materia de un grupo
+// @Description  Asigna el maestro que imparte la materia al grupo durante el periodo (y sus subperiodos). Solo el maestro asignado puede capturar esas calificaciones. El periodo debe ser del mismo ciclo escolar que el grupo.
+// @Tags         assignments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        assignment  body      models.AssignmentRequest  true  "Asignación"
+// @Success      201         {object}  utils.SuccessResponse{data=models.TeachingAssignment}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /assignments [post]
+func (h *AssignmentHandler) CreateAssignment(c *gin.Context) {
+    var request models.AssignmentRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if _, err := h.teachers.FindByID(request.TeacherID); err != nil {
+        respondLookupError(c, err, utils.CodeTeacherNotFound, "teacher.not_found")
+        return
+    }
+    
+    if _, err := h.subjects.FindByID(request.SubjectID); err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    group, err := h.groups.FindByID(request.GroupID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return
+    }
+    
+    term, err := h.terms.FindByID(request.TermID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    if term.SchoolYear != group.SchoolYear {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeTermGroupYear, "term.group_year_mismatch")
+        return
+    }
+    
+    assignment := models.TeachingAssignment{
+        TeacherID: request.TeacherID,
+        SubjectID: request.SubjectID,
+        GroupID:   request.GroupID,
+        TermID:    request.TermID,
+    }
+    
+    if err := h.assignments.Create(&assignment); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateAssignment, "assignment.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "assignment.created", assignment)
+}
+
+// GetAllAssignments godoc
+// @Summary      Listar asignaciones
+// @Description  Obtiene una página de asignaciones, filtrable por maestro, materia, grupo o periodo
+// @Tags         assignments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit       query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort        query     string  false  "Campo de orden"  Enums(assignment_id, teacher_id, subject_id, group_id, term_id)
+// @Param        order       query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        teacher_id  query     int     false  "Filtrar por maestro"
+// @Param        subject_id  query     int     false  "Filtrar por materia"
+// @Param        group_id    query     int     false  "Filtrar por grupo"
+// @Param        term_id     query     int     false  "Filtrar por periodo"
+// @Success      200         {object}  utils.PaginatedResponse{data=[]models.TeachingAssignment}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /assignments [get]
+func (h *AssignmentHandler) GetAllAssignments(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.AssignmentSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    var filter repositories.AssignmentFilter
+    params := map[string]*int{
+        "teacher_id": &filter.TeacherID,
+        "subject_id": &filter.SubjectID,
+        "group_id":   &filter.GroupID,
+        "term_id":    &filter.TermID,
+    }
+    for name, target := range params {
+        value := c.Query(name)
+        if value == "" {
+            continue
+        }
+        id, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", name))
+            return
+        }
+        *target = id
+    }
+    
+    assignments, total, err := h.assignments.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "assignment.list_retrieved", assignments, opts.Page, opts.Limit, total)
+}
+
+// DeleteAssignment godoc
+// @Summary      Eliminar una asignación
+// @Description  Quita la asignación del maestro; sus calificaciones capturadas se conservan
+// @Tags         assignments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        assignment_id  path      int  true  "ID de la asignación"
+// @Success      200            {object}  utils.SuccessResponse
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /assignments/{assignment_id} [delete]
+func (h *AssignmentHandler) DeleteAssignment(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("assignment_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.assignments.Delete(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeAssignmentNotFound, "assignment.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.delete_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "assignment.deleted", nil)
+}
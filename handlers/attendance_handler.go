@@ -0,0 +1,602 @@
+package handlers
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "net/http"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+    
+    "github.com/gin-gonic/gin"
+    "github.com/gin-gonic/gin/binding"
+    "github.com/go-playground/validator/v10"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// dateLayout es el formato de las fechas de asistencia en peticiones y parámetros
+const dateLayout = "2006-01-02"
+
+// AttendanceHandler agrupa los endpoints de asistencia
+type AttendanceHandler struct {
+    attendance  repositories.AttendanceRepository
+    students    repositories.StudentRepository
+    subjects    repositories.SubjectRepository
+    groups      repositories.GroupRepository
+    terms       repositories.TermRepository
+    teachers    repositories.TeacherRepository
+    assignments repositories.AssignmentRepository
+    // maxAbsencePercentage es el porcentaje máximo de faltas permitido en una materia
+    maxAbsencePercentage float64
+}
+
+// NewAttendanceHandler crea un AttendanceHandler con los repositorios indicados
+func NewAttendanceHandler(attendance repositories.AttendanceRepository, students repositories.StudentRepository, subjects repositories.SubjectRepository, groups repositories.GroupRepository, terms repositories.TermRepository, teachers repositories.TeacherRepository, assignments repositories.AssignmentRepository, maxAbsencePercentage float64) *AttendanceHandler {
+    return &AttendanceHandler{
+        attendance:           attendance,
+        students:             students,
+        subjects:             subjects,
+        groups:               groups,
+        terms:                terms,
+        teachers:             teachers,
+        assignments:          assignments,
+        maxAbsencePercentage: maxAbsencePercentage,
+    }
+}
+
+// TakeRollCall godoc
+// @Summary      Tomar lista a un grupo
+// @Description  Registra en una sola operación la asistencia de los estudiantes de un grupo (hasta 200) en una fecha. Con subject_id es la asistencia a esa materia y solo puede tomarla el maestro asignado a ella en el grupo; sin subject_id es la lista diaria y solo puede tomarla el titular del grupo. Si un estudiante ya tiene registro en la misma materia y fecha se actualiza. Si algún elemento es rechazado no se guarda ningún registro y la respuesta lista los rechazados. Si otra lista guarda al mismo tiempo el registro de un estudiante en la misma materia y fecha responde 409 y no se guarda ninguno; basta con volver a enviarla.
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        roll  body      models.RollCallRequest  true  "Grupo, materia, fecha y asistencia de cada estudiante"
+// @Success      200   {object}  utils.SuccessResponse{data=models.RollCallResponse}
+// @Failure      400   {object}  utils.ErrorResponse{details=models.RollCallResponse}
+// @Failure      403   {object}  utils.ErrorResponse
+// @Failure      404   {object}  utils.ErrorResponse
+// @Failure      409   {object}  utils.ErrorResponse
+// @Failure      500   {object}  utils.ErrorResponse
+// @Router       /attendance/roll-call [post]
+func (h *AttendanceHandler) TakeRollCall(c *gin.Context) {
+    var request models.RollCallRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    group, err := h.groups.FindByID(request.GroupID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return
+    }
+    
+    if request.SubjectID != nil {
+        if _, err := h.subjects.FindByID(*request.SubjectID); err != nil {
+            respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+            return
+        }
+    }
+    
+    // El formato ya lo validó el binding
+    date, _ := time.Parse(dateLayout, request.Date)
+    if date.After(today()) {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "attendance.future_date")
+        return
+    }
+    
+    if !h.authorizeRollCall(c, group, request.SubjectID, date) {
+        return
+    }
+    
+    response, err := h.validateRollCall(request, group, utils.Language(c))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return
+    }
+    if len(response.Failed) > 0 {
+        utils.RespondWithErrorDetails(c, http.StatusBadRequest, utils.CodeBatchRejected, "attendance.rejected", response)
+        return
+    }
+    
+    change := gradeChange(c, "")
+    records := make([]models.Attendance, len(request.Records))
+    for i, item := range request.Records {
+        records[i] = models.Attendance{
+            StudentID:      item.StudentID,
+            GroupID:        group.GroupID,
+            SubjectID:      request.SubjectID,
+            Date:           date,
+            Status:         item.Status,
+            Notes:          strings.TrimSpace(item.Notes),
+            RecordedBy:     change.UserID,
+            RecordedByName: change.Username,
+        }
+    }
+    
+    created, err := h.attendance.RecordMany(records)
+    if err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateAttendance, "attendance.concurrent")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "attendance.record_error")
+        return
+    }
+    
+    for i, record := range records {
+        if created[i] {
+            response.Created = append(response.Created, record)
+        } else {
+            response.Updated = append(response.Updated, record)
+        }
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "attendance.recorded", response)
+}
+
+// GetAllAttendance godoc
+// @Summary      Listar registros de asistencia
+// @Description  Obtiene una página de registros de asistencia, filtrable por grupo, estudiante, materia, estado y rango de fechas
+// @Tags         attendance
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit       query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort        query     string  false  "Campo de orden"  Enums(attendance_id, date, student_id, status)
+// @Param        order       query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        group_id    query     int     false  "Filtrar por grupo"
+// @Param        student_id  query     int     false  "Filtrar por estudiante"
+// @Param        subject_id  query     int     false  "Filtrar por materia"
+// @Param        daily       query     bool    false  "Solo las listas diarias de grupo, sin materia"
+// @Param        status      query     string  false  "Filtrar por estado"  Enums(present, absent, late, justified)
+// @Param        from        query     string  false  "Desde la fecha (AAAA-MM-DD)"
+// @Param        to          query     string  false  "Hasta la fecha (AAAA-MM-DD)"
+// @Success      200         {object}  utils.PaginatedResponse{data=[]models.Attendance}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /attendance [get]
+func (h *AttendanceHandler) GetAllAttendance(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.AttendanceSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    var filter repositories.AttendanceFilter
+    params := map[string]*int{
+        "group_id":   &filter.GroupID,
+        "student_id": &filter.StudentID,
+        "subject_id": &filter.SubjectID,
+    }
+    for name, target := range params {
+        value := c.Query(name)
+        if value == "" {
+            continue
+        }
+        id, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", name))
+            return
+        }
+        *target = id
+    }
+    
+    filter.DailyOnly, err = strconv.ParseBool(c.DefaultQuery("daily", "false"))
+    if err != nil {
+        respondInvalidParameter(c, i18n.NewMessage("param.not_boolean", "daily"))
+        return
+    }
+    
+    filter.Status = strings.TrimSpace(c.Query("status"))
+    if filter.Status != "" && !contains(models.AttendanceStatuses, filter.Status) {
+        respondInvalidParameter(c, i18n.NewMessage("attendance.invalid_status", strings.Join(models.AttendanceStatuses, ", ")))
+        return
+    }
+    
+    var ok bool
+    if filter.From, filter.To, ok = parseDateRange(c); !ok {
+        return
+    }
+    
+    records, total, err := h.attendance.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "attendance.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "attendance.list_retrieved", records, opts.Page, opts.Limit, total)
+}
+
+// UpdateAttendance godoc
+// @Summary      Corregir un registro de asistencia
+// @Description  Cambia el estado o las notas de un registro, por ejemplo para justificar una falta. Aplican los mismos permisos que para tomar la lista.
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        attendance_id  path      int                             true  "ID del registro de asistencia"
+// @Param        attendance     body      models.AttendanceUpdateRequest  true  "Estado y notas"
+// @Success      200            {object}  utils.SuccessResponse{data=models.Attendance}
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      403            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /attendance/{attendance_id} [put]
+func (h *AttendanceHandler) UpdateAttendance(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("attendance_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    record, err := h.attendance.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeAttendanceNotFound, "attendance.not_found")
+        return
+    }
+    
+    var request models.AttendanceUpdateRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    group, err := h.groups.FindByID(record.GroupID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return
+    }
+    
+    if !h.authorizeRollCall(c, group, record.SubjectID, record.Date) {
+        return
+    }
+    
+    change := gradeChange(c, "")
+    record.Status = request.Status
+    record.Notes = strings.TrimSpace(request.Notes)
+    record.RecordedBy = change.UserID
+    record.RecordedByName = change.Username
+    
+    if err := h.attendance.Update(record); err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "attendance.update_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "attendance.updated", record)
+}
+
+// DeleteAttendance godoc
+// @Summary      Eliminar un registro de asistencia
+// @Description  Elimina un registro de asistencia capturado por error
+// @Tags         attendance
+// @Produce      json
+// @Security     BearerAuth
+// @Param        attendance_id  path      int  true  "ID del registro de asistencia"
+// @Success      200            {object}  utils.SuccessResponse
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /attendance/{attendance_id} [delete]
+func (h *AttendanceHandler) DeleteAttendance(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("attendance_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.attendance.Delete(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeAttendanceNotFound, "attendance.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "attendance.delete_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "attendance.deleted", nil)
+}
+
+// GetStudentAttendance godoc
+// @Summary      Resumen de asistencia de un estudiante
+// @Description  Cuenta las asistencias, faltas, retardos y faltas justificadas del estudiante, en total y por materia (sin subject_id es la lista diaria del grupo). El porcentaje de faltas considera solo las faltas sin justificar; exceeds_limit indica si supera MAX_ABSENCE_PERCENTAGE. Con term_id solo considera las fechas del periodo; from y to acotan el rango. En el portal de padres el tutor solo puede consultar a los estudiantes vinculados a su cuenta.
+// @Tags         attendance
+// @Produce      json
+// @Security     BearerAuth
+// @Param        student_id  path      int     true   "ID del estudiante"
+// @Param        term_id     query     int     false  "Periodo a considerar"
+// @Param        from        query     string  false  "Desde la fecha (AAAA-MM-DD)"
+// @Param        to          query     string  false  "Hasta la fecha (AAAA-MM-DD)"
+// @Success      200         {object}  utils.SuccessResponse{data=models.AttendanceSummary}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      403         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /students/{student_id}/attendance [get]
+// @Router       /guardians/me/students/{student_id}/attendance [get]
+func (h *AttendanceHandler) GetStudentAttendance(c *gin.Context) {
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    student, err := h.students.FindByID(studentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    term, _, ok := parseTermFilter(c, h.terms)
+    if !ok {
+        return
+    }
+    
+    from, to, ok := parseDateRange(c)
+    if !ok {
+        return
+    }
+    if term != nil {
+        from, to = intersectRange(from, to, term)
+    }
+    
+    counts, err := h.attendance.Counts(studentID, from, to)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "attendance.summary_error")
+        return
+    }
+    
+    summary := &models.AttendanceSummary{
+        Student:              newStudentBasic(student),
+        Term:                 newTermBasic(term),
+        MaxAbsencePercentage: h.maxAbsencePercentage,
+        Subjects:             []models.SubjectAttendance{},
+    }
+    if from != nil {
+        summary.From = from.Format(dateLayout)
+    }
+    if to != nil {
+        summary.To = to.Format(dateLayout)
+    }
+    
+    overall, bySubject := summarizeAttendance(counts, h.maxAbsencePercentage)
+    summary.AttendanceTotals = overall
+    
+    ids := []int{}
+    for subjectID := range bySubject {
+        if subjectID != dailyAttendance {
+            ids = append(ids, subjectID)
+        }
+    }
+    subjects, err := h.subjects.FindByIDs(ids)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "attendance.summary_error")
+        return
+    }
+    
+    lang := utils.Language(c)
+    for subjectID, totals := range bySubject {
+        item := models.SubjectAttendance{AttendanceTotals: *totals}
+        if subjectID == dailyAttendance {
+            item.Name = i18n.T(lang, "attendance.daily")
+        } else {
+            id := subjectID
+            item.SubjectID = &id
+            item.Name = fmt.Sprintf("Materia %d", subjectID)
+            if subject, ok := subjects[subjectID]; ok {
+                item.Name = subject.Name
+            }
+        }
+        summary.Subjects = append(summary.Subjects, item)
+    }
+    // La lista diaria va primero y después las materias por nombre
+    sort.Slice(summary.Subjects, func(i, j int) bool {
+        a, b := summary.Subjects[i], summary.Subjects[j]
+        if (a.SubjectID == nil) != (b.SubjectID == nil) {
+            return a.SubjectID == nil
+        }
+        return a.Name < b.Name
+    })
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "attendance.summary_retrieved", summary)
+}
+
+// validateRollCall revisa cada elemento de la lista: datos válidos, estudiante existente, que
+// pertenezca al grupo y que no aparezca dos veces.
+// Los elementos rechazados se devuelven en Failed con el mensaje en el idioma lang; err indica
+// una falla de la base de datos.
+func (h *AttendanceHandler) validateRollCall(request models.RollCallRequest, group *models.Group, lang string) (models.RollCallResponse, error) {
+    response := models.RollCallResponse{
+        Created: []models.Attendance{},
+        Updated: []models.Attendance{},
+        Failed:  []models.RollCallFailure{},
+    }
+    fail := func(index int, item models.RollCallItem, code string, message string) {
+        response.Failed = append(response.Failed, models.RollCallFailure{
+            Index:     index,
+            StudentID: item.StudentID,
+            Code:      code,
+            Message:   message,
+        })
+    }
+    
+    studentIDs := []int{}
+    for _, item := range request.Records {
+        studentIDs = append(studentIDs, item.StudentID)
+    }
+    students, err := h.students.FindByIDs(studentIDs)
+    if err != nil {
+        return response, err
+    }
+    
+    seen := make(map[int]int)
+    for i, item := range request.Records {
+        if err := binding.Validator.ValidateStruct(&item); err != nil {
+            var fieldErrors validator.ValidationErrors
+            if errors.As(err, &fieldErrors) {
+                fail(i, item, utils.CodeValidationFailed, i18n.FieldMessage(lang, fieldErrors[0]))
+                continue
+            }
+            return response, err
+        }
+        if first, ok := seen[item.StudentID]; ok {
+            fail(i, item, utils.CodeValidationFailed, i18n.T(lang, "attendance.repeated_student", first))
+            continue
+        }
+        seen[item.StudentID] = i
+    
+        student, ok := students[item.StudentID]
+        if !ok {
+            fail(i, item, utils.CodeStudentNotFound, i18n.T(lang, "student.not_found"))
+            continue
+        }
+        if student.GroupID == nil || *student.GroupID != group.GroupID {
+            fail(i, item, utils.CodeStudentNotInGroup, i18n.T(lang, "attendance.not_in_group"))
+        }
+    }
+    return response, nil
+}
+
+// authorizeRollCall verifica que un maestro pueda tomar la lista del grupo en la fecha. En una
+// materia debe estar asignado a ella en el grupo, en un periodo que incluya la fecha (si ningún
+// periodo la incluye basta cualquier asignación); la lista diaria solo la toma el titular del
+// grupo. Los administradores pueden tomar cualquier lista.
+// Si no está autorizado ya respondió al cliente y devuelve false.
+func (h *AttendanceHandler) authorizeRollCall(c *gin.Context, group *models.Group, subjectID *int, date time.Time) bool {
+    teacher, ok := currentTeacher(c, h.teachers)
+    if !ok {
+        return false
+    }
+    if teacher == nil {
+        return true
+    }
+    
+    if subjectID == nil {
+        if group.HomeroomTeacherID == nil || *group.HomeroomTeacherID != teacher.TeacherID {
+            utils.RespondWithError(c, http.StatusForbidden, utils.CodeTeacherNotAssigned, "attendance.not_homeroom")
+            return false
+        }
+        return true
+    }
+    
+    termIDs, err := h.terms.ContainingDate(group.SchoolYear, date)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    assigned, err := h.assignments.IsAssigned(teacher.TeacherID, *subjectID, group.GroupID, termIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.query_error")
+        return false
+    }
+    if !assigned {
+        utils.RespondWithError(c, http.StatusForbidden, utils.CodeTeacherNotAssigned, "attendance.not_assigned")
+        return false
+    }
+    return true
+}
+
+// dailyAttendance es la llave de la lista diaria del grupo en los conteos por materia
+const dailyAttendance = 0
+
+// summarizeAttendance suma los conteos en total y por materia; la lista diaria queda en la llave
+// dailyAttendance. El porcentaje de faltas se compara con maxAbsencePercentage.
+func summarizeAttendance(counts []models.AttendanceCount, maxAbsencePercentage float64) (models.AttendanceTotals, map[int]*models.AttendanceTotals) {
+    var overall models.AttendanceTotals
+    bySubject := make(map[int]*models.AttendanceTotals)
+    for _, count := range counts {
+        subjectID := dailyAttendance
+        if count.SubjectID != nil {
+            subjectID = *count.SubjectID
+        }
+        totals, ok := bySubject[subjectID]
+        if !ok {
+            totals = &models.AttendanceTotals{}
+            bySubject[subjectID] = totals
+        }
+        addAttendanceCount(totals, count)
+        addAttendanceCount(&overall, count)
+    }
+    
+    finishAttendanceTotals(&overall, maxAbsencePercentage)
+    for _, totals := range bySubject {
+        finishAttendanceTotals(totals, maxAbsencePercentage)
+    }
+    return overall, bySubject
+}
+
+// addAttendanceCount suma un conteo al estado que le corresponde
+func addAttendanceCount(totals *models.AttendanceTotals, count models.AttendanceCount) {
+    totals.Total += count.Count
+    switch count.Status {
+    case models.AttendancePresent:
+        totals.Present += count.Count
+    case models.AttendanceAbsent:
+        totals.Absent += count.Count
+    case models.AttendanceLate:
+        totals.Late += count.Count
+    case models.AttendanceJustified:
+        totals.Justified += count.Count
+    }
+}
+
+// finishAttendanceTotals calcula el porcentaje de faltas sin justificar y si supera el máximo
+func finishAttendanceTotals(totals *models.AttendanceTotals, maxAbsencePercentage float64) {
+    if totals.Total == 0 {
+        return
+    }
+    totals.AbsencePercentage = math.Round(float64(totals.Absent)/float64(totals.Total)*10000) / 100
+    totals.ExceedsLimit = totals.AbsencePercentage > maxAbsencePercentage
+}
+
+// parseDateRange lee las fechas from y to opcionales (AAAA-MM-DD).
+// Si hay un error ya respondió 400 y ok es false.
+func parseDateRange(c *gin.Context) (from, to *time.Time, ok bool) {
+    dates := map[string]**time.Time{
+        "from": &from,
+        "to":   &to,
+    }
+    for name, target := range dates {
+        value := c.Query(name)
+        if value == "" {
+            continue
+        }
+        date, err := time.Parse(dateLayout, value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_date", name))
+            return nil, nil, false
+        }
+        *target = &date
+    }
+    if from != nil && to != nil && from.After(*to) {
+        respondInvalidParameter(c, i18n.NewMessage("param.date_range"))
+        return nil, nil, false
+    }
+    return from, to, true
+}
+
+// intersectRange acota el rango from-to a las fechas del periodo
+func intersectRange(from, to *time.Time, term *models.Term) (*time.Time, *time.Time) {
+    start, end := term.StartDate, term.EndDate
+    if from == nil || from.Before(start) {
+        from = &start
+    }
+    if to == nil || to.After(end) {
+        to = &end
+    }
+    return from, to
+}
+
+// today devuelve la fecha actual a medianoche UTC, como las fechas de asistencia
+func today() time.Time {
+    date, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+    return date
+}
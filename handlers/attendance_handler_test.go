@@ -0,0 +1,33 @@
+package handlers_test
+
+import (
+    "net/http"
+    "testing"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/models"
+    "ControlEscolar/utils"
+)
+
+func TestTakeRollCallRequiresAssignedTeacher(t *testing.T) {
+    f := newSchoolFixture(t)
+    records := []gin.H{{"student_id": f.student.StudentID, "status": models.AttendanceAbsent}}
+    bySubject := gin.H{"group_id": f.group.GroupID, "subject_id": f.subject.SubjectID, "date": "2025-10-17", "records": records}
+    
+    assertError(t, performRequest(f.router, http.MethodPost, "/api/attendance/roll-call", f.newStudentAccount(t), bySubject), http.StatusForbidden, utils.CodeForbidden)
+    assertError(t, performRequest(f.router, http.MethodPost, "/api/attendance/roll-call", f.other, bySubject), http.StatusForbidden, utils.CodeTeacherNotAssigned)
+    assertStatus(t, performRequest(f.router, http.MethodPost, "/api/attendance/roll-call", f.assigned, bySubject), http.StatusOK)
+    
+    // La lista diaria del grupo es del maestro titular, no de los maestros de las materias
+    daily := gin.H{"group_id": f.group.GroupID, "date": "2025-10-17", "records": records}
+    assertError(t, performRequest(f.router, http.MethodPost, "/api/attendance/roll-call", f.assigned, daily), http.StatusForbidden, utils.CodeTeacherNotAssigned)
+    assertStatus(t, performRequest(f.router, http.MethodPost, "/api/attendance/roll-call", f.admin, daily), http.StatusOK)
+    
+    var count int64
+    if err := f.db.Model(&models.Attendance{}).Where("student_id = ?", f.student.StudentID).Count(&count).Error; err != nil {
+        t.Fatalf("consultar asistencia: %v", err)
+    }
+    if count != 2 {
+        t.Errorf("registros de asistencia = %d, se esperaban la lista de la materia y la diaria", count)
+    }
+}
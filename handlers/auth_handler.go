@@ -0,0 +1,187 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/auth"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// AuthHandler agrupa los endpoints de autenticación y cuentas de usuario
+type AuthHandler struct {
+    users    repositories.UserRepository
+    students repositories.StudentRepository
+    tokens   *auth.TokenService
+}
+
+// NewAuthHandler crea un AuthHandler con los repositorios y el servicio de tokens indicados
+func NewAuthHandler(users repositories.UserRepository, students repositories.StudentRepository, tokens *auth.TokenService) *AuthHandler {
+    return &AuthHandler{
+        users:    users,
+        students: students,
+        tokens:   tokens,
+    }
+}
+
+// Login godoc
+// @Summary      Iniciar sesión
+// @Description  Valida usuario y contraseña y emite un JWT firmado
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials  body      models.LoginRequest  true  "Credenciales"
+// @Success      200          {object}  utils.SuccessResponse{data=models.LoginResponse}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      401          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+    var request models.LoginRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    user, err := h.users.FindByUsername(strings.TrimSpace(request.Username))
+    if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "auth.login_error")
+        return
+    }
+    if user == nil || !auth.CheckPassword(user.PasswordHash, request.Password) {
+        utils.RespondWithError(c, http.StatusUnauthorized, utils.CodeInvalidCredentials, "auth.invalid_credentials")
+        return
+    }
+    
+    token, expiresAt, err := h.tokens.Generate(user)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "auth.token_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "auth.logged_in", models.LoginResponse{
+        Token:     token,
+        TokenType: "Bearer",
+        ExpiresAt: expiresAt.Unix(),
+        User:      *user,
+    })
+}
+
+// GetCurrentUser godoc
+// @Summary      Obtener el usuario autenticado
+// @Description  Devuelve la cuenta asociada al token enviado
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.SuccessResponse{data=models.User}
+// @Failure      401  {object}  utils.ErrorResponse
+// @Failure      404  {object}  utils.ErrorResponse
+// @Router       /auth/me [get]
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
+    claims := auth.CurrentUser(c)
+    if claims == nil {
+        utils.RespondWithError(c, http.StatusUnauthorized, utils.CodeAuthRequired, "auth.token_required")
+        return
+    }
+    
+    user, err := h.users.FindByID(claims.UserID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeUserNotFound, "user.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "user.retrieved", user)
+}
+
+// CreateUser godoc
+// @Summary      Crear una cuenta de usuario
+// @Description  Registra una cuenta con rol admin, teacher, student o parent. Las cuentas de alumno deben indicar student_id.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user  body      models.CreateUserRequest  true  "Información de la cuenta"
+// @Success      201   {object}  utils.SuccessResponse{data=models.User}
+// @Failure      400   {object}  utils.ErrorResponse
+// @Failure      404   {object}  utils.ErrorResponse
+// @Failure      409   {object}  utils.ErrorResponse
+// @Failure      500   {object}  utils.ErrorResponse
+// @Router       /users [post]
+func (h *AuthHandler) CreateUser(c *gin.Context) {
+    var request models.CreateUserRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if request.Role == models.RoleStudent {
+        if request.StudentID == nil {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "user.student_required")
+            return
+        }
+        if _, err := h.students.FindByID(*request.StudentID); err != nil {
+            respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+            return
+        }
+    } else {
+        request.StudentID = nil
+    }
+    
+    hash, err := auth.HashPassword(request.Password)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "user.create_error")
+        return
+    }
+    
+    user := models.User{
+        Username:     strings.TrimSpace(request.Username),
+        PasswordHash: hash,
+        Role:         request.Role,
+        StudentID:    request.StudentID,
+    }
+    
+    if err := h.users.Create(&user); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateUsername, "user.duplicate_username")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "user.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "user.created", user)
+}
+
+// GetAllUsers godoc
+// @Summary      Listar cuentas de usuario
+// @Description  Obtiene una página de cuentas registradas
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Número de página (desde 1)"  default(1)
+// @Param        limit  query     int  false  "Registros por página (máximo 100)"  default(20)
+// @Success      200    {object}  utils.PaginatedResponse{data=[]models.User}
+// @Failure      400    {object}  utils.ErrorResponse
+// @Failure      500    {object}  utils.ErrorResponse
+// @Router       /users [get]
+func (h *AuthHandler) GetAllUsers(c *gin.Context) {
+    opts, err := parseListOptions(c, []string{"user_id", "username", "role"})
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    users, total, err := h.users.List(opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "user.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "user.list_retrieved", users, opts.Page, opts.Limit, total)
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// EnrollmentHandler agrupa los endpoints de inscripciones de estudiantes en materias
+type EnrollmentHandler struct {
+    enrollments repositories.EnrollmentRepository
+    students    repositories.StudentRepository
+    subjects    repositories.SubjectRepository
+    groups      repositories.GroupRepository
+    terms       repositories.TermRepository
+}
+
+// NewEnrollmentHandler crea un EnrollmentHandler con los repositorios indicados
+func NewEnrollmentHandler(enrollments repositories.EnrollmentRepository, students repositories.StudentRepository, subjects repositories.SubjectRepository, groups repositories.GroupRepository, terms repositories.TermRepository) *EnrollmentHandler {
+    return &EnrollmentHandler{
+        enrollments: enrollments,
+        students:    students,
+        subjects:    subjects,
+        groups:      groups,
+        terms:       terms,
+    }
+}
+
+// CreateEnrollment godoc
+// @Summary      Inscribir a un estudiante en una materia
+// @Description  Inscribe al estudiante en la materia durante el periodo (y sus subperiodos). Solo se pueden registrar calificaciones de materias inscritas.
+// @Tags         enrollments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        enrollment  body      models.EnrollmentRequest  true  "Inscripción"
+// @Success      201         {object}  utils.SuccessResponse{data=models.Enrollment}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /enrollments [post]
+func (h *EnrollmentHandler) CreateEnrollment(c *gin.Context) {
+    var request models.EnrollmentRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if _, err := h.students.FindByID(request.StudentID); err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    if _, err := h.subjects.FindByID(request.SubjectID); err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    if _, err := h.terms.FindByID(request.TermID); err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    enrollment := models.Enrollment{
+        StudentID: request.StudentID,
+        SubjectID: request.SubjectID,
+        TermID:    request.TermID,
+    }
+    
+    if err := h.enrollments.Create(&enrollment); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateEnrollment, "enrollment.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "enrollment.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "enrollment.created", enrollment)
+}
+
+// EnrollGroup godoc
+// @Summary      Inscribir a un grupo completo en una materia
+// @Description  Inscribe en la materia a todos los estudiantes del grupo que todavía no estén inscritos en ese periodo. El periodo debe ser del mismo ciclo escolar que el grupo.
+// @Tags         enrollments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        enrollment  body      models.GroupEnrollmentRequest  true  "Grupo, materia y periodo"
+// @Success      200         {object}  utils.SuccessResponse{data=models.GroupEnrollmentResponse}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /enrollments/group [post]
+func (h *EnrollmentHandler) EnrollGroup(c *gin.Context) {
+    var request models.GroupEnrollmentRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if !h.validateGroupRequest(c, request) {
+        return
+    }
+    
+    result, err := h.enrollments.EnrollGroup(request.GroupID, request.SubjectID, request.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "enrollment.group_enroll_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "enrollment.group_enrolled", models.GroupEnrollmentResponse{
+        Enrolled:        result.Changed,
+        AlreadyEnrolled: result.Skipped,
+    })
+}
+
+// GetAllEnrollments godoc
+// @Summary      Listar inscripciones
+// @Description  Obtiene una página de inscripciones, filtrable por estudiante, materia, periodo o grupo del estudiante
+// @Tags         enrollments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit       query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort        query     string  false  "Campo de orden"  Enums(enrollment_id, student_id, subject_id, term_id)
+// @Param        order       query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        student_id  query     int     false  "Filtrar por estudiante"
+// @Param        subject_id  query     int     false  "Filtrar por materia"
+// @Param        term_id     query     int     false  "Filtrar por periodo"
+// @Param        group_id    query     int     false  "Filtrar por grupo del estudiante"
+// @Success      200         {object}  utils.PaginatedResponse{data=[]models.Enrollment}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /enrollments [get]
+func (h *EnrollmentHandler) GetAllEnrollments(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.EnrollmentSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    var filter repositories.EnrollmentFilter
+    params := map[string]*int{
+        "student_id": &filter.StudentID,
+        "subject_id": &filter.SubjectID,
+        "term_id":    &filter.TermID,
+        "group_id":   &filter.GroupID,
+    }
+    for name, target := range params {
+        value := c.Query(name)
+        if value == "" {
+            continue
+        }
+        id, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", name))
+            return
+        }
+        *target = id
+    }
+    
+    enrollments, total, err := h.enrollments.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "enrollment.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "enrollment.list_retrieved", enrollments, opts.Page, opts.Limit, total)
+}
+
+// DeleteEnrollment godoc
+// @Summary      Dar de baja a un estudiante de una materia
+// @Description  Elimina la inscripción si el estudiante todavía no tiene calificaciones de la materia en el periodo
+// @Tags         enrollments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        enrollment_id  path      int  true  "ID de la inscripción"
+// @Success      200            {object}  utils.SuccessResponse
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      409            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /enrollments/{enrollment_id} [delete]
+func (h *EnrollmentHandler) DeleteEnrollment(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("enrollment_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.enrollments.Delete(id); err != nil {
+        switch {
+        case errors.Is(err, repositories.ErrNotFound):
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeEnrollmentNotFound, "enrollment.not_found")
+        case errors.Is(err, repositories.ErrInUse):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeEnrollmentHasGrades, "enrollment.has_grades")
+        default:
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "enrollment.delete_error")
+        }
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "enrollment.deleted", nil)
+}
+
+// UnenrollGroup godoc
+// @Summary      Dar de baja a un grupo completo de una materia
+// @Description  Elimina las inscripciones de los estudiantes del grupo en la materia y periodo; los que ya tienen calificaciones conservan la inscripción
+// @Tags         enrollments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id    query     int  true  "ID del grupo"
+// @Param        subject_id  query     int  true  "ID de la materia"
+// @Param        term_id     query     int  true  "ID del periodo"
+// @Success      200         {object}  utils.SuccessResponse{data=models.GroupUnenrollmentResponse}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /enrollments/group [delete]
+func (h *EnrollmentHandler) UnenrollGroup(c *gin.Context) {
+    var request models.GroupEnrollmentRequest
+    
+    if err := c.ShouldBindQuery(&request); err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    if !h.validateGroupRequest(c, request) {
+        return
+    }
+    
+    result, err := h.enrollments.UnenrollGroup(request.GroupID, request.SubjectID, request.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "enrollment.group_unenroll_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "enrollment.group_unenrolled", models.GroupUnenrollmentResponse{
+        Unenrolled: result.Changed,
+        WithGrades: result.Skipped,
+    })
+}
+
+// validateGroupRequest verifica que el grupo, la materia y el periodo existan y que el periodo
+// sea del ciclo escolar del grupo. Si hay un error ya respondió al cliente y devuelve false.
+func (h *EnrollmentHandler) validateGroupRequest(c *gin.Context, request models.GroupEnrollmentRequest) bool {
+    group, err := h.groups.FindByID(request.GroupID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return false
+    }
+    
+    if _, err := h.subjects.FindByID(request.SubjectID); err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return false
+    }
+    
+    term, err := h.terms.FindByID(request.TermID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return false
+    }
+    
+    if term.SchoolYear != group.SchoolYear {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeTermGroupYear, "term.group_year_mismatch")
+        return false
+    }
+    return true
+}
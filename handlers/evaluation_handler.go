@@ -0,0 +1,393 @@
+package handlers
+
+import (
+    "errors"
+    "math"
+    "net/http"
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// EvaluationHandler agrupa los endpoints de criterios de evaluación y desglose de calificaciones
+type EvaluationHandler struct {
+    evaluations repositories.EvaluationRepository
+    grades      repositories.GradeRepository
+    students    repositories.StudentRepository
+    subjects    repositories.SubjectRepository
+    terms       repositories.TermRepository
+    teachers    repositories.TeacherRepository
+    assignments repositories.AssignmentRepository
+    locks       repositories.GradeLockRepository
+}
+
+// NewEvaluationHandler crea un EvaluationHandler con los repositorios indicados
+func NewEvaluationHandler(evaluations repositories.EvaluationRepository, grades repositories.GradeRepository, students repositories.StudentRepository, subjects repositories.SubjectRepository, terms repositories.TermRepository, teachers repositories.TeacherRepository, assignments repositories.AssignmentRepository, locks repositories.GradeLockRepository) *EvaluationHandler {
+    return &EvaluationHandler{
+        evaluations: evaluations,
+        grades:      grades,
+        students:    students,
+        subjects:    subjects,
+        terms:       terms,
+        teachers:    teachers,
+        assignments: assignments,
+        locks:       locks,
+    }
+}
+
+// GetCriteria godoc
+// @Summary      Obtener los criterios de evaluación de una materia
+// @Description  Obtiene los criterios de evaluación de la materia en el periodo y su peso en la calificación final. Si no tiene criterios la lista está vacía y la calificación se captura directamente.
+// @Tags         evaluation-criteria
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subject_id  path      int  true  "ID de la materia"
+// @Param        term_id     path      int  true  "ID del periodo"
+// @Success      200         {object}  utils.SuccessResponse{data=models.EvaluationCriteriaResponse}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /evaluation-criteria/subject/{subject_id}/term/{term_id} [get]
+func (h *EvaluationHandler) GetCriteria(c *gin.Context) {
+    subject, term, ok := h.parseSubjectTerm(c)
+    if !ok {
+        return
+    }
+    
+    criteria, err := h.evaluations.Criteria(subject.SubjectID, term.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.query_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "evaluation.criteria_retrieved", models.EvaluationCriteriaResponse{
+        SubjectID: subject.SubjectID,
+        TermID:    term.TermID,
+        Criteria:  criteria,
+    })
+}
+
+// ReplaceCriteria godoc
+// @Summary      Definir los criterios de evaluación de una materia
+// @Description  Reemplaza los criterios de evaluación de la materia en el periodo (hasta 20); sus pesos deben sumar 100 y sus nombres no se pueden repetir. Los criterios existentes se identifican por nombre y conservan sus calificaciones; quitar uno que ya tiene calificaciones responde 409, igual que definirlos cuando algún estudiante tiene una calificación capturada directamente en el periodo (hay que eliminarla antes). Desde ese momento la calificación final se calcula con los criterios y se recalcula la de los estudiantes que ya tienen calificaciones por criterio. Los maestros solo pueden definir los criterios de las materias que tienen asignadas en el periodo.
+// @Tags         evaluation-criteria
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subject_id  path      int                               true  "ID de la materia"
+// @Param        term_id     path      int                               true  "ID del periodo"
+// @Param        criteria    body      models.EvaluationCriteriaRequest  true  "Criterios y pesos"
+// @Success      200         {object}  utils.SuccessResponse{data=models.EvaluationCriteriaResponse}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      403         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      423         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /evaluation-criteria/subject/{subject_id}/term/{term_id} [put]
+func (h *EvaluationHandler) ReplaceCriteria(c *gin.Context) {
+    subject, term, ok := h.parseSubjectTerm(c)
+    if !ok {
+        return
+    }
+    
+    var request models.EvaluationCriteriaRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    criteria, ok := newEvaluationCriteria(c, request.Criteria)
+    if !ok {
+        return
+    }
+    
+    if !h.authorizeCriteria(c, subject.SubjectID, term.TermID) {
+        return
+    }
+    
+    if !h.checkScoredUnlocked(c, subject.SubjectID, term.TermID) {
+        return
+    }
+    
+    grades, err := h.evaluations.ReplaceCriteria(subject.SubjectID, term.TermID, criteria, gradeChange(c, request.Reason))
+    if err != nil {
+        switch {
+        case errors.Is(err, repositories.ErrInUse):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeCriterionHasScores, "evaluation.has_scores")
+        case errors.Is(err, repositories.ErrManualGrades):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeManualGrades, "evaluation.manual_grades")
+        default:
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.save_error")
+        }
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "evaluation.criteria_saved", models.EvaluationCriteriaResponse{
+        SubjectID: subject.SubjectID,
+        TermID:    term.TermID,
+        Criteria:  criteria,
+        Grades:    newBatchGradeResults(grades),
+    })
+}
+
+// DeleteCriteria godoc
+// @Summary      Eliminar los criterios de evaluación de una materia
+// @Description  Elimina los criterios de evaluación de la materia en el periodo para volver a capturar la calificación directamente. Solo es posible si ningún criterio tiene calificaciones. Los maestros solo pueden eliminar los criterios de las materias que tienen asignadas en el periodo.
+// @Tags         evaluation-criteria
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subject_id  path      int  true  "ID de la materia"
+// @Param        term_id     path      int  true  "ID del periodo"
+// @Success      200         {object}  utils.SuccessResponse
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      403         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /evaluation-criteria/subject/{subject_id}/term/{term_id} [delete]
+func (h *EvaluationHandler) DeleteCriteria(c *gin.Context) {
+    subject, term, ok := h.parseSubjectTerm(c)
+    if !ok {
+        return
+    }
+    
+    if !h.authorizeCriteria(c, subject.SubjectID, term.TermID) {
+        return
+    }
+    
+    if err := h.evaluations.DeleteCriteria(subject.SubjectID, term.TermID); err != nil {
+        switch {
+        case errors.Is(err, repositories.ErrNotFound):
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeCriteriaNotFound, "evaluation.criteria_not_found")
+        case errors.Is(err, repositories.ErrInUse):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeCriterionHasScores, "evaluation.has_scores")
+        default:
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.delete_error")
+        }
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "evaluation.criteria_deleted", nil)
+}
+
+// GetGradeBreakdown godoc
+// @Summary      Desglose de la calificación por criterio
+// @Description  Obtiene la calificación del estudiante en cada criterio de evaluación de la materia en el periodo, los puntos que aporta cada uno y la calificación final. Los criterios sin capturar cuentan como 0.
+// @Tags         grades
+// @Produce      json
+// @Security     BearerAuth
+// @Param        student_id  path      int  true  "ID del estudiante"
+// @Param        subject_id  path      int  true  "ID de la materia"
+// @Param        term_id     path      int  true  "ID del periodo"
+// @Success      200         {object}  utils.SuccessResponse{data=models.GradeBreakdown}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      403         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /grades/student/{student_id}/subject/{subject_id}/term/{term_id}/components [get]
+func (h *EvaluationHandler) GetGradeBreakdown(c *gin.Context) {
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    subject, term, ok := h.parseSubjectTerm(c)
+    if !ok {
+        return
+    }
+    
+    student, err := h.students.FindByID(studentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    criteria, err := h.evaluations.Criteria(subject.SubjectID, term.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.query_error")
+        return
+    }
+    if len(criteria) == 0 {
+        utils.RespondWithError(c, http.StatusNotFound, utils.CodeCriteriaNotFound, "evaluation.criteria_not_found")
+        return
+    }
+    
+    scores, err := h.evaluations.Scores(student.StudentID, subject.SubjectID, term.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.query_error")
+        return
+    }
+    byCriterion := make(map[int]float64, len(scores))
+    for _, score := range scores {
+        byCriterion[score.CriterionID] = score.Score
+    }
+    
+    breakdown := models.GradeBreakdown{
+        Student:    newStudentBasic(student),
+        Subject:    models.SubjectBasic{SubjectID: subject.SubjectID, Name: subject.Name},
+        Term:       newTermBasic(term),
+        Components: make([]models.ComponentBreakdown, len(criteria)),
+    }
+    for i, criterion := range criteria {
+        component := models.ComponentBreakdown{
+            CriterionID: criterion.CriterionID,
+            Name:        criterion.Name,
+            Weight:      criterion.Weight,
+        }
+        if score, ok := byCriterion[criterion.CriterionID]; ok {
+            component.Score = &score
+            component.Contribution = roundGrade(criterion.Weight * score / 100)
+        }
+        breakdown.Components[i] = component
+    }
+    
+    // La calificación final es la guardada; una calificación eliminada no se muestra
+    grade, err := h.grades.FindByKey(student.StudentID, subject.SubjectID, term.TermID)
+    switch {
+    case err == nil && !grade.DeletedAt.Valid:
+        breakdown.Grade = &grade.Grade
+    case err != nil && !errors.Is(err, repositories.ErrNotFound):
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.list_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "evaluation.breakdown_retrieved", breakdown)
+}
+
+// parseSubjectTerm obtiene la materia y el periodo de la ruta.
+// Si no son válidos o no existen ya respondió al cliente y devuelve false.
+func (h *EvaluationHandler) parseSubjectTerm(c *gin.Context) (*models.Subject, *models.Term, bool) {
+    subjectID, err := strconv.Atoi(c.Param("subject_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "subject.invalid_id")
+        return nil, nil, false
+    }
+    
+    termID, err := strconv.Atoi(c.Param("term_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "term.invalid_id")
+        return nil, nil, false
+    }
+    
+    subject, err := h.subjects.FindByID(subjectID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return nil, nil, false
+    }
+    
+    term, err := h.terms.FindByID(termID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return nil, nil, false
+    }
+    return subject, term, true
+}
+
+// authorizeCriteria verifica que el maestro autenticado imparta la materia a algún grupo en el
+// periodo o en uno que lo contenga; los administradores siempre están autorizados.
+// Si no está autorizado ya respondió al cliente y devuelve false.
+func (h *EvaluationHandler) authorizeCriteria(c *gin.Context, subjectID, termID int) bool {
+    teacher, ok := currentTeacher(c, h.teachers)
+    if !ok {
+        return false
+    }
+    if teacher == nil {
+        return true
+    }
+    
+    termIDs, err := h.terms.WithAncestors(termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    teaches, err := h.assignments.TeachesSubject(teacher.TeacherID, subjectID, termIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.query_error")
+        return false
+    }
+    if !teaches {
+        utils.RespondWithError(c, http.StatusForbidden, utils.CodeTeacherNotAssigned, "evaluation.not_assigned")
+        return false
+    }
+    return true
+}
+
+// checkScoredUnlocked verifica que no estén cerradas las calificaciones de los grupos de los
+// estudiantes que ya tienen calificaciones por criterio, porque cambiar los criterios recalcula
+// su calificación final. Si alguna está cerrada ya respondió 423 al cliente y devuelve false.
+func (h *EvaluationHandler) checkScoredUnlocked(c *gin.Context, subjectID, termID int) bool {
+    studentIDs, err := h.evaluations.ScoredStudents(subjectID, termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.query_error")
+        return false
+    }
+    if len(studentIDs) == 0 {
+        return true
+    }
+    
+    students, err := h.students.FindByIDs(studentIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return false
+    }
+    
+    termIDs, err := h.terms.WithAncestors(termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    checked := make(map[int]bool)
+    for _, student := range students {
+        if student.GroupID == nil || checked[*student.GroupID] {
+            continue
+        }
+        checked[*student.GroupID] = true
+    
+        locked, err := h.locks.IsLocked(subjectID, *student.GroupID, termIDs)
+        if err != nil {
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade_lock.query_error")
+            return false
+        }
+        if locked {
+            respondGradesLocked(c)
+            return false
+        }
+    }
+    return true
+}
+
+// newEvaluationCriteria convierte los criterios de la petición verificando que los nombres no se
+// repitan y que los pesos sumen 100. Si no son válidos ya respondió 400 al cliente y devuelve false.
+func newEvaluationCriteria(c *gin.Context, items []models.EvaluationCriterionItem) ([]models.EvaluationCriterion, bool) {
+    criteria := make([]models.EvaluationCriterion, len(items))
+    names := make(map[string]bool, len(items))
+    total := 0.0
+    for i, item := range items {
+        name := strings.TrimSpace(item.Name)
+        key := strings.ToLower(name)
+        if names[key] {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeDuplicateCriterion, "evaluation.repeated_name", name)
+            return nil, false
+        }
+        names[key] = true
+    
+        // Los pesos se guardan con dos decimales
+        weight := roundGrade(item.Weight)
+        total += weight
+        criteria[i] = models.EvaluationCriterion{Name: name, Weight: weight}
+    }
+    
+    if math.Abs(total-100) > 0.001 {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeCriteriaWeights, "evaluation.weights_sum", roundGrade(total))
+        return nil, false
+    }
+    return criteria, true
+}
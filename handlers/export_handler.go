@@ -0,0 +1,222 @@
+package handlers
+
+import (
+    "log"
+    "net/http"
+    "strconv"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/spreadsheet"
+    "ControlEscolar/utils"
+)
+
+// exportMediaTypes son los tipos que se pueden pedir en el header Accept, en orden de preferencia
+var exportMediaTypes = map[string]string{
+    "text/csv":             spreadsheet.FormatCSV,
+    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": spreadsheet.FormatXLSX,
+    "application/x-ndjson": spreadsheet.FormatNDJSON,
+}
+
+// exportMediaTypeOrder fija la preferencia cuando el cliente acepta cualquier tipo
+var exportMediaTypeOrder = []string{
+    "text/csv",
+    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+    "application/x-ndjson",
+}
+
+// ExportHandler agrupa los endpoints de exportación a CSV, XLSX y NDJSON
+type ExportHandler struct {
+    exports  repositories.ExportRepository
+    groups   repositories.GroupRepository
+    subjects repositories.SubjectRepository
+    terms    repositories.TermRepository
+}
+
+// NewExportHandler crea un ExportHandler con los repositorios indicados
+func NewExportHandler(exports repositories.ExportRepository, groups repositories.GroupRepository, subjects repositories.SubjectRepository, terms repositories.TermRepository) *ExportHandler {
+    return &ExportHandler{
+        exports:  exports,
+        groups:   groups,
+        subjects: subjects,
+        terms:    terms,
+    }
+}
+
+// ExportStudents godoc
+// @Summary      Exportar estudiantes
+// @Description  Descarga los estudiantes en CSV, XLSX o NDJSON según el header Accept o el parámetro format. Los registros se leen y escriben por partes, sin cargarlos todos en memoria.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format    query     string  false  "Formato; tiene prioridad sobre Accept"  Enums(csv, xlsx, ndjson)
+// @Param        group_id  query     int     false  "Grupo"
+// @Success      200       {file}    file
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      406       {object}  utils.ErrorResponse
+// @Router       /students/export [get]
+func (h *ExportHandler) ExportStudents(c *gin.Context) {
+    format, ok := negotiateExportFormat(c)
+    if !ok {
+        return
+    }
+    
+    var filter repositories.ExportFilter
+    if filter.GroupID, ok = h.parseGroupFilter(c); !ok {
+        return
+    }
+    
+    columns := []string{"student_id", "name", "email", "group_id", "group", "school_year"}
+    streamExport(c, format, "Estudiantes", "estudiantes", columns, func(w spreadsheet.Writer) error {
+        return h.exports.EachStudent(filter, func(row models.StudentExportRow) error {
+            return w.WriteRow([]interface{}{row.StudentID, row.Name, row.Email, row.GroupID, row.Group, row.SchoolYear})
+        })
+    })
+}
+
+// ExportSubjects godoc
+// @Summary      Exportar materias
+// @Description  Descarga las materias en CSV, XLSX o NDJSON según el header Accept o el parámetro format
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format  query     string  false  "Formato; tiene prioridad sobre Accept"  Enums(csv, xlsx, ndjson)
+// @Success      200     {file}    file
+// @Failure      400     {object}  utils.ErrorResponse
+// @Failure      406     {object}  utils.ErrorResponse
+// @Router       /subjects/export [get]
+func (h *ExportHandler) ExportSubjects(c *gin.Context) {
+    format, ok := negotiateExportFormat(c)
+    if !ok {
+        return
+    }
+    
+    columns := []string{"subject_id", "name", "credits"}
+    streamExport(c, format, "Materias", "materias", columns, func(w spreadsheet.Writer) error {
+        return h.exports.EachSubject(func(subject models.Subject) error {
+            return w.WriteRow([]interface{}{subject.SubjectID, subject.Name, subject.Credits})
+        })
+    })
+}
+
+// ExportGrades godoc
+// @Summary      Exportar calificaciones
+// @Description  Descarga las calificaciones con los nombres del estudiante, grupo, materia y periodo en CSV, XLSX o NDJSON según el header Accept o el parámetro format. Los registros se leen y escriben por partes, sin cargarlos todos en memoria.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format      query     string  false  "Formato; tiene prioridad sobre Accept"  Enums(csv, xlsx, ndjson)
+// @Param        group_id    query     int     false  "Grupo del estudiante"
+// @Param        subject_id  query     int     false  "Materia"
+// @Param        term_id     query     int     false  "Periodo (incluye sus parciales)"
+// @Success      200         {file}    file
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      406         {object}  utils.ErrorResponse
+// @Router       /grades/export [get]
+func (h *ExportHandler) ExportGrades(c *gin.Context) {
+    format, ok := negotiateExportFormat(c)
+    if !ok {
+        return
+    }
+    
+    var filter repositories.ExportFilter
+    if filter.GroupID, ok = h.parseGroupFilter(c); !ok {
+        return
+    }
+    
+    if value := c.Query("subject_id"); value != "" {
+        subjectID, err := strconv.Atoi(value)
+        if err != nil {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "subject.invalid_id")
+            return
+        }
+        if _, err := h.subjects.FindByID(subjectID); err != nil {
+            respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+            return
+        }
+        filter.SubjectID = subjectID
+    }
+    
+    if _, filter.TermIDs, ok = parseTermFilter(c, h.terms); !ok {
+        return
+    }
+    
+    columns := []string{"grade_id", "student_id", "student_name", "group_id", "group",
+        "subject_id", "subject_name", "term_id", "term_name", "school_year", "grade"}
+    streamExport(c, format, "Calificaciones", "calificaciones", columns, func(w spreadsheet.Writer) error {
+        return h.exports.EachGrade(filter, func(row models.GradeExportRow) error {
+            return w.WriteRow([]interface{}{row.GradeID, row.StudentID, row.StudentName, row.GroupID, row.Group,
+                row.SubjectID, row.SubjectName, row.TermID, row.TermName, row.SchoolYear, row.Grade})
+        })
+    })
+}
+
+// parseGroupFilter lee el group_id opcional y verifica que el grupo exista; 0 indica sin filtro.
+// Si hay un error ya respondió al cliente y ok es false.
+func (h *ExportHandler) parseGroupFilter(c *gin.Context) (groupID int, ok bool) {
+    value := c.Query("group_id")
+    if value == "" {
+        return 0, true
+    }
+    
+    groupID, err := strconv.Atoi(value)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "group.invalid_id")
+        return 0, false
+    }
+    if _, err := h.groups.FindByID(groupID); err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return 0, false
+    }
+    return groupID, true
+}
+
+// negotiateExportFormat elige el formato con el parámetro format o, si no viene, con el header Accept.
+// Si no hay un formato disponible ya respondió al cliente y ok es false.
+func negotiateExportFormat(c *gin.Context) (format string, ok bool) {
+    switch value := c.Query("format"); value {
+    case "":
+    case spreadsheet.FormatCSV, spreadsheet.FormatXLSX, spreadsheet.FormatNDJSON:
+        return value, true
+    default:
+        respondInvalidParameter(c, i18n.NewMessage("param.format"))
+        return "", false
+    }
+    
+    format, ok = exportMediaTypes[c.NegotiateFormat(exportMediaTypeOrder...)]
+    if !ok {
+        utils.RespondWithError(c, http.StatusNotAcceptable, utils.CodeNotAcceptable, "export.not_acceptable")
+        return "", false
+    }
+    return format, true
+}
+
+// streamExport escribe la respuesta como descarga mientras write recorre los registros.
+// Una vez enviado el encabezado ya no se puede cambiar el código de estado, así que los
+// errores a mitad de la exportación solo se registran y la descarga queda incompleta.
+func streamExport(c *gin.Context, format, title, filename string, columns []string, write func(spreadsheet.Writer) error) {
+    c.Header("Content-Type", spreadsheet.ContentTypes[format])
+    c.Header("Content-Disposition", `attachment; filename="`+filename+"."+format+`"`)
+    c.Status(http.StatusOK)
+    
+    writer, err := spreadsheet.NewWriter(c.Writer, format, title, columns)
+    if err == nil {
+        err = write(writer)
+        if closeErr := writer.Close(); err == nil {
+            err = closeErr
+        }
+    }
+    if err != nil {
+        log.Printf("Error exportando %s: %v", filename, err)
+    }
+}
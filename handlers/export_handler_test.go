@@ -0,0 +1,25 @@
+package handlers_test
+
+import (
+    "net/http"
+    "strings"
+    "testing"
+    
+    "ControlEscolar/utils"
+)
+
+func TestExportStudentsWritesCSVForStaff(t *testing.T) {
+    f := newSchoolFixture(t)
+    
+    recorder := performRequest(f.router, http.MethodGet, "/api/students/export?format=csv", f.assigned, nil)
+    assertStatus(t, recorder, http.StatusOK)
+    if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/csv") {
+        t.Errorf("Content-Type = %q, se esperaba text/csv", contentType)
+    }
+    lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+    if len(lines) != 2 || !strings.Contains(lines[1], f.student.Email) {
+        t.Errorf("se esperaba el encabezado y una fila con %s, se obtuvo:\n%s", f.student.Email, recorder.Body.String())
+    }
+    
+    assertError(t, performRequest(f.router, http.MethodGet, "/api/students/export?format=csv", f.newStudentAccount(t), nil), http.StatusForbidden, utils.CodeForbidden)
+}
@@ -1,47 +1,128 @@
 package handlers
 
 import (
+    "errors"
     "log"
     "net/http"
     "strconv"
+    "unicode/utf8"
     
     "github.com/gin-gonic/gin"
-    "ControlEscolar/config"
+    "github.com/gin-gonic/gin/binding"
+    "github.com/go-playground/validator/v10"
+    "ControlEscolar/auth"
+    "ControlEscolar/i18n"
     "ControlEscolar/models"
+    "ControlEscolar/repositories"
     "ControlEscolar/utils"
 )
 
+// GradeHandler agrupa los endpoints de calificaciones
+type GradeHandler struct {
+    grades       repositories.GradeRepository
+    students     repositories.StudentRepository
+    subjects     repositories.SubjectRepository
+    terms        repositories.TermRepository
+    teachers     repositories.TeacherRepository
+    assignments  repositories.AssignmentRepository
+    enrollments  repositories.EnrollmentRepository
+    locks        repositories.GradeLockRepository
+    evaluations  repositories.EvaluationRepository
+    scales       repositories.GradingScaleRepository
+    // passingGrade es el valor aprobatorio de la escala de 0 a 100 que se usa sin asignación
+    passingGrade float64
+}
+
+// NewGradeHandler crea un GradeHandler con los repositorios indicados
+func NewGradeHandler(grades repositories.GradeRepository, students repositories.StudentRepository, subjects repositories.SubjectRepository, terms repositories.TermRepository, teachers repositories.TeacherRepository, assignments repositories.AssignmentRepository, enrollments repositories.EnrollmentRepository, locks repositories.GradeLockRepository, evaluations repositories.EvaluationRepository, scales repositories.GradingScaleRepository, passingGrade float64) *GradeHandler {
+    return &GradeHandler{
+        grades:       grades,
+        students:     students,
+        subjects:     subjects,
+        terms:        terms,
+        teachers:     teachers,
+        assignments:  assignments,
+        enrollments:  enrollments,
+        locks:        locks,
+        evaluations:  evaluations,
+        scales:       scales,
+        passingGrade: passingGrade,
+    }
+}
+
 // CreateGrade godoc
 // @Summary      Crear una nueva calificación
-// @Description  Registra una nueva calificación para un estudiante en una materia
+// @Description  Registra una nueva calificación para un estudiante en una materia durante un periodo. Solo el maestro asignado a la materia en el grupo del estudiante puede registrarla, y el estudiante debe estar inscrito en la materia en el periodo. La calificación se captura en la escala de la materia en el grado escolar del estudiante (de 0 a 100 si no tiene una asignada) y se guarda de 0 a 100. Si la materia tiene criterios de evaluación en el periodo la calificación se calcula con ellos y no se puede capturar directamente.
 // @Tags         grades
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        grade  body      models.CreateGradeRequest  true  "Información de la calificación"
 // @Success      201    {object}  utils.SuccessResponse{data=models.GradeResponse}
 // @Failure      400    {object}  utils.ErrorResponse
+// @Failure      403    {object}  utils.ErrorResponse
 // @Failure      404    {object}  utils.ErrorResponse
+// @Failure      409    {object}  utils.ErrorResponse{details=models.GradeConflictDetails}
+// @Failure      423    {object}  utils.ErrorResponse
 // @Failure      500    {object}  utils.ErrorResponse
 // @Router       /grades [post]
-func CreateGrade(c *gin.Context) {
+func (h *GradeHandler) CreateGrade(c *gin.Context) {
     var request models.CreateGradeRequest
     
     if err := c.ShouldBindJSON(&request); err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "Datos inválidos: "+err.Error())
+        respondBindingError(c, err)
         return
     }
     
     // Verificar que el estudiante existe
-    var student models.Student
-    if err := config.GetDB().First(&student, request.StudentID).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Estudiante no encontrado")
+    student, err := h.students.FindByID(request.StudentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
         return
     }
     
     // Verificar que la materia existe
-    var subject models.Subject
-    if err := config.GetDB().First(&subject, request.SubjectID).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Materia no encontrada")
+    subject, err := h.subjects.FindByID(request.SubjectID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    // Verificar que el periodo existe
+    term, err := h.terms.FindByID(request.TermID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    if !h.authorizeGrading(c, student, subject.SubjectID, &term.TermID) {
+        return
+    }
+    
+    if !h.checkUnlocked(c, student, subject.SubjectID, &term.TermID) {
+        return
+    }
+    
+    if !h.checkNotComputed(c, subject.SubjectID, &term.TermID) {
+        return
+    }
+    
+    if !h.checkEnrollment(c, student.StudentID, subject.SubjectID, term.TermID) {
+        return
+    }
+    
+    scales := h.scaleResolver()
+    value, ok := h.capturedGrade(c, scales, subject.SubjectID, student, *request.Grade)
+    if !ok {
+        return
+    }
+    
+    // Solo puede existir una calificación por estudiante, materia y periodo
+    if existing, err := h.grades.FindByKey(request.StudentID, request.SubjectID, request.TermID); err == nil {
+        respondGradeConflict(c, existing)
+        return
+    } else if !errors.Is(err, repositories.ErrNotFound) {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.create_error")
         return
     }
     
@@ -49,131 +130,549 @@ func CreateGrade(c *gin.Context) {
     grade := models.Grade{
         StudentID: request.StudentID,
         SubjectID: request.SubjectID,
-        Grade:     request.Grade,
+        TermID:    &term.TermID,
+        Grade:     value,
     }
     
-    if err := config.GetDB().Create(&grade).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al crear la calificación")
+    if err := h.grades.Create(&grade, gradeChange(c, "")); err != nil {
+        // Otra petición pudo crear la misma calificación después de la verificación
+        if errors.Is(err, repositories.ErrDuplicate) {
+            if existing, findErr := h.grades.FindByKey(request.StudentID, request.SubjectID, request.TermID); findErr == nil {
+                respondGradeConflict(c, existing)
+                return
+            }
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.create_error")
         return
     }
     
-    // Preparar respuesta con información completa
-    response := models.GradeResponse{
-        GradeID:   grade.GradeID,
-        StudentID: grade.StudentID,
-        SubjectID: grade.SubjectID,
-        Grade:     grade.Grade,
-        Student: &models.StudentBasic{
-            StudentID: student.StudentID,
-            Name:      student.Name,
-            Group:     student.Group,
-            Email:     student.Email,
-        },
-        Subject: &models.SubjectBasic{
-            SubjectID: subject.SubjectID,
-            Name:      subject.Name,
-        },
-    }
-    
-    utils.RespondWithSuccess(c, http.StatusCreated, "Calificación creada exitosamente", response)
+    utils.RespondWithSuccess(c, http.StatusCreated, "grade.created", newScaledGradeResponse(scales, &grade, student, subject, term))
 }
 
 // UpdateGrade godoc
 // @Summary      Actualizar una calificación
-// @Description  Actualiza el valor de una calificación existente
+// @Description  Actualiza el valor de una calificación existente. Solo el maestro asignado a la materia en el grupo del estudiante puede actualizarla. La calificación se captura en la escala de la materia en el grado escolar del estudiante. El valor anterior, la cuenta que hizo el cambio y el motivo quedan en el historial.
 // @Tags         grades
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        grade_id  path      int                        true  "ID de la calificación"
 // @Param        grade     body      models.UpdateGradeRequest  true  "Nueva calificación"
 // @Success      200       {object}  utils.SuccessResponse{data=models.GradeResponse}
 // @Failure      400       {object}  utils.ErrorResponse
+// @Failure      403       {object}  utils.ErrorResponse
 // @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      423       {object}  utils.ErrorResponse
 // @Failure      500       {object}  utils.ErrorResponse
 // @Router       /grades/{grade_id} [put]
-func UpdateGrade(c *gin.Context) {
+func (h *GradeHandler) UpdateGrade(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("grade_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var grade models.Grade
-    if err := config.GetDB().First(&grade, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Calificación no encontrada")
+    grade, err := h.grades.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGradeNotFound, "grade.not_found")
         return
     }
     
     var request models.UpdateGradeRequest
     if err := c.ShouldBindJSON(&request); err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "Datos inválidos: "+err.Error())
+        respondBindingError(c, err)
+        return
+    }
+    
+    student, err := h.students.FindByID(grade.StudentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    if !h.authorizeGrading(c, student, grade.SubjectID, grade.TermID) {
+        return
+    }
+    
+    if !h.checkUnlocked(c, student, grade.SubjectID, grade.TermID) {
+        return
+    }
+    
+    if !h.checkNotComputed(c, grade.SubjectID, grade.TermID) {
+        return
+    }
+    
+    scales := h.scaleResolver()
+    value, ok := h.capturedGrade(c, scales, grade.SubjectID, student, *request.Grade)
+    if !ok {
         return
     }
     
     // Actualizar solo el campo grade
-    grade.Grade = request.Grade
+    grade.Grade = value
     
-    if err := config.GetDB().Save(&grade).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al actualizar calificación")
+    if err := h.grades.Update(grade, gradeChange(c, request.Reason)); err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.update_error")
         return
     }
     
     // Obtener información completa para la respuesta
-    var student models.Student
-    var subject models.Subject
-    config.GetDB().First(&student, grade.StudentID)
-    config.GetDB().First(&subject, grade.SubjectID)
-    
-    response := models.GradeResponse{
-        GradeID:   grade.GradeID,
-        StudentID: grade.StudentID,
-        SubjectID: grade.SubjectID,
-        Grade:     grade.Grade,
-        Student: &models.StudentBasic{
-            StudentID: student.StudentID,
-            Name:      student.Name,
-            Group:     student.Group,
-            Email:     student.Email,
-        },
-        Subject: &models.SubjectBasic{
-            SubjectID: subject.SubjectID,
-            Name:      subject.Name,
-        },
-    }
-    
-    utils.RespondWithSuccess(c, http.StatusOK, "Calificación actualizada exitosamente", response)
+    subject, _ := h.subjects.FindByID(grade.SubjectID)
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.updated", newScaledGradeResponse(scales, grade, student, subject, h.findTerm(grade.TermID)))
+}
+
+// UpsertGrade godoc
+// @Summary      Registrar o actualizar la calificación de un periodo
+// @Description  Crea la calificación del estudiante en la materia y periodo indicados, o la actualiza si ya existe; si estaba eliminada la restaura con el nuevo valor. Solo el maestro asignado a la materia en el grupo del estudiante puede hacerlo, y el estudiante debe estar inscrito en la materia en el periodo. La calificación se captura en la escala de la materia en el grado escolar del estudiante.
+// @Tags         grades
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        student_id  path      int                        true  "ID del estudiante"
+// @Param        subject_id  path      int                        true  "ID de la materia"
+// @Param        term_id     path      int                        true  "ID del periodo"
+// @Param        grade       body      models.UpdateGradeRequest  true  "Calificación"
+// @Success      200         {object}  utils.SuccessResponse{data=models.GradeResponse}
+// @Success      201         {object}  utils.SuccessResponse{data=models.GradeResponse}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      403         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      423         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /grades/student/{student_id}/subject/{subject_id}/term/{term_id} [put]
+func (h *GradeHandler) UpsertGrade(c *gin.Context) {
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    subjectID, err := strconv.Atoi(c.Param("subject_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "subject.invalid_id")
+        return
+    }
+    
+    termID, err := strconv.Atoi(c.Param("term_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "term.invalid_id")
+        return
+    }
+    
+    var request models.UpdateGradeRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    student, err := h.students.FindByID(studentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    subject, err := h.subjects.FindByID(subjectID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    term, err := h.terms.FindByID(termID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    if !h.authorizeGrading(c, student, subject.SubjectID, &term.TermID) {
+        return
+    }
+    
+    if !h.checkUnlocked(c, student, subject.SubjectID, &term.TermID) {
+        return
+    }
+    
+    if !h.checkNotComputed(c, subject.SubjectID, &term.TermID) {
+        return
+    }
+    
+    if !h.checkEnrollment(c, student.StudentID, subject.SubjectID, term.TermID) {
+        return
+    }
+    
+    scales := h.scaleResolver()
+    value, ok := h.capturedGrade(c, scales, subject.SubjectID, student, *request.Grade)
+    if !ok {
+        return
+    }
+    
+    grade := models.Grade{
+        StudentID: studentID,
+        SubjectID: subjectID,
+        TermID:    &term.TermID,
+        Grade:     value,
+    }
+    
+    created, err := h.grades.Upsert(&grade, gradeChange(c, request.Reason))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.save_error")
+        return
+    }
+    
+    response := newScaledGradeResponse(scales, &grade, student, subject, term)
+    if created {
+        utils.RespondWithSuccess(c, http.StatusCreated, "grade.created", response)
+        return
+    }
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.updated", response)
+}
+
+// CreateGradesBatch godoc
+// @Summary      Capturar las calificaciones de varios estudiantes
+// @Description  Registra o actualiza en una sola operación las calificaciones de varios estudiantes (hasta 200) en una materia y periodo. Cada elemento se valida como en la captura individual, incluida la escala de cada estudiante; si alguno es rechazado no se guarda ninguna calificación y la respuesta lista los rechazados.
+// @Tags         grades
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        grades  body      models.BatchGradeRequest  true  "Materia, periodo y calificaciones"
+// @Success      200     {object}  utils.SuccessResponse{data=models.BatchGradeResponse}
+// @Failure      400     {object}  utils.ErrorResponse{details=models.BatchGradeResponse}
+// @Failure      403     {object}  utils.ErrorResponse
+// @Failure      404     {object}  utils.ErrorResponse
+// @Failure      409     {object}  utils.ErrorResponse
+// @Failure      423     {object}  utils.ErrorResponse{details=models.BatchGradeResponse}
+// @Failure      500     {object}  utils.ErrorResponse
+// @Router       /grades/batch [post]
+func (h *GradeHandler) CreateGradesBatch(c *gin.Context) {
+    var request models.BatchGradeRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if _, err := h.subjects.FindByID(request.SubjectID); err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    term, err := h.terms.FindByID(request.TermID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    teacher, ok := currentTeacher(c, h.teachers)
+    if !ok {
+        return
+    }
+    
+    if !h.checkNotComputed(c, request.SubjectID, &term.TermID) {
+        return
+    }
+    
+    termIDs, err := h.terms.WithAncestors(term.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return
+    }
+    
+    // Las calificaciones se capturan en la escala de cada estudiante y se convierten a 0-100 al validarlas
+    scales := h.scaleResolver()
+    response, err := h.validateGradeBatch(&request, teacher, termIDs, scales, utils.Language(c))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return
+    }
+    if len(response.Failed) > 0 {
+        respondBatchRejected(c, response)
+        return
+    }
+    
+    grades := make([]models.Grade, len(request.Grades))
+    for i, item := range request.Grades {
+        grades[i] = models.Grade{
+            StudentID: item.StudentID,
+            SubjectID: request.SubjectID,
+            TermID:    &term.TermID,
+            Grade:     *item.Grade,
+        }
+    }
+    
+    created, err := h.grades.UpsertMany(grades, gradeChange(c, request.Reason))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.batch_save_error")
+        return
+    }
+    
+    results, err := h.newScaledBatchResults(scales, grades)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return
+    }
+    for i, result := range results {
+        if created[i] {
+            response.Created = append(response.Created, result)
+        } else {
+            response.Updated = append(response.Updated, result)
+        }
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.saved", response)
+}
+
+// RecordComponentScores godoc
+// @Summary      Capturar las calificaciones de un criterio de evaluación
+// @Description  Registra o actualiza la calificación de varios estudiantes (hasta 200) en un criterio de evaluación y recalcula su calificación final en la materia y periodo del criterio como la suma ponderada de sus criterios; los criterios sin capturar cuentan como 0. Cada elemento se valida como en la captura masiva de calificaciones; si alguno es rechazado no se guarda ninguna calificación y la respuesta lista los rechazados.
+// @Tags         grades
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        scores  body      models.ComponentScoreRequest  true  "Criterio y calificaciones"
+// @Success      200     {object}  utils.SuccessResponse{data=models.ComponentScoreResponse}
+// @Failure      400     {object}  utils.ErrorResponse{details=models.BatchGradeResponse}
+// @Failure      403     {object}  utils.ErrorResponse
+// @Failure      404     {object}  utils.ErrorResponse
+// @Failure      423     {object}  utils.ErrorResponse{details=models.BatchGradeResponse}
+// @Failure      500     {object}  utils.ErrorResponse
+// @Router       /grades/components [post]
+func (h *GradeHandler) RecordComponentScores(c *gin.Context) {
+    var request models.ComponentScoreRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    criterion, err := h.evaluations.FindCriterion(request.CriterionID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeCriterionNotFound, "evaluation.criterion_not_found")
+        return
+    }
+    
+    if _, err := h.subjects.FindByID(criterion.SubjectID); err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    teacher, ok := currentTeacher(c, h.teachers)
+    if !ok {
+        return
+    }
+    
+    termIDs, err := h.terms.WithAncestors(criterion.TermID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return
+    }
+    
+    // Cada calificación del criterio se valida como una captura masiva de la materia y periodo
+    batch := models.BatchGradeRequest{
+        SubjectID: criterion.SubjectID,
+        TermID:    criterion.TermID,
+        Grades:    request.Scores,
+        Reason:    request.Reason,
+    }
+    response, err := h.validateGradeBatch(&batch, teacher, termIDs, nil, utils.Language(c))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return
+    }
+    if len(response.Failed) > 0 {
+        respondBatchRejected(c, response)
+        return
+    }
+    
+    scores := make([]models.ComponentScore, len(request.Scores))
+    for i, item := range request.Scores {
+        scores[i] = models.ComponentScore{
+            StudentID: item.StudentID,
+            Score:     *item.Grade,
+        }
+    }
+    
+    grades, err := h.evaluations.RecordScores(criterion, scores, gradeChange(c, request.Reason))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.scores_error")
+        return
+    }
+    
+    results, err := h.newScaledBatchResults(h.scaleResolver(), grades)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "evaluation.scores_saved", models.ComponentScoreResponse{
+        Criterion: *criterion,
+        Scores:    scores,
+        Grades:    results,
+    })
 }
 
 // DeleteGrade godoc
 // @Summary      Eliminar una calificación
-// @Description  Elimina una calificación del sistema
+// @Description  Marca una calificación como eliminada; se puede restaurar después. Su historial incluye la eliminación. Un maestro solo puede eliminar las calificaciones de la materia en los grupos y periodos que tiene asignados; no se pueden eliminar calificaciones cerradas ni calculadas con criterios de evaluación.
 // @Tags         grades
 // @Produce      json
-// @Param        grade_id  path      int  true  "ID de la calificación"
+// @Security     BearerAuth
+// @Param        grade_id  path      int     true   "ID de la calificación"
+// @Param        reason    query     string  false  "Motivo de la eliminación (máximo 255 caracteres)"
 // @Success      200       {object}  utils.SuccessResponse
 // @Failure      400       {object}  utils.ErrorResponse
+// @Failure      403       {object}  utils.ErrorResponse
 // @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      423       {object}  utils.ErrorResponse
 // @Failure      500       {object}  utils.ErrorResponse
 // @Router       /grades/{grade_id} [delete]
-func DeleteGrade(c *gin.Context) {
+func (h *GradeHandler) DeleteGrade(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("grade_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    reason := c.Query("reason")
+    if utf8.RuneCountInString(reason) > 255 {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidParameter, "grade.reason_too_long")
+        return
+    }
+    
+    grade, err := h.grades.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGradeNotFound, "grade.not_found")
+        return
+    }
+    
+    student, err := h.students.FindByID(grade.StudentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    if !h.authorizeGrading(c, student, grade.SubjectID, grade.TermID) {
+        return
+    }
+    
+    if !h.checkUnlocked(c, student, grade.SubjectID, grade.TermID) {
+        return
+    }
+    
+    if !h.checkNotComputed(c, grade.SubjectID, grade.TermID) {
+        return
+    }
+    
+    if err := h.grades.Delete(id, gradeChange(c, reason)); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeGradeNotFound, "grade.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.delete_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.deleted", nil)
+}
+
+// RestoreGrade godoc
+// @Summary      Restaurar una calificación eliminada
+// @Description  Quita la marca de eliminada de una calificación y registra la restauración en su historial. El estudiante y la materia deben estar activos. Un maestro solo puede restaurar las calificaciones de la materia en los grupos y periodos que tiene asignados; no se pueden restaurar calificaciones cerradas ni de materias calculadas con criterios de evaluación.
+// @Tags         grades
+// @Produce      json
+// @Security     BearerAuth
+// @Param        grade_id  path      int  true  "ID de la calificación"
+// @Success      200       {object}  utils.SuccessResponse{data=models.GradeResponse}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      403       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      423       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /grades/{grade_id}/restore [post]
+func (h *GradeHandler) RestoreGrade(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("grade_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    grade, err := h.grades.FindWithDeleted(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGradeNotFound, "grade.not_found")
+        return
+    }
+    
+    // La calificación no puede quedar activa si su estudiante o su materia están eliminados
+    student, err := h.students.FindByID(grade.StudentID)
+    if err != nil {
+        respondParentLookupError(c, err)
+        return
+    }
+    subject, err := h.subjects.FindByID(grade.SubjectID)
+    if err != nil {
+        respondParentLookupError(c, err)
+        return
+    }
+    
+    if !h.authorizeGrading(c, student, grade.SubjectID, grade.TermID) {
         return
     }
     
-    var grade models.Grade
-    if err := config.GetDB().First(&grade, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Calificación no encontrada")
+    if !h.checkUnlocked(c, student, grade.SubjectID, grade.TermID) {
         return
     }
     
-    if err := config.GetDB().Delete(&grade).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al eliminar calificación")
+    if !h.checkNotComputed(c, grade.SubjectID, grade.TermID) {
         return
     }
     
-    utils.RespondWithSuccess(c, http.StatusOK, "Calificación eliminada exitosamente", nil)
+    if grade, err = h.grades.Restore(id, gradeChange(c, "")); err != nil {
+        respondLookupError(c, err, utils.CodeGradeNotFound, "grade.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.restored", newScaledGradeResponse(h.scaleResolver(), grade, student, subject, h.findTerm(grade.TermID)))
+}
+
+// GetGradeHistory godoc
+// @Summary      Historial de una calificación
+// @Description  Devuelve los cambios de una calificación del más antiguo al más reciente: valor anterior, valor nuevo, cuenta que hizo el cambio, fecha y motivo. El historial se conserva aunque la calificación se elimine.
+// @Tags         grades
+// @Produce      json
+// @Security     BearerAuth
+// @Param        grade_id  path      int  true  "ID de la calificación"
+// @Success      200       {object}  utils.SuccessResponse{data=[]models.GradeHistory}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /grades/{grade_id}/history [get]
+func (h *GradeHandler) GetGradeHistory(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("grade_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    history, err := h.grades.History(id)
+    if err != nil {
+        log.Printf("Error obteniendo historial de calificación: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.history_error")
+        return
+    }
+    
+    // Sin historial solo se responde 404 si la calificación tampoco existe; las calificaciones
+    // registradas antes del historial no tienen cambios
+    if len(history) == 0 {
+        if _, err := h.grades.FindWithDeleted(id); err != nil {
+            respondLookupError(c, err, utils.CodeGradeNotFound, "grade.not_found")
+            return
+        }
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.history_retrieved", history)
 }
 
 // GetGradeByStudentAndSubject godoc
@@ -181,120 +680,468 @@ func DeleteGrade(c *gin.Context) {
 // @Description  Obtiene una calificación específica de un estudiante por grade_id y student_id
 // @Tags         grades
 // @Produce      json
+// @Security     BearerAuth
 // @Param        grade_id    path      int  true  "ID de la calificación"
 // @Param        student_id  path      int  true  "ID del estudiante"
-// @Success      200         {object}  models.GradeResponse
+// @Success      200         {object}  utils.SuccessResponse{data=models.GradeResponse}
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
 // @Router       /grades/{grade_id}/student/{student_id} [get]
-func GetGradeByStudentAndSubject(c *gin.Context) {
+func (h *GradeHandler) GetGradeByStudentAndSubject(c *gin.Context) {
     gradeID, err := strconv.Atoi(c.Param("grade_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID de calificación inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "grade.invalid_id")
         return
     }
     
     studentID, err := strconv.Atoi(c.Param("student_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID de estudiante inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
         return
     }
     
     // Buscar la calificación
-    var grade models.Grade
-    if err := config.GetDB().
-        Where("grade_id = ? AND student_id = ?", gradeID, studentID).
-        First(&grade).Error; err != nil {
+    grade, err := h.grades.FindByIDAndStudent(gradeID, studentID)
+    if err != nil {
         log.Printf("Error buscando calificación: %v", err)
-        utils.RespondWithError(c, http.StatusNotFound, "Calificación no encontrada")
-        return
-    }
-    
-    // Obtener información del estudiante y materia
-    var student models.Student
-    var subject models.Subject
-    config.GetDB().First(&student, grade.StudentID)
-    config.GetDB().First(&subject, grade.SubjectID)
-    
-    response := models.GradeResponse{
-        GradeID:   grade.GradeID,
-        StudentID: grade.StudentID,
-        SubjectID: grade.SubjectID,
-        Grade:     grade.Grade,
-        Student: &models.StudentBasic{
-            StudentID: student.StudentID,
-            Name:      student.Name,
-            Group:     student.Group,
-            Email:     student.Email,
-        },
-        Subject: &models.SubjectBasic{
-            SubjectID: subject.SubjectID,
-            Name:      subject.Name,
-        },
-    }
-    
-    c.JSON(http.StatusOK, response)
+        respondLookupError(c, err, utils.CodeGradeNotFound, "grade.not_found")
+        return
+    }
+    
+    // Obtener información del estudiante, materia y periodo
+    student, _ := h.students.FindByID(grade.StudentID)
+    subject, _ := h.subjects.FindByID(grade.SubjectID)
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.retrieved", newScaledGradeResponse(h.scaleResolver(), grade, student, subject, h.findTerm(grade.TermID)))
 }
 
 // GetStudentGrades godoc
 // @Summary      Obtener todas las calificaciones de un estudiante
-// @Description  Obtiene todas las calificaciones registradas para un estudiante específico
+// @Description  Obtiene las calificaciones registradas para un estudiante específico. Con term_id solo devuelve las de ese periodo y sus subperiodos. Las calificaciones eliminadas y las de materias eliminadas solo se incluyen con include_deleted (administradores). En el portal de padres el tutor solo puede consultar a los estudiantes vinculados a su cuenta.
 // @Tags         grades
 // @Produce      json
-// @Param        student_id  path      int  true  "ID del estudiante"
-// @Success      200         {array}   models.GradeResponse
-// @Failure      400         {object}  utils.ErrorResponse
-// @Failure      404         {object}  utils.ErrorResponse
-// @Failure      500         {object}  utils.ErrorResponse
+// @Security     BearerAuth
+// @Param        student_id       path      int   true   "ID del estudiante"
+// @Param        term_id          query     int   false  "Filtrar por periodo (incluye sus parciales)"
+// @Param        include_deleted  query     bool  false  "Incluir calificaciones eliminadas (solo administradores)"  default(false)
+// @Success      200              {object}  utils.SuccessResponse{data=[]models.GradeResponse}
+// @Failure      400              {object}  utils.ErrorResponse
+// @Failure      403              {object}  utils.ErrorResponse
+// @Failure      404              {object}  utils.ErrorResponse
+// @Failure      500              {object}  utils.ErrorResponse
 // @Router       /grades/student/{student_id} [get]
-func GetStudentGrades(c *gin.Context) {
+// @Router       /guardians/me/students/{student_id}/grades [get]
+func (h *GradeHandler) GetStudentGrades(c *gin.Context) {
     studentID, err := strconv.Atoi(c.Param("student_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID de estudiante inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
         return
     }
     
     // Verificar que el estudiante existe
-    var student models.Student
-    if err := config.GetDB().First(&student, studentID).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Estudiante no encontrado")
+    student, err := h.students.FindByID(studentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
         return
     }
     
-    // Obtener todas las calificaciones del estudiante
-    var grades []models.Grade
-    if err := config.GetDB().
-        Where("student_id = ?", studentID).
-        Find(&grades).Error; err != nil {
+    // Filtrar por periodo, incluyendo los periodos que contiene
+    var termIDs []int
+    if value := c.Query("term_id"); value != "" {
+        termID, err := strconv.Atoi(value)
+        if err != nil {
+            utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "term.invalid_id")
+            return
+        }
+        if _, err := h.terms.FindByID(termID); err != nil {
+            respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+            return
+        }
+        if termIDs, err = h.terms.WithDescendants(termID); err != nil {
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.list_error")
+            return
+        }
+    }
+    
+    includeDeleted, ok := parseIncludeDeleted(c)
+    if !ok {
+        return
+    }
+    
+    // Obtener las calificaciones del estudiante
+    grades, err := h.grades.FindByStudent(studentID, termIDs, includeDeleted)
+    if err != nil {
         log.Printf("Error obteniendo calificaciones: %v", err)
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al obtener calificaciones")
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade.list_error")
         return
     }
     
-    // Preparar respuestas con información completa
-    var responses []models.GradeResponse
-    for _, grade := range grades {
-        var subject models.Subject
-        config.GetDB().First(&subject, grade.SubjectID)
+    // Preparar respuestas con información completa, consultando cada materia, periodo y escala una sola vez
+    scales := h.scaleResolver()
+    subjects := make(map[int]*models.Subject)
+    terms := make(map[int]*models.Term)
+    responses := []models.GradeResponse{}
+    for i := range grades {
+        subject, ok := subjects[grades[i].SubjectID]
+        if !ok {
+            subject, _ = h.subjects.FindByID(grades[i].SubjectID)
+            subjects[grades[i].SubjectID] = subject
+        }
+        
+        var term *models.Term
+        if grades[i].TermID != nil {
+            if term, ok = terms[*grades[i].TermID]; !ok {
+                term = h.findTerm(grades[i].TermID)
+                terms[*grades[i].TermID] = term
+            }
+        }
         
-        response := models.GradeResponse{
+        responses = append(responses, newScaledGradeResponse(scales, &grades[i], student, subject, term))
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade.list_retrieved", responses)
+}
+
+// findTerm obtiene el periodo de una calificación, o nil si no tiene o no existe
+func (h *GradeHandler) findTerm(termID *int) *models.Term {
+    if termID == nil {
+        return nil
+    }
+    term, err := h.terms.FindByID(*termID)
+    if err != nil {
+        return nil
+    }
+    return term
+}
+
+// authorizeGrading verifica que el maestro autenticado esté asignado a la materia en el grupo
+// del estudiante, en el periodo de la calificación o en uno que lo contenga.
+// Si no está autorizado ya respondió al cliente y devuelve false.
+func (h *GradeHandler) authorizeGrading(c *gin.Context, student *models.Student, subjectID int, termID *int) bool {
+    teacher, ok := currentTeacher(c, h.teachers)
+    if !ok {
+        return false
+    }
+    if teacher == nil {
+        return true
+    }
+    
+    // Las calificaciones sin periodo o de estudiantes sin grupo no tienen maestro asignado
+    if termID == nil || student.GroupID == nil {
+        respondNotAssigned(c)
+        return false
+    }
+    
+    termIDs, err := h.terms.WithAncestors(*termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    assigned, err := h.assignments.IsAssigned(teacher.TeacherID, subjectID, *student.GroupID, termIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.query_error")
+        return false
+    }
+    if !assigned {
+        respondNotAssigned(c)
+        return false
+    }
+    return true
+}
+
+// checkEnrollment verifica que el estudiante esté inscrito en la materia en el periodo de la
+// calificación o en uno que lo contenga. Si no lo está ya respondió al cliente y devuelve false.
+func (h *GradeHandler) checkEnrollment(c *gin.Context, studentID, subjectID, termID int) bool {
+    termIDs, err := h.terms.WithAncestors(termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    enrolled, err := h.enrollments.IsEnrolled(studentID, subjectID, termIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "enrollment.query_error")
+        return false
+    }
+    if !enrolled {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeNotEnrolled, "enrollment.not_enrolled")
+        return false
+    }
+    return true
+}
+
+// checkUnlocked verifica que las calificaciones de la materia en el grupo del estudiante no estén
+// cerradas en el periodo de la calificación ni en uno que lo contenga. Si están cerradas ya
+// respondió 423 al cliente y devuelve false.
+func (h *GradeHandler) checkUnlocked(c *gin.Context, student *models.Student, subjectID int, termID *int) bool {
+    // Las calificaciones sin periodo o de estudiantes sin grupo no se pueden cerrar
+    if termID == nil || student.GroupID == nil {
+        return true
+    }
+    
+    termIDs, err := h.terms.WithAncestors(*termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    locked, err := h.locks.IsLocked(subjectID, *student.GroupID, termIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade_lock.query_error")
+        return false
+    }
+    if locked {
+        respondGradesLocked(c)
+        return false
+    }
+    return true
+}
+
+// checkNotComputed verifica que la calificación de la materia en el periodo no se calcule con
+// criterios de evaluación. Si se calcula ya respondió 409 al cliente y devuelve false.
+func (h *GradeHandler) checkNotComputed(c *gin.Context, subjectID int, termID *int) bool {
+    // Las calificaciones sin periodo no tienen criterios
+    if termID == nil {
+        return true
+    }
+    
+    computed, err := h.evaluations.HasCriteria(subjectID, *termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "evaluation.query_error")
+        return false
+    }
+    if computed {
+        utils.RespondWithError(c, http.StatusConflict, utils.CodeGradeComputed, "grade.computed")
+        return false
+    }
+    return true
+}
+
+// scaleResolver crea un scaleResolver para las calificaciones de una petición
+func (h *GradeHandler) scaleResolver() *scaleResolver {
+    return newScaleResolver(h.scales, h.passingGrade)
+}
+
+// capturedGrade verifica que value esté dentro de la escala de la materia en el grado escolar del
+// estudiante y lo convierte a la calificación de 0 a 100 que se guarda. Si está fuera de la escala
+// ya respondió 400 al cliente y devuelve false.
+func (h *GradeHandler) capturedGrade(c *gin.Context, scales *scaleResolver, subjectID int, student *models.Student, value float64) (float64, bool) {
+    scale, err := scales.For(subjectID, student)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.query_error")
+        return 0, false
+    }
+    if !scale.InRange(value) {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeGradeOutOfScale, "grade.out_of_scale", scale.MinValue, scale.MaxValue)
+        return 0, false
+    }
+    return scale.ToPercentage(value), true
+}
+
+// newScaledBatchResults resume las calificaciones guardadas para la respuesta con su representación
+// en la escala de cada estudiante
+func (h *GradeHandler) newScaledBatchResults(scales *scaleResolver, grades []models.Grade) ([]models.BatchGradeResult, error) {
+    studentIDs := make([]int, len(grades))
+    for i, grade := range grades {
+        studentIDs[i] = grade.StudentID
+    }
+    students, err := h.students.FindByIDs(studentIDs)
+    if err != nil {
+        return nil, err
+    }
+    
+    results := newBatchGradeResults(grades)
+    for i := range results {
+        var student *models.Student
+        if found, ok := students[results[i].StudentID]; ok {
+            student = &found
+        }
+        results[i].Scaled = scales.Scaled(grades[i].SubjectID, student, results[i].Grade)
+    }
+    return results, nil
+}
+
+// validateGradeBatch revisa cada elemento de una captura masiva con las mismas reglas de la
+// captura individual: datos válidos, estudiante existente, maestro asignado, calificaciones
+// abiertas, inscripción y, si scales no es nil, calificación dentro de la escala del estudiante.
+// Con scales las calificaciones válidas de request se convierten a 0-100.
+// Los elementos rechazados se devuelven en Failed con el mensaje en el idioma lang; err indica
+// una falla de la base de datos.
+func (h *GradeHandler) validateGradeBatch(request *models.BatchGradeRequest, teacher *models.Teacher, termIDs []int, scales *scaleResolver, lang string) (models.BatchGradeResponse, error) {
+    response := models.BatchGradeResponse{
+        Created: []models.BatchGradeResult{},
+        Updated: []models.BatchGradeResult{},
+        Failed:  []models.BatchGradeFailure{},
+    }
+    fail := func(index int, item models.BatchGradeItem, code string, message string) {
+        response.Failed = append(response.Failed, models.BatchGradeFailure{
+            Index:     index,
+            StudentID: item.StudentID,
+            Code:      code,
+            Message:   message,
+        })
+    }
+    
+    studentIDs := []int{}
+    for _, item := range request.Grades {
+        studentIDs = append(studentIDs, item.StudentID)
+    }
+    students, err := h.students.FindByIDs(studentIDs)
+    if err != nil {
+        return response, err
+    }
+    enrolledIDs, err := h.enrollments.EnrolledStudents(request.SubjectID, termIDs, studentIDs)
+    if err != nil {
+        return response, err
+    }
+    enrolled := make(map[int]bool, len(enrolledIDs))
+    for _, id := range enrolledIDs {
+        enrolled[id] = true
+    }
+    
+    // La asignación del maestro y el cierre se consultan una vez por grupo
+    assignedGroups := make(map[int]bool)
+    lockedGroups := make(map[int]bool)
+    seen := make(map[int]int)
+    for i, item := range request.Grades {
+        if err := binding.Validator.ValidateStruct(&item); err != nil {
+            var fieldErrors validator.ValidationErrors
+            if errors.As(err, &fieldErrors) {
+                fe := fieldErrors[0]
+                fail(i, item, utils.CodeValidationFailed, i18n.FieldMessage(lang, fe))
+                continue
+            }
+            return response, err
+        }
+        if first, ok := seen[item.StudentID]; ok {
+            fail(i, item, utils.CodeDuplicateGrade, i18n.T(lang, "grade.batch_repeated_student", first))
+            continue
+        }
+        seen[item.StudentID] = i
+        
+        student, ok := students[item.StudentID]
+        if !ok {
+            fail(i, item, utils.CodeStudentNotFound, i18n.T(lang, "student.not_found"))
+            continue
+        }
+        
+        if teacher != nil {
+            if student.GroupID == nil {
+                fail(i, item, utils.CodeTeacherNotAssigned, i18n.T(lang, "teacher.not_assigned"))
+                continue
+            }
+            assigned, checked := assignedGroups[*student.GroupID]
+            if !checked {
+                assigned, err = h.assignments.IsAssigned(teacher.TeacherID, request.SubjectID, *student.GroupID, termIDs)
+                if err != nil {
+                    return response, err
+                }
+                assignedGroups[*student.GroupID] = assigned
+            }
+            if !assigned {
+                fail(i, item, utils.CodeTeacherNotAssigned, i18n.T(lang, "teacher.not_assigned"))
+                continue
+            }
+        }
+        
+        if student.GroupID != nil {
+            locked, checked := lockedGroups[*student.GroupID]
+            if !checked {
+                locked, err = h.locks.IsLocked(request.SubjectID, *student.GroupID, termIDs)
+                if err != nil {
+                    return response, err
+                }
+                lockedGroups[*student.GroupID] = locked
+            }
+            if locked {
+                fail(i, item, utils.CodeGradesLocked, i18n.T(lang, "grade.locked"))
+                continue
+            }
+        }
+        
+        if !enrolled[item.StudentID] {
+            fail(i, item, utils.CodeNotEnrolled, i18n.T(lang, "enrollment.not_enrolled"))
+            continue
+        }
+        
+        if scales != nil {
+            scale, err := scales.For(request.SubjectID, &student)
+            if err != nil {
+                return response, err
+            }
+            if !scale.InRange(*item.Grade) {
+                fail(i, item, utils.CodeGradeOutOfScale, i18n.T(lang, "grade.out_of_scale", scale.MinValue, scale.MaxValue))
+                continue
+            }
+            value := scale.ToPercentage(*item.Grade)
+            request.Grades[i].Grade = &value
+        }
+    }
+    return response, nil
+}
+
+// gradeChange identifica a la cuenta autenticada como autora de un cambio de calificación
+func gradeChange(c *gin.Context, reason string) models.GradeChange {
+    change := models.GradeChange{Reason: reason}
+    if claims := auth.CurrentUser(c); claims != nil {
+        change.UserID = &claims.UserID
+        change.Username = claims.Username
+    }
+    return change
+}
+
+// respondParentLookupError responde 409 si el estudiante o la materia de una calificación que se
+// quiere restaurar están eliminados, o 500 ante cualquier otro error
+func respondParentLookupError(c *gin.Context, err error) {
+    if errors.Is(err, repositories.ErrNotFound) {
+        utils.RespondWithError(c, http.StatusConflict, utils.CodeParentDeleted, "grade.parent_deleted")
+        return
+    }
+    utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+}
+
+// respondBatchRejected responde con los elementos rechazados de una captura masiva. Si el único
+// motivo de rechazo es el cierre, la respuesta es 423 como en la captura individual.
+func respondBatchRejected(c *gin.Context, response models.BatchGradeResponse) {
+    status, code := http.StatusLocked, utils.CodeGradesLocked
+    for _, failure := range response.Failed {
+        if failure.Code != utils.CodeGradesLocked {
+            status, code = http.StatusBadRequest, utils.CodeBatchRejected
+            break
+        }
+    }
+    utils.RespondWithErrorDetails(c, status, code, "grade.batch_rejected", response)
+}
+
+// newBatchGradeResults resume las calificaciones guardadas para la respuesta
+func newBatchGradeResults(grades []models.Grade) []models.BatchGradeResult {
+    results := make([]models.BatchGradeResult, len(grades))
+    for i, grade := range grades {
+        results[i] = models.BatchGradeResult{
             GradeID:   grade.GradeID,
             StudentID: grade.StudentID,
-            SubjectID: grade.SubjectID,
             Grade:     grade.Grade,
-            Student: &models.StudentBasic{
-                StudentID: student.StudentID,
-                Name:      student.Name,
-                Group:     student.Group,
-                Email:     student.Email,
-            },
-            Subject: &models.SubjectBasic{
-                SubjectID: subject.SubjectID,
-                Name:      subject.Name,
-            },
         }
-        responses = append(responses, response)
     }
-    
-    c.JSON(http.StatusOK, responses)
-}
\ No newline at end of file
+    return results
+}
+
+// respondGradesLocked responde 423 cuando las calificaciones están cerradas
+func respondGradesLocked(c *gin.Context) {
+    utils.RespondWithError(c, http.StatusLocked, utils.CodeGradesLocked, "grade.locked")
+}
+
+// respondNotAssigned responde 403 cuando el maestro no imparte la materia al grupo
+func respondNotAssigned(c *gin.Context) {
+    utils.RespondWithError(c, http.StatusForbidden, utils.CodeTeacherNotAssigned, "teacher.not_assigned")
+}
+
+// respondGradeConflict responde 409 indicando la calificación que ya existe
+func respondGradeConflict(c *gin.Context, existing *models.Grade) {
+    key := "grade.duplicate"
+    if existing.DeletedAt.Valid {
+        key = "grade.duplicate_deleted"
+    }
+    utils.RespondWithErrorDetails(c, http.StatusConflict, utils.CodeDuplicateGrade, key, models.GradeConflictDetails{GradeID: existing.GradeID})
+}
@@ -0,0 +1,125 @@
+package handlers_test
+
+import (
+    "fmt"
+    "net/http"
+    "testing"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// lock cierra las calificaciones de la materia en el grupo y devuelve una función para reabrirlas
+func (f *schoolFixture) lock(t *testing.T) func() {
+    t.Helper()
+    locks := repositories.NewGormGradeLockRepository(f.db)
+    lock := &models.GradeLock{SubjectID: f.subject.SubjectID, GroupID: f.group.GroupID, TermID: f.term.TermID}
+    if err := locks.Close(lock, models.GradeChange{Username: "admin"}); err != nil {
+        t.Fatalf("cerrar calificaciones: %v", err)
+    }
+    return func() {
+        if _, err := locks.Reopen(lock.LockID, models.GradeChange{Username: "admin"}); err != nil {
+            t.Fatalf("reabrir calificaciones: %v", err)
+        }
+    }
+}
+
+// path devuelve la ruta de la calificación de la fixture con el sufijo indicado
+func (f *schoolFixture) gradePath(suffix string) string {
+    return fmt.Sprintf("/api/grades/%d%s", f.grade.GradeID, suffix)
+}
+
+func TestDeleteGradeRequiresAssignedTeacher(t *testing.T) {
+    f := newSchoolFixture(t)
+    
+    assertError(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.other, nil), http.StatusForbidden, utils.CodeTeacherNotAssigned)
+    assertStatus(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.assigned, nil), http.StatusOK)
+}
+
+func TestDeleteGradeRejectsLockedGrades(t *testing.T) {
+    f := newSchoolFixture(t)
+    reopen := f.lock(t)
+    
+    // El cierre aplica también al administrador
+    assertError(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.assigned, nil), http.StatusLocked, utils.CodeGradesLocked)
+    assertError(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.admin, nil), http.StatusLocked, utils.CodeGradesLocked)
+    
+    reopen()
+    assertStatus(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.admin, nil), http.StatusOK)
+}
+
+func TestDeleteGradeRejectsComputedGrades(t *testing.T) {
+    f := newSchoolFixture(t)
+    mustCreate(t, f.db, &models.EvaluationCriterion{SubjectID: f.subject.SubjectID, TermID: f.term.TermID, Name: "Examen", Weight: 100})
+    
+    assertError(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.assigned, nil), http.StatusConflict, utils.CodeGradeComputed)
+}
+
+func TestRestoreGradeRequiresAssignedTeacherAndOpenGrades(t *testing.T) {
+    f := newSchoolFixture(t)
+    assertStatus(t, performRequest(f.router, http.MethodDelete, f.gradePath(""), f.assigned, nil), http.StatusOK)
+    
+    assertError(t, performRequest(f.router, http.MethodPost, f.gradePath("/restore"), f.other, nil), http.StatusForbidden, utils.CodeTeacherNotAssigned)
+    
+    reopen := f.lock(t)
+    assertError(t, performRequest(f.router, http.MethodPost, f.gradePath("/restore"), f.assigned, nil), http.StatusLocked, utils.CodeGradesLocked)
+    assertError(t, performRequest(f.router, http.MethodPost, f.gradePath("/restore"), f.admin, nil), http.StatusLocked, utils.CodeGradesLocked)
+    
+    reopen()
+    assertStatus(t, performRequest(f.router, http.MethodPost, f.gradePath("/restore"), f.assigned, nil), http.StatusOK)
+}
+
+func TestUpdateGradeRequiresAssignedTeacherAndOpenGrades(t *testing.T) {
+    f := newSchoolFixture(t)
+    body := gin.H{"grade": 90}
+    
+    assertError(t, performRequest(f.router, http.MethodPut, f.gradePath(""), f.admin, body), http.StatusForbidden, utils.CodeForbidden)
+    assertError(t, performRequest(f.router, http.MethodPut, f.gradePath(""), f.other, body), http.StatusForbidden, utils.CodeTeacherNotAssigned)
+    
+    reopen := f.lock(t)
+    assertError(t, performRequest(f.router, http.MethodPut, f.gradePath(""), f.assigned, body), http.StatusLocked, utils.CodeGradesLocked)
+    
+    reopen()
+    assertStatus(t, performRequest(f.router, http.MethodPut, f.gradePath(""), f.assigned, body), http.StatusOK)
+}
+
+func TestRecordComponentScoresAcceptsZero(t *testing.T) {
+    f := newSchoolFixture(t)
+    exam := models.EvaluationCriterion{SubjectID: f.subject.SubjectID, TermID: f.term.TermID, Name: "Examen", Weight: 60}
+    homework := models.EvaluationCriterion{SubjectID: f.subject.SubjectID, TermID: f.term.TermID, Name: "Tareas", Weight: 40}
+    mustCreate(t, f.db, &exam, &homework)
+    
+    // Un 0 es una calificación válida; solo se rechaza la calificación ausente
+    missing := gin.H{"criterion_id": exam.CriterionID, "scores": []gin.H{{"student_id": f.grade.StudentID}}}
+    response := assertError(t, performRequest(f.router, http.MethodPost, "/api/grades/components", f.assigned, missing), http.StatusBadRequest, utils.CodeBatchRejected)
+    if failed, _ := response.Details.(map[string]interface{})["failed"].([]interface{}); len(failed) != 1 {
+        t.Fatalf("se esperaba un elemento rechazado, details = %v", response.Details)
+    }
+    
+    for _, capture := range []struct {
+        criterion models.EvaluationCriterion
+        score     float64
+    }{
+        {exam, 0},
+        {homework, 90},
+    } {
+        body := gin.H{"criterion_id": capture.criterion.CriterionID, "scores": []gin.H{{"student_id": f.grade.StudentID, "grade": capture.score}}}
+        assertStatus(t, performRequest(f.router, http.MethodPost, "/api/grades/components", f.assigned, body), http.StatusOK)
+    }
+    
+    var score models.ComponentScore
+    if err := f.db.Where("criterion_id = ? AND student_id = ?", exam.CriterionID, f.grade.StudentID).First(&score).Error; err != nil {
+        t.Fatalf("la calificación 0 del examen no se guardó: %v", err)
+    }
+    
+    // 0 * 60% + 90 * 40% = 36
+    var grade models.Grade
+    if err := f.db.First(&grade, f.grade.GradeID).Error; err != nil {
+        t.Fatalf("consultar calificación final: %v", err)
+    }
+    if grade.Grade != 36 {
+        t.Errorf("calificación final = %v, se esperaba 36", grade.Grade)
+    }
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// GradeLockHandler agrupa los endpoints de cierre y reapertura de calificaciones
+type GradeLockHandler struct {
+    locks       repositories.GradeLockRepository
+    subjects    repositories.SubjectRepository
+    groups      repositories.GroupRepository
+    terms       repositories.TermRepository
+    teachers    repositories.TeacherRepository
+    assignments repositories.AssignmentRepository
+}
+
+// NewGradeLockHandler crea un GradeLockHandler con los repositorios indicados
+func NewGradeLockHandler(locks repositories.GradeLockRepository, subjects repositories.SubjectRepository, groups repositories.GroupRepository, terms repositories.TermRepository, teachers repositories.TeacherRepository, assignments repositories.AssignmentRepository) *GradeLockHandler {
+    return &GradeLockHandler{
+        locks:       locks,
+        subjects:    subjects,
+        groups:      groups,
+        terms:       terms,
+        teachers:    teachers,
+        assignments: assignments,
+    }
+}
+
+// CloseGrades godoc
+// @Summary      Cerrar las calificaciones de una materia
+// @Description  Cierra las calificaciones de la materia en el grupo durante el periodo y sus subperiodos. Mientras estén cerradas, crearlas, modificarlas, eliminarlas o restaurarlas responde 423. Los maestros solo pueden cerrar las materias que tienen asignadas en el grupo. El periodo debe ser del mismo ciclo escolar que el grupo.
+// @Tags         grade-locks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        lock  body      models.GradeLockRequest  true  "Materia, grupo y periodo"
+// @Success      201   {object}  utils.SuccessResponse{data=models.GradeLock}
+// @Failure      400   {object}  utils.ErrorResponse
+// @Failure      403   {object}  utils.ErrorResponse
+// @Failure      404   {object}  utils.ErrorResponse
+// @Failure      409   {object}  utils.ErrorResponse
+// @Failure      500   {object}  utils.ErrorResponse
+// @Router       /grade-locks [post]
+func (h *GradeLockHandler) CloseGrades(c *gin.Context) {
+    var request models.GradeLockRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if _, err := h.subjects.FindByID(request.SubjectID); err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    group, err := h.groups.FindByID(request.GroupID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return
+    }
+    
+    term, err := h.terms.FindByID(request.TermID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    if term.SchoolYear != group.SchoolYear {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeTermGroupYear, "term.group_year_mismatch")
+        return
+    }
+    
+    if !h.authorizeClose(c, request.SubjectID, request.GroupID, request.TermID) {
+        return
+    }
+    
+    lock := models.GradeLock{
+        SubjectID: request.SubjectID,
+        GroupID:   request.GroupID,
+        TermID:    request.TermID,
+    }
+    
+    if err := h.locks.Close(&lock, gradeChange(c, request.Reason)); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeGradesAlreadyLocked, "grade_lock.already_locked")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade_lock.close_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "grade_lock.closed", lock)
+}
+
+// GetAllGradeLocks godoc
+// @Summary      Listar cierres de calificaciones
+// @Description  Obtiene una página de las materias con calificaciones cerradas, filtrable por materia, grupo o periodo
+// @Tags         grade-locks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit       query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort        query     string  false  "Campo de orden"  Enums(lock_id, subject_id, group_id, term_id, closed_at)
+// @Param        order       query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        subject_id  query     int     false  "Filtrar por materia"
+// @Param        group_id    query     int     false  "Filtrar por grupo"
+// @Param        term_id     query     int     false  "Filtrar por periodo"
+// @Success      200         {object}  utils.PaginatedResponse{data=[]models.GradeLock}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /grade-locks [get]
+func (h *GradeLockHandler) GetAllGradeLocks(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.GradeLockSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    filter, ok := parseGradeLockFilter(c)
+    if !ok {
+        return
+    }
+    
+    locks, total, err := h.locks.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade_lock.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "grade_lock.list_retrieved", locks, opts.Page, opts.Limit, total)
+}
+
+// ReopenGrades godoc
+// @Summary      Reabrir calificaciones cerradas
+// @Description  Elimina el cierre para que las calificaciones puedan modificarse de nuevo. El motivo es obligatorio y queda en la bitácora de cierres junto con la cuenta que reabrió.
+// @Tags         grade-locks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        lock_id  path      int                            true  "ID del cierre"
+// @Param        reopen   body      models.ReopenGradeLockRequest  true  "Motivo de la reapertura"
+// @Success      200      {object}  utils.SuccessResponse{data=models.GradeLock}
+// @Failure      400      {object}  utils.ErrorResponse
+// @Failure      404      {object}  utils.ErrorResponse
+// @Failure      500      {object}  utils.ErrorResponse
+// @Router       /grade-locks/{lock_id}/reopen [post]
+func (h *GradeLockHandler) ReopenGrades(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("lock_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    var request models.ReopenGradeLockRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    lock, err := h.locks.Reopen(id, gradeChange(c, request.Reason))
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGradeLockNotFound, "grade_lock.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grade_lock.reopened", lock)
+}
+
+// GetGradeLockEvents godoc
+// @Summary      Bitácora de cierres de calificaciones
+// @Description  Obtiene una página de los cierres y reaperturas registrados, con la cuenta que los hizo, la fecha y el motivo. Los registros se conservan aunque el cierre se reabra.
+// @Tags         grade-locks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit       query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort        query     string  false  "Campo de orden"  Enums(event_id, created_at)
+// @Param        order       query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        subject_id  query     int     false  "Filtrar por materia"
+// @Param        group_id    query     int     false  "Filtrar por grupo"
+// @Param        term_id     query     int     false  "Filtrar por periodo"
+// @Success      200         {object}  utils.PaginatedResponse{data=[]models.GradeLockEvent}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /grade-locks/events [get]
+func (h *GradeLockHandler) GetGradeLockEvents(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.GradeLockEventSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    filter, ok := parseGradeLockFilter(c)
+    if !ok {
+        return
+    }
+    
+    events, total, err := h.locks.Events(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grade_lock.events_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "grade_lock.events_retrieved", events, opts.Page, opts.Limit, total)
+}
+
+// authorizeClose verifica que un maestro esté asignado a la materia en el grupo, en el periodo o en
+// uno que lo contenga; los administradores pueden cerrar cualquier materia.
+// Si no está autorizado ya respondió al cliente y devuelve false.
+func (h *GradeLockHandler) authorizeClose(c *gin.Context, subjectID, groupID, termID int) bool {
+    teacher, ok := currentTeacher(c, h.teachers)
+    if !ok {
+        return false
+    }
+    if teacher == nil {
+        return true
+    }
+    
+    termIDs, err := h.terms.WithAncestors(termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return false
+    }
+    
+    assigned, err := h.assignments.IsAssigned(teacher.TeacherID, subjectID, groupID, termIDs)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "assignment.query_error")
+        return false
+    }
+    if !assigned {
+        utils.RespondWithError(c, http.StatusForbidden, utils.CodeTeacherNotAssigned, "grade_lock.not_assigned")
+        return false
+    }
+    return true
+}
+
+// parseGradeLockFilter lee subject_id, group_id y term_id de la query string.
+// Si alguno no es un entero ya respondió 400 y ok es false.
+func parseGradeLockFilter(c *gin.Context) (filter repositories.GradeLockFilter, ok bool) {
+    params := map[string]*int{
+        "subject_id": &filter.SubjectID,
+        "group_id":   &filter.GroupID,
+        "term_id":    &filter.TermID,
+    }
+    for name, target := range params {
+        value := c.Query(name)
+        if value == "" {
+            continue
+        }
+        id, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", name))
+            return filter, false
+        }
+        *target = id
+    }
+    return filter, true
+}
@@ -0,0 +1,461 @@
+package handlers
+
+import (
+    "errors"
+    "log"
+    "net/http"
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// GradingScaleHandler agrupa los endpoints de escalas de calificación y sus asignaciones
+type GradingScaleHandler struct {
+    scales   repositories.GradingScaleRepository
+    subjects repositories.SubjectRepository
+}
+
+// NewGradingScaleHandler crea un GradingScaleHandler con los repositorios indicados
+func NewGradingScaleHandler(scales repositories.GradingScaleRepository, subjects repositories.SubjectRepository) *GradingScaleHandler {
+    return &GradingScaleHandler{
+        scales:   scales,
+        subjects: subjects,
+    }
+}
+
+// CreateGradingScale godoc
+// @Summary      Crear una escala de calificación
+// @Description  Registra una escala con su rango, valor aprobatorio, decimales, regla de redondeo y, opcionalmente, letras o valores cualitativos. Si tiene letras, la de menor valor mínimo debe empezar en min_value.
+// @Tags         grading-scales
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        scale  body      models.GradingScaleRequest  true  "Información de la escala"
+// @Success      201    {object}  utils.SuccessResponse{data=models.GradingScale}
+// @Failure      400    {object}  utils.ErrorResponse
+// @Failure      409    {object}  utils.ErrorResponse
+// @Failure      500    {object}  utils.ErrorResponse
+// @Router       /grading-scales [post]
+func (h *GradingScaleHandler) CreateGradingScale(c *gin.Context) {
+    var request models.GradingScaleRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    var scale models.GradingScale
+    if err := applyGradingScaleRequest(&scale, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.scales.Create(&scale); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateScaleName, "grading_scale.duplicate_name")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "grading_scale.created", scale)
+}
+
+// GetAllGradingScales godoc
+// @Summary      Listar escalas de calificación
+// @Description  Obtiene una página de escalas de calificación con sus letras
+// @Tags         grading-scales
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit  query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort   query     string  false  "Campo de orden"  Enums(scale_id, name)
+// @Param        order  query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Success      200    {object}  utils.PaginatedResponse{data=[]models.GradingScale}
+// @Failure      400    {object}  utils.ErrorResponse
+// @Failure      500    {object}  utils.ErrorResponse
+// @Router       /grading-scales [get]
+func (h *GradingScaleHandler) GetAllGradingScales(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.GradingScaleSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    scales, total, err := h.scales.List(opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "grading_scale.list_retrieved", scales, opts.Page, opts.Limit, total)
+}
+
+// GetGradingScale godoc
+// @Summary      Obtener una escala de calificación por ID
+// @Tags         grading-scales
+// @Produce      json
+// @Security     BearerAuth
+// @Param        scale_id  path      int  true  "ID de la escala"
+// @Success      200       {object}  utils.SuccessResponse{data=models.GradingScale}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Router       /grading-scales/{scale_id} [get]
+func (h *GradingScaleHandler) GetGradingScale(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("scale_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    scale, err := h.scales.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeScaleNotFound, "grading_scale.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grading_scale.retrieved", scale)
+}
+
+// UpdateGradingScale godoc
+// @Summary      Actualizar una escala de calificación
+// @Description  Reemplaza la definición de la escala y sus letras. Las calificaciones se guardan de 0 a 100, así que el cambio se refleja en todas las calificaciones que usan la escala.
+// @Tags         grading-scales
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        scale_id  path      int                         true  "ID de la escala"
+// @Param        scale     body      models.GradingScaleRequest  true  "Información de la escala"
+// @Success      200       {object}  utils.SuccessResponse{data=models.GradingScale}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /grading-scales/{scale_id} [put]
+func (h *GradingScaleHandler) UpdateGradingScale(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("scale_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    scale, err := h.scales.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeScaleNotFound, "grading_scale.not_found")
+        return
+    }
+    
+    var request models.GradingScaleRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if err := applyGradingScaleRequest(scale, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.scales.Update(scale); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateScaleName, "grading_scale.duplicate_name")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.update_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grading_scale.updated", scale)
+}
+
+// DeleteGradingScale godoc
+// @Summary      Eliminar una escala de calificación
+// @Description  Elimina la escala con sus letras; primero deben quitarse sus asignaciones
+// @Tags         grading-scales
+// @Produce      json
+// @Security     BearerAuth
+// @Param        scale_id  path      int  true  "ID de la escala"
+// @Success      200       {object}  utils.SuccessResponse
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /grading-scales/{scale_id} [delete]
+func (h *GradingScaleHandler) DeleteGradingScale(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("scale_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.scales.Delete(id); err != nil {
+        switch {
+        case errors.Is(err, repositories.ErrNotFound):
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeScaleNotFound, "grading_scale.not_found")
+        case errors.Is(err, repositories.ErrInUse):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeScaleInUse, "grading_scale.in_use")
+        default:
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.delete_error")
+        }
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grading_scale.deleted", nil)
+}
+
+// AssignGradingScale godoc
+// @Summary      Asignar una escala de calificación
+// @Description  Asigna la escala a un grado escolar, a una materia o a una materia en un grado escolar. Cada calificación usa la asignación más específica: materia y grado, luego materia, luego grado; sin asignación se usa la escala de 0 a 100.
+// @Tags         grading-scales
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        assignment  body      models.GradingScaleAssignmentRequest  true  "Escala, grado escolar y materia"
+// @Success      201         {object}  utils.SuccessResponse{data=models.GradingScaleAssignment}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /grading-scales/assignments [post]
+func (h *GradingScaleHandler) AssignGradingScale(c *gin.Context) {
+    var request models.GradingScaleAssignmentRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if request.GradeLevel == nil && request.SubjectID == nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "grading_scale.assignment_target")
+        return
+    }
+    
+    if _, err := h.scales.FindByID(request.ScaleID); err != nil {
+        respondLookupError(c, err, utils.CodeScaleNotFound, "grading_scale.not_found")
+        return
+    }
+    
+    if request.SubjectID != nil {
+        if _, err := h.subjects.FindByID(*request.SubjectID); err != nil {
+            respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+            return
+        }
+    }
+    
+    assignment := models.GradingScaleAssignment{
+        ScaleID:    request.ScaleID,
+        GradeLevel: request.GradeLevel,
+        SubjectID:  request.SubjectID,
+    }
+    
+    if err := h.scales.Assign(&assignment); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateScaleAssignment, "grading_scale.duplicate_assignment")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.assign_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "grading_scale.assigned", assignment)
+}
+
+// GetGradingScaleAssignments godoc
+// @Summary      Listar asignaciones de escalas
+// @Description  Obtiene una página de asignaciones de escalas, filtrable por escala, grado escolar o materia
+// @Tags         grading-scales
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page         query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit        query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort         query     string  false  "Campo de orden"  Enums(assignment_id, scale_id, grade_level, subject_id)
+// @Param        order        query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        scale_id     query     int     false  "Filtrar por escala"
+// @Param        grade_level  query     int     false  "Filtrar por grado escolar"
+// @Param        subject_id   query     int     false  "Filtrar por materia"
+// @Success      200          {object}  utils.PaginatedResponse{data=[]models.GradingScaleAssignment}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /grading-scales/assignments [get]
+func (h *GradingScaleHandler) GetGradingScaleAssignments(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.GradingScaleAssignmentSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    var filter repositories.GradingScaleAssignmentFilter
+    params := map[string]*int{
+        "scale_id":    &filter.ScaleID,
+        "grade_level": &filter.GradeLevel,
+        "subject_id":  &filter.SubjectID,
+    }
+    for name, target := range params {
+        value := c.Query(name)
+        if value == "" {
+            continue
+        }
+        id, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", name))
+            return
+        }
+        *target = id
+    }
+    
+    assignments, total, err := h.scales.Assignments(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.assignment_list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "grading_scale.assignments_retrieved", assignments, opts.Page, opts.Limit, total)
+}
+
+// UnassignGradingScale godoc
+// @Summary      Quitar una asignación de escala
+// @Description  Quita la asignación; las calificaciones afectadas pasan a mostrarse con la siguiente asignación que les aplique o con la escala de 0 a 100
+// @Tags         grading-scales
+// @Produce      json
+// @Security     BearerAuth
+// @Param        assignment_id  path      int  true  "ID de la asignación"
+// @Success      200            {object}  utils.SuccessResponse
+// @Failure      400            {object}  utils.ErrorResponse
+// @Failure      404            {object}  utils.ErrorResponse
+// @Failure      500            {object}  utils.ErrorResponse
+// @Router       /grading-scales/assignments/{assignment_id} [delete]
+func (h *GradingScaleHandler) UnassignGradingScale(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("assignment_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.scales.Unassign(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeScaleAssignmentNotFound, "grading_scale.assignment_not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "grading_scale.unassign_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "grading_scale.unassigned", nil)
+}
+
+// applyGradingScaleRequest valida las reglas entre campos de la petición y las copia a la escala
+func applyGradingScaleRequest(scale *models.GradingScale, request models.GradingScaleRequest) error {
+    if request.MaxValue <= request.MinValue {
+        return i18n.NewMessage("grading_scale.range")
+    }
+    if request.PassingValue < request.MinValue || request.PassingValue > request.MaxValue {
+        return i18n.NewMessage("grading_scale.passing_range")
+    }
+    
+    letters := make([]models.ScaleLetter, len(request.Letters))
+    seenLetters := make(map[string]bool, len(request.Letters))
+    seenValues := make(map[float64]bool, len(request.Letters))
+    coversMin := len(request.Letters) == 0
+    for i, item := range request.Letters {
+        letter := strings.TrimSpace(item.Letter)
+        if seenLetters[letter] {
+            return i18n.NewMessage("grading_scale.letter_repeated", letter)
+        }
+        seenLetters[letter] = true
+    
+        if item.MinValue < request.MinValue || item.MinValue > request.MaxValue {
+            return i18n.NewMessage("grading_scale.letter_range", letter)
+        }
+        if seenValues[item.MinValue] {
+            return i18n.NewMessage("grading_scale.letter_value_repeated", item.MinValue)
+        }
+        seenValues[item.MinValue] = true
+        if item.MinValue == request.MinValue {
+            coversMin = true
+        }
+    
+        letters[i] = models.ScaleLetter{
+            Letter:   letter,
+            Label:    strings.TrimSpace(item.Label),
+            MinValue: item.MinValue,
+        }
+    }
+    // Sin una letra desde el mínimo, los valores más bajos de la escala no tendrían letra
+    if !coversMin {
+        return i18n.NewMessage("grading_scale.letters_cover")
+    }
+    
+    scale.Name = strings.TrimSpace(request.Name)
+    scale.MinValue = request.MinValue
+    scale.MaxValue = request.MaxValue
+    scale.PassingValue = request.PassingValue
+    scale.Decimals = request.Decimals
+    scale.Rounding = request.Rounding
+    if scale.Rounding == "" {
+        scale.Rounding = models.RoundingHalfUp
+    }
+    scale.Letters = letters
+    return nil
+}
+
+// scaleResolver obtiene la escala de calificación de una materia para el grado escolar de un
+// estudiante, consultando cada combinación de materia y grado una sola vez
+type scaleResolver struct {
+    scales       repositories.GradingScaleRepository
+    passingGrade float64
+    resolved     map[[2]int]models.GradingScale
+}
+
+// newScaleResolver crea un scaleResolver; passingGrade es el valor aprobatorio de la escala de 0 a 100
+func newScaleResolver(scales repositories.GradingScaleRepository, passingGrade float64) *scaleResolver {
+    return &scaleResolver{
+        scales:       scales,
+        passingGrade: passingGrade,
+        resolved:     make(map[[2]int]models.GradingScale),
+    }
+}
+
+// For devuelve la escala de la materia en el grado escolar del estudiante; sin asignación devuelve
+// la escala de 0 a 100. Los estudiantes sin grupo solo consideran la escala de la materia.
+func (r *scaleResolver) For(subjectID int, student *models.Student) (models.GradingScale, error) {
+    var gradeLevel *int
+    if student != nil && student.Group != nil {
+        gradeLevel = &student.Group.GradeLevel
+    }
+    key := [2]int{subjectID, 0}
+    if gradeLevel != nil {
+        key[1] = *gradeLevel
+    }
+    if scale, ok := r.resolved[key]; ok {
+        return scale, nil
+    }
+    
+    scale := models.DefaultGradingScale(r.passingGrade)
+    found, err := r.scales.Resolve(subjectID, gradeLevel)
+    switch {
+    case err == nil:
+        scale = *found
+    case !errors.Is(err, repositories.ErrNotFound):
+        return scale, err
+    }
+    r.resolved[key] = scale
+    return scale, nil
+}
+
+// Scaled representa una calificación de 0 a 100 en la escala que le corresponde; si la escala no
+// se puede consultar devuelve nil y la respuesta omite la representación
+func (r *scaleResolver) Scaled(subjectID int, student *models.Student, grade float64) *models.ScaledGrade {
+    scale, err := r.For(subjectID, student)
+    if err != nil {
+        log.Printf("Error obteniendo escala de calificación: %v", err)
+        return nil
+    }
+    scaled := scale.Convert(grade)
+    return &scaled
+}
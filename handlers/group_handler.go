@@ -0,0 +1,247 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// GroupHandler agrupa los endpoints de grupos
+type GroupHandler struct {
+    groups   repositories.GroupRepository
+    teachers repositories.TeacherRepository
+}
+
+// NewGroupHandler crea un GroupHandler con los repositorios indicados
+func NewGroupHandler(groups repositories.GroupRepository, teachers repositories.TeacherRepository) *GroupHandler {
+    return &GroupHandler{
+        groups:   groups,
+        teachers: teachers,
+    }
+}
+
+// CreateGroup godoc
+// @Summary      Crear un grupo
+// @Description  Registra un grupo de un ciclo escolar. El nombre se forma con el grado y la sección (p. ej. 5A); el turno por defecto es morning.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group  body      models.GroupRequest  true  "Información del grupo"
+// @Success      201    {object}  utils.SuccessResponse{data=models.Group}
+// @Failure      400    {object}  utils.ErrorResponse
+// @Failure      409    {object}  utils.ErrorResponse
+// @Failure      500    {object}  utils.ErrorResponse
+// @Router       /groups [post]
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+    var request models.GroupRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    var group models.Group
+    if err := h.applyGroupRequest(&group, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.groups.Create(&group); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateGroup, "group.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "group.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "group.created", group)
+}
+
+// GetAllGroups godoc
+// @Summary      Listar grupos
+// @Description  Obtiene una página de grupos, filtrable por ciclo escolar, grado o turno
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page         query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit        query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort         query     string  false  "Campo de orden"  Enums(group_id, name, grade_level, school_year)
+// @Param        order        query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        school_year  query     string  false  "Filtrar por ciclo escolar"  example(2025-2026)
+// @Param        grade_level  query     int     false  "Filtrar por grado"
+// @Param        shift        query     string  false  "Filtrar por turno"  Enums(morning, afternoon, evening)
+// @Success      200          {object}  utils.PaginatedResponse{data=[]models.Group}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /groups [get]
+func (h *GroupHandler) GetAllGroups(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.GroupSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    filter := repositories.GroupFilter{
+        SchoolYear: strings.TrimSpace(c.Query("school_year")),
+        Shift:      strings.TrimSpace(c.Query("shift")),
+    }
+    if value := c.Query("grade_level"); value != "" {
+        gradeLevel, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", "grade_level"))
+            return
+        }
+        filter.GradeLevel = gradeLevel
+    }
+    
+    groups, total, err := h.groups.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "group.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "group.list_retrieved", groups, opts.Page, opts.Limit, total)
+}
+
+// GetGroup godoc
+// @Summary      Obtener un grupo por ID
+// @Description  Obtiene la información de un grupo específico
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      int  true  "ID del grupo"
+// @Success      200       {object}  utils.SuccessResponse{data=models.Group}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Router       /groups/{group_id} [get]
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("group_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    group, err := h.groups.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "group.retrieved", group)
+}
+
+// UpdateGroup godoc
+// @Summary      Actualizar un grupo
+// @Description  Actualiza la información de un grupo existente
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      int                  true  "ID del grupo"
+// @Param        group     body      models.GroupRequest  true  "Información actualizada del grupo"
+// @Success      200       {object}  utils.SuccessResponse{data=models.Group}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /groups/{group_id} [put]
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("group_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    group, err := h.groups.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGroupNotFound, "group.not_found")
+        return
+    }
+    
+    var request models.GroupRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if err := h.applyGroupRequest(group, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.groups.Update(group); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateGroup, "group.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "group.update_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "group.updated", group)
+}
+
+// DeleteGroup godoc
+// @Summary      Eliminar un grupo
+// @Description  Elimina un grupo que no tenga estudiantes asignados
+// @Tags         groups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        group_id  path      int  true  "ID del grupo"
+// @Success      200       {object}  utils.SuccessResponse
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      404       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /groups/{group_id} [delete]
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("group_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.groups.Delete(id); err != nil {
+        switch {
+        case errors.Is(err, repositories.ErrNotFound):
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeGroupNotFound, "group.not_found")
+        case errors.Is(err, repositories.ErrInUse):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeGroupInUse, "group.in_use")
+        default:
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "group.delete_error")
+        }
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "group.deleted", nil)
+}
+
+// applyGroupRequest valida la petición y copia sus datos al grupo.
+// El titular, si se indica, debe ser un maestro registrado.
+func (h *GroupHandler) applyGroupRequest(group *models.Group, request models.GroupRequest) error {
+    if request.HomeroomTeacherID != nil {
+        if _, err := h.teachers.FindByID(*request.HomeroomTeacherID); err != nil {
+            return i18n.NewMessage("group.homeroom_not_found")
+        }
+    }
+    
+    group.SchoolYear = strings.TrimSpace(request.SchoolYear)
+    group.GradeLevel = request.GradeLevel
+    group.Section = strings.ToUpper(request.Section)
+    group.Shift = request.Shift
+    if group.Shift == "" {
+        group.Shift = models.ShiftMorning
+    }
+    group.Name = models.GroupName(group.GradeLevel, group.Section)
+    group.HomeroomTeacherID = request.HomeroomTeacherID
+    return nil
+}
@@ -0,0 +1,457 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// GuardianHandler agrupa los endpoints de tutores y del portal de padres
+type GuardianHandler struct {
+    guardians repositories.GuardianRepository
+    users     repositories.UserRepository
+    students  repositories.StudentRepository
+}
+
+// NewGuardianHandler crea un GuardianHandler con los repositorios indicados
+func NewGuardianHandler(guardians repositories.GuardianRepository, users repositories.UserRepository, students repositories.StudentRepository) *GuardianHandler {
+    return &GuardianHandler{
+        guardians: guardians,
+        users:     users,
+        students:  students,
+    }
+}
+
+// CreateGuardian godoc
+// @Summary      Crear un tutor
+// @Description  Registra un padre, madre o tutor. Para que pueda consultar a sus estudiantes en el portal de padres debe vincularse con user_id a una cuenta con rol parent.
+// @Tags         guardians
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        guardian  body      models.GuardianRequest  true  "Información del tutor"
+// @Success      201       {object}  utils.SuccessResponse{data=models.Guardian}
+// @Failure      400       {object}  utils.ErrorResponse
+// @Failure      409       {object}  utils.ErrorResponse
+// @Failure      500       {object}  utils.ErrorResponse
+// @Router       /guardians [post]
+func (h *GuardianHandler) CreateGuardian(c *gin.Context) {
+    var request models.GuardianRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    var guardian models.Guardian
+    if err := h.applyGuardianRequest(&guardian, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.guardians.Create(&guardian); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateGuardian, "guardian.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "guardian.created", guardian)
+}
+
+// GetAllGuardians godoc
+// @Summary      Listar tutores
+// @Description  Obtiene una página de tutores, con búsqueda por nombre o email y filtro por estudiante
+// @Tags         guardians
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit       query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort        query     string  false  "Campo de orden"  Enums(guardian_id, name, email)
+// @Param        order       query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        q           query     string  false  "Buscar texto en nombre o email"
+// @Param        student_id  query     int     false  "Solo los tutores del estudiante"
+// @Success      200         {object}  utils.PaginatedResponse{data=[]models.Guardian}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /guardians [get]
+func (h *GuardianHandler) GetAllGuardians(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.GuardianSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    filter := repositories.GuardianFilter{
+        Query: strings.TrimSpace(c.Query("q")),
+    }
+    if value := c.Query("student_id"); value != "" {
+        if filter.StudentID, err = strconv.Atoi(value); err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", "student_id"))
+            return
+        }
+    }
+    
+    guardians, total, err := h.guardians.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "guardian.list_retrieved", guardians, opts.Page, opts.Limit, total)
+}
+
+// GetGuardian godoc
+// @Summary      Obtener un tutor por ID
+// @Description  Obtiene la información de un tutor con los estudiantes que tiene vinculados
+// @Tags         guardians
+// @Produce      json
+// @Security     BearerAuth
+// @Param        guardian_id  path      int  true  "ID del tutor"
+// @Success      200          {object}  utils.SuccessResponse{data=models.GuardianResponse}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      404          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /guardians/{guardian_id} [get]
+func (h *GuardianHandler) GetGuardian(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("guardian_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    guardian, err := h.guardians.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGuardianNotFound, "guardian.not_found")
+        return
+    }
+    
+    h.respondGuardian(c, guardian, "guardian.retrieved")
+}
+
+// UpdateGuardian godoc
+// @Summary      Actualizar un tutor
+// @Description  Actualiza la información de un tutor existente
+// @Tags         guardians
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        guardian_id  path      int                     true  "ID del tutor"
+// @Param        guardian     body      models.GuardianRequest  true  "Información actualizada del tutor"
+// @Success      200          {object}  utils.SuccessResponse{data=models.Guardian}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      404          {object}  utils.ErrorResponse
+// @Failure      409          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /guardians/{guardian_id} [put]
+func (h *GuardianHandler) UpdateGuardian(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("guardian_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    guardian, err := h.guardians.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeGuardianNotFound, "guardian.not_found")
+        return
+    }
+    
+    var request models.GuardianRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if err := h.applyGuardianRequest(guardian, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.guardians.Update(guardian); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateGuardian, "guardian.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.update_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "guardian.updated", guardian)
+}
+
+// DeleteGuardian godoc
+// @Summary      Eliminar un tutor
+// @Description  Elimina un tutor junto con sus vínculos con estudiantes; su cuenta de acceso se conserva
+// @Tags         guardians
+// @Produce      json
+// @Security     BearerAuth
+// @Param        guardian_id  path      int  true  "ID del tutor"
+// @Success      200          {object}  utils.SuccessResponse
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      404          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /guardians/{guardian_id} [delete]
+func (h *GuardianHandler) DeleteGuardian(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("guardian_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.guardians.Delete(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeGuardianNotFound, "guardian.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.delete_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "guardian.deleted", nil)
+}
+
+// LinkStudent godoc
+// @Summary      Vincular un estudiante a un tutor
+// @Description  Vincula al tutor con un estudiante indicando el parentesco. Un estudiante puede tener varios tutores y un tutor varios estudiantes.
+// @Tags         guardians
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        guardian_id  path      int                            true  "ID del tutor"
+// @Param        link         body      models.GuardianStudentRequest  true  "Estudiante y parentesco"
+// @Success      201          {object}  utils.SuccessResponse{data=models.GuardianStudent}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      404          {object}  utils.ErrorResponse
+// @Failure      409          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /guardians/{guardian_id}/students [post]
+func (h *GuardianHandler) LinkStudent(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("guardian_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    var request models.GuardianStudentRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if _, err := h.guardians.FindByID(id); err != nil {
+        respondLookupError(c, err, utils.CodeGuardianNotFound, "guardian.not_found")
+        return
+    }
+    
+    if _, err := h.students.FindByID(request.StudentID); err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    link := models.GuardianStudent{
+        GuardianID:   id,
+        StudentID:    request.StudentID,
+        Relationship: request.Relationship,
+    }
+    
+    if err := h.guardians.Link(&link); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateGuardianLink, "guardian.duplicate_link")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.link_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "guardian.linked", link)
+}
+
+// UnlinkStudent godoc
+// @Summary      Desvincular un estudiante de un tutor
+// @Tags         guardians
+// @Produce      json
+// @Security     BearerAuth
+// @Param        guardian_id  path      int  true  "ID del tutor"
+// @Param        student_id   path      int  true  "ID del estudiante"
+// @Success      200          {object}  utils.SuccessResponse
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      404          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /guardians/{guardian_id}/students/{student_id} [delete]
+func (h *GuardianHandler) UnlinkStudent(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("guardian_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    if err := h.guardians.Unlink(id, studentID); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeGuardianLinkNotFound, "guardian.link_not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.unlink_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "guardian.unlinked", nil)
+}
+
+// GetStudentGuardians godoc
+// @Summary      Tutores de un estudiante
+// @Description  Obtiene los tutores de un estudiante con su parentesco
+// @Tags         guardians
+// @Produce      json
+// @Security     BearerAuth
+// @Param        student_id  path      int  true  "ID del estudiante"
+// @Success      200         {object}  utils.SuccessResponse{data=[]models.StudentGuardian}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /students/{student_id}/guardians [get]
+func (h *GuardianHandler) GetStudentGuardians(c *gin.Context) {
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    if _, err := h.students.FindByID(studentID); err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    links, err := h.guardians.ForStudent(studentID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.list_error")
+        return
+    }
+    
+    guardians := make([]models.StudentGuardian, 0, len(links))
+    for _, link := range links {
+        if link.Guardian == nil {
+            continue
+        }
+        guardians = append(guardians, models.StudentGuardian{
+            Guardian:     *link.Guardian,
+            Relationship: link.Relationship,
+        })
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "guardian.list_retrieved", guardians)
+}
+
+// GetMyGuardian godoc
+// @Summary      Mi información de tutor
+// @Description  Portal de padres: obtiene el tutor vinculado a la cuenta autenticada con sus estudiantes. Las calificaciones y la asistencia de cada estudiante se consultan en /guardians/me/students/{student_id}/grades y /guardians/me/students/{student_id}/attendance.
+// @Tags         guardians
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  utils.SuccessResponse{data=models.GuardianResponse}
+// @Failure      403  {object}  utils.ErrorResponse
+// @Failure      500  {object}  utils.ErrorResponse
+// @Router       /guardians/me [get]
+func (h *GuardianHandler) GetMyGuardian(c *gin.Context) {
+    guardian, ok := currentGuardian(c, h.guardians)
+    if !ok {
+        return
+    }
+    
+    h.respondGuardian(c, guardian, "guardian.retrieved")
+}
+
+// RequireOwnChild permite continuar solo si el estudiante del parámetro student_id está vinculado
+// al tutor de la cuenta autenticada. Se usa en las rutas del portal de padres antes de los
+// handlers de consulta de estudiantes.
+func (h *GuardianHandler) RequireOwnChild(c *gin.Context) {
+    guardian, ok := currentGuardian(c, h.guardians)
+    if !ok {
+        c.Abort()
+        return
+    }
+    
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        c.Abort()
+        return
+    }
+    
+    linked, err := h.guardians.IsLinked(guardian.GuardianID, studentID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.query_error")
+        c.Abort()
+        return
+    }
+    // Un estudiante ajeno se rechaza igual que en las rutas de alumnos
+    if !linked {
+        utils.RespondWithError(c, http.StatusForbidden, utils.CodeOwnDataOnly, "guardian.not_own_student")
+        c.Abort()
+        return
+    }
+    c.Next()
+}
+
+// respondGuardian responde con el tutor y los estudiantes que tiene vinculados
+func (h *GuardianHandler) respondGuardian(c *gin.Context, guardian *models.Guardian, key string) {
+    links, err := h.guardians.Children(guardian.GuardianID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "guardian.query_error")
+        return
+    }
+    
+    response := models.GuardianResponse{
+        Guardian: *guardian,
+        Students: make([]models.GuardianChild, 0, len(links)),
+    }
+    for _, link := range links {
+        if link.Student == nil {
+            continue
+        }
+        response.Students = append(response.Students, models.GuardianChild{
+            Student:      newStudentBasic(link.Student),
+            Relationship: link.Relationship,
+        })
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, key, response)
+}
+
+// applyGuardianRequest valida la petición y copia sus datos al tutor.
+// La cuenta vinculada, si se indica, debe tener rol parent.
+func (h *GuardianHandler) applyGuardianRequest(guardian *models.Guardian, request models.GuardianRequest) error {
+    if request.UserID != nil {
+        user, err := h.users.FindByID(*request.UserID)
+        if err != nil {
+            return i18n.NewMessage("guardian.user_not_found")
+        }
+        if user.Role != models.RoleParent {
+            return i18n.NewMessage("guardian.user_not_parent", models.RoleParent)
+        }
+    }
+    
+    guardian.Name = strings.TrimSpace(request.Name)
+    guardian.Email = nil
+    if email := strings.TrimSpace(request.Email); email != "" {
+        guardian.Email = &email
+    }
+    guardian.Phone = strings.TrimSpace(request.Phone)
+    guardian.UserID = request.UserID
+    return nil
+}
@@ -0,0 +1,33 @@
+package handlers_test
+
+import (
+    "fmt"
+    "net/http"
+    "testing"
+    
+    "ControlEscolar/models"
+    "ControlEscolar/utils"
+)
+
+func TestGuardianPortalOnlyShowsLinkedStudents(t *testing.T) {
+    f := newSchoolFixture(t)
+    
+    user := &models.User{Username: "rosa", PasswordHash: "-", Role: models.RoleParent}
+    mustCreate(t, f.db, user)
+    guardian := &models.Guardian{Name: "Rosa Martínez", UserID: &user.UserID}
+    mustCreate(t, f.db, guardian)
+    mustCreate(t, f.db, &models.GuardianStudent{GuardianID: guardian.GuardianID, StudentID: f.student.StudentID, Relationship: "mother"})
+    parent := tokenFor(t, user)
+    
+    classmate := models.Student{Name: "Beto", Email: "beto@example.com", GroupID: &f.group.GroupID}
+    mustCreate(t, f.db, &classmate)
+    
+    grades := func(studentID int) string {
+        return fmt.Sprintf("/api/guardians/me/students/%d/grades", studentID)
+    }
+    assertStatus(t, performRequest(f.router, http.MethodGet, grades(f.student.StudentID), parent, nil), http.StatusOK)
+    assertError(t, performRequest(f.router, http.MethodGet, grades(classmate.StudentID), parent, nil), http.StatusForbidden, utils.CodeOwnDataOnly)
+    
+    // El portal es solo para tutores, aunque el personal pueda consultar al estudiante en otras rutas
+    assertError(t, performRequest(f.router, http.MethodGet, grades(f.student.StudentID), f.admin, nil), http.StatusForbidden, utils.CodeForbidden)
+}
@@ -0,0 +1,213 @@
+package handlers_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+    
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "gorm.io/gorm/logger"
+    "ControlEscolar/auth"
+    "ControlEscolar/config"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/routes"
+    "ControlEscolar/utils"
+)
+
+// testTokens firma los tokens de las pruebas que pasan por auth.RequireAuth
+var testTokens = auth.NewTokenService([]byte("secreto-de-pruebas"), time.Hour)
+
+// testGrading son los criterios por defecto con los que main configura las rutas
+var testGrading = config.GradingConfig{
+    PassingGrade:         models.DefaultPassingGrade,
+    MaxAbsencePercentage: models.DefaultMaxAbsencePercentage,
+}
+
+func init() {
+    gin.SetMode(gin.TestMode)
+}
+
+// newTestDB abre una base de datos SQLite en memoria con las mismas migraciones que main
+func newTestDB(t *testing.T) *gorm.DB {
+    t.Helper()
+    
+    db, err := config.OpenDatabase(config.DatabaseConfig{
+        Driver:   config.DriverSQLite,
+        Path:     ":memory:",
+        LogLevel: logger.Silent,
+    })
+    if err != nil {
+        t.Fatalf("abrir base de datos: %v", err)
+    }
+    t.Cleanup(func() {
+        if sqlDB, err := db.DB(); err == nil {
+            sqlDB.Close()
+        }
+    })
+    
+    if err := models.MigrateAll(db); err != nil {
+        t.Fatalf("migrar base de datos: %v", err)
+    }
+    if err := models.AddForeignKeys(db); err != nil {
+        t.Fatalf("crear llaves foráneas: %v", err)
+    }
+    return db
+}
+
+// newTestRouter registra las rutas de la API sobre la base de datos de la prueba
+func newTestRouter(db *gorm.DB) *gin.Engine {
+    return newTestRouterWith(routes.NewGormRepositories(db))
+}
+
+// newTestRouterWith registra las rutas de la API con los repositorios indicados; las pruebas
+// parten de routes.NewGormRepositories y sustituyen los que necesitan por los de memoria
+func newTestRouterWith(repos routes.Repositories) *gin.Engine {
+    router := gin.New()
+    routes.RegisterRoutes(router, repos, testTokens, testGrading)
+    return router
+}
+
+// mustCreate inserta los registros indicados o termina la prueba
+func mustCreate(t *testing.T, db *gorm.DB, values ...interface{}) {
+    t.Helper()
+    for _, value := range values {
+        if err := db.Create(value).Error; err != nil {
+            t.Fatalf("crear %T: %v", value, err)
+        }
+    }
+}
+
+// tokenFor emite un token de prueba para la cuenta indicada
+func tokenFor(t *testing.T, user *models.User) string {
+    t.Helper()
+    token, _, err := testTokens.Generate(user)
+    if err != nil {
+        t.Fatalf("generar token: %v", err)
+    }
+    return token
+}
+
+// newAccount crea una cuenta con el rol indicado y devuelve su token
+func newAccount(t *testing.T, db *gorm.DB, username, role string) string {
+    t.Helper()
+    user := &models.User{Username: username, PasswordHash: "-", Role: role}
+    mustCreate(t, db, user)
+    return tokenFor(t, user)
+}
+
+// schoolFixture es una escuela mínima para las pruebas de los handlers: un estudiante inscrito en
+// una materia con una calificación, un maestro asignado a la materia en el grupo del estudiante,
+// otro maestro sin asignación y un administrador. El router tiene las rutas reales de la API.
+type schoolFixture struct {
+    db       *gorm.DB
+    router   *gin.Engine
+    admin    string
+    assigned string
+    other    string
+    subject  models.Subject
+    group    models.Group
+    term     models.Term
+    student  models.Student
+    grade    models.Grade
+}
+
+func newSchoolFixture(t *testing.T) *schoolFixture {
+    t.Helper()
+    db := newTestDB(t)
+    f := &schoolFixture{db: db, router: newTestRouter(db)}
+    
+    f.term = models.Term{
+        Name:       "Ciclo 2025-2026",
+        SchoolYear: "2025-2026",
+        Kind:       models.TermKindSchoolYear,
+        StartDate:  time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC),
+        EndDate:    time.Date(2026, time.July, 31, 0, 0, 0, 0, time.UTC),
+    }
+    f.group = models.Group{SchoolYear: "2025-2026", GradeLevel: 3, Section: "A", Name: "3A"}
+    f.subject = models.Subject{Name: "Matemáticas"}
+    mustCreate(t, db, &f.term, &f.group, &f.subject)
+    
+    f.student = models.Student{Name: "Ana", Email: "ana@example.com", GroupID: &f.group.GroupID}
+    mustCreate(t, db, &f.student)
+    mustCreate(t, db, &models.Enrollment{StudentID: f.student.StudentID, SubjectID: f.subject.SubjectID, TermID: f.term.TermID})
+    
+    f.admin = newAccount(t, db, "admin", models.RoleAdmin)
+    f.assigned = f.newTeacher(t, "maestra.lopez", true)
+    f.other = f.newTeacher(t, "maestro.ruiz", false)
+    
+    f.grade = models.Grade{StudentID: f.student.StudentID, SubjectID: f.subject.SubjectID, TermID: &f.term.TermID, Grade: 80}
+    if err := repositories.NewGormGradeRepository(db).Create(&f.grade, models.GradeChange{}); err != nil {
+        t.Fatalf("crear calificación: %v", err)
+    }
+    return f
+}
+
+// newTeacher crea un maestro con cuenta, asignado o no a la materia del grupo, y devuelve su token
+func (f *schoolFixture) newTeacher(t *testing.T, username string, assigned bool) string {
+    t.Helper()
+    user := &models.User{Username: username, PasswordHash: "-", Role: models.RoleTeacher}
+    mustCreate(t, f.db, user)
+    teacher := &models.Teacher{Name: username, UserID: &user.UserID}
+    mustCreate(t, f.db, teacher)
+    if assigned {
+        mustCreate(t, f.db, &models.TeachingAssignment{TeacherID: teacher.TeacherID, SubjectID: f.subject.SubjectID, GroupID: f.group.GroupID, TermID: f.term.TermID})
+    }
+    return tokenFor(t, user)
+}
+
+// newStudentAccount crea la cuenta de alumno del estudiante de la fixture y devuelve su token
+func (f *schoolFixture) newStudentAccount(t *testing.T) string {
+    t.Helper()
+    user := &models.User{Username: "ana", PasswordHash: "-", Role: models.RoleStudent, StudentID: &f.student.StudentID}
+    mustCreate(t, f.db, user)
+    return tokenFor(t, user)
+}
+
+// performRequest envía una petición al router; body se serializa como JSON si no es nil
+func performRequest(router http.Handler, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+    var reader io.Reader
+    if body != nil {
+        payload, _ := json.Marshal(body)
+        reader = bytes.NewReader(payload)
+    }
+    
+    req := httptest.NewRequest(method, path, reader)
+    if body != nil {
+        req.Header.Set("Content-Type", "application/json")
+    }
+    if token != "" {
+        req.Header.Set("Authorization", "Bearer "+token)
+    }
+    
+    recorder := httptest.NewRecorder()
+    router.ServeHTTP(recorder, req)
+    return recorder
+}
+
+// assertError verifica el estado y el código de una respuesta de error
+func assertError(t *testing.T, recorder *httptest.ResponseRecorder, status int, code string) utils.ErrorResponse {
+    t.Helper()
+    
+    var response utils.ErrorResponse
+    if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+        t.Fatalf("respuesta no es JSON: %v (%s)", err, recorder.Body.String())
+    }
+    if recorder.Code != status || response.Code != code {
+        t.Fatalf("se esperaba %d %s, se obtuvo %d %s: %s", status, code, recorder.Code, response.Code, recorder.Body.String())
+    }
+    return response
+}
+
+// assertStatus verifica el estado de una respuesta
+func assertStatus(t *testing.T, recorder *httptest.ResponseRecorder, status int) {
+    t.Helper()
+    if recorder.Code != status {
+        t.Fatalf("se esperaba %d, se obtuvo %d: %s", status, recorder.Code, recorder.Body.String())
+    }
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+    "encoding/json"
+    "errors"
+    "log"
+    "net/http"
+    "reflect"
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "github.com/gin-gonic/gin/binding"
+    "github.com/go-playground/validator/v10"
+    "ControlEscolar/auth"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// Los errores de validación usan los nombres JSON de los campos, que son los que conoce el cliente,
+// y se traducen al idioma de la petición
+func init() {
+    if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+        v.RegisterTagNameFunc(func(field reflect.StructField) string {
+            name := strings.Split(field.Tag.Get("json"), ",")[0]
+            if name == "-" {
+                return ""
+            }
+            return name
+        })
+        if err := i18n.RegisterValidator(v); err != nil {
+            log.Printf("⚠️  Advertencia al registrar las traducciones de validación: %v", err)
+        }
+    }
+}
+
+// respondLookupError responde 404 con notFoundCode y el mensaje notFoundKey si el registro no existe
+// o 500 ante cualquier otro error
+func respondLookupError(c *gin.Context, err error, notFoundCode string, notFoundKey string) {
+    if errors.Is(err, repositories.ErrNotFound) {
+        utils.RespondWithError(c, http.StatusNotFound, notFoundCode, notFoundKey)
+        return
+    }
+    utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+}
+
+// respondInvalidParameter responde 400 INVALID_PARAMETER con el motivo indicado, normalmente un
+// *i18n.Message para que se traduzca al idioma de la petición
+func respondInvalidParameter(c *gin.Context, reason error) {
+    utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidParameter, "request.invalid_parameters", reason)
+}
+
+// newGradeResponse arma la respuesta de una calificación con su estudiante, materia y periodo
+func newGradeResponse(grade *models.Grade, student *models.Student, subject *models.Subject, term *models.Term) models.GradeResponse {
+    response := models.GradeResponse{
+        GradeID:   grade.GradeID,
+        StudentID: grade.StudentID,
+        SubjectID: grade.SubjectID,
+        TermID:    grade.TermID,
+        Grade:     grade.Grade,
+    }
+    
+    if student != nil {
+        basic := newStudentBasic(student)
+        response.Student = &basic
+    }
+    
+    if subject != nil {
+        response.Subject = &models.SubjectBasic{
+            SubjectID: subject.SubjectID,
+            Name:      subject.Name,
+        }
+    }
+    
+    response.Term = newTermBasic(term)
+    
+    if grade.DeletedAt.Valid {
+        deletedAt := grade.DeletedAt.Time
+        response.DeletedAt = &deletedAt
+    }
+    
+    return response
+}
+
+// newScaledGradeResponse arma la respuesta de una calificación incluyendo su representación en la
+// escala de la materia y el grado escolar del estudiante
+func newScaledGradeResponse(scales *scaleResolver, grade *models.Grade, student *models.Student, subject *models.Subject, term *models.Term) models.GradeResponse {
+    response := newGradeResponse(grade, student, subject, term)
+    response.Scaled = scales.Scaled(grade.SubjectID, student, grade.Grade)
+    return response
+}
+
+// newStudentBasic resume los datos de un estudiante; el grupo solo se incluye si está cargado
+func newStudentBasic(student *models.Student) models.StudentBasic {
+    basic := models.StudentBasic{
+        StudentID: student.StudentID,
+        Name:      student.Name,
+        GroupID:   student.GroupID,
+        Email:     student.Email,
+    }
+    if student.Group != nil {
+        basic.Group = student.Group.Name
+    }
+    return basic
+}
+
+// newTermBasic resume los datos de un periodo; devuelve nil si no hay periodo
+func newTermBasic(term *models.Term) *models.TermBasic {
+    if term == nil {
+        return nil
+    }
+    return &models.TermBasic{
+        TermID:     term.TermID,
+        Name:       term.Name,
+        SchoolYear: term.SchoolYear,
+        Kind:       term.Kind,
+    }
+}
+
+// parseTermFilter lee el term_id opcional y devuelve el periodo con los IDs que abarca.
+// Si hay un error ya respondió al cliente y ok es false.
+func parseTermFilter(c *gin.Context, terms repositories.TermRepository) (term *models.Term, termIDs []int, ok bool) {
+    value := c.Query("term_id")
+    if value == "" {
+        return nil, nil, true
+    }
+    
+    termID, err := strconv.Atoi(value)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "term.invalid_id")
+        return nil, nil, false
+    }
+    
+    term, err = terms.FindByID(termID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return nil, nil, false
+    }
+    
+    termIDs, err = terms.WithDescendants(termID)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.query_error")
+        return nil, nil, false
+    }
+    
+    return term, termIDs, true
+}
+
+// parseIncludeDeleted lee el include_deleted opcional; solo los administradores pueden consultar
+// registros eliminados. Si hay un error ya respondió al cliente y ok es false.
+func parseIncludeDeleted(c *gin.Context) (includeDeleted bool, ok bool) {
+    includeDeleted, err := strconv.ParseBool(c.DefaultQuery("include_deleted", "false"))
+    if err != nil {
+        respondInvalidParameter(c, i18n.NewMessage("param.not_boolean", "include_deleted"))
+        return false, false
+    }
+    if includeDeleted {
+        if claims := auth.CurrentUser(c); claims == nil || !claims.HasRole(models.RoleAdmin) {
+            utils.RespondWithError(c, http.StatusForbidden, utils.CodeForbidden, "request.deleted_admin_only")
+            return false, false
+        }
+    }
+    return includeDeleted, true
+}
+
+// parsePassingGrade lee el passing_grade opcional o usa el valor por defecto.
+// Si hay un error ya respondió al cliente y ok es false.
+func parsePassingGrade(c *gin.Context, defaultValue float64) (float64, bool) {
+    value := c.Query("passing_grade")
+    if value == "" {
+        return defaultValue, true
+    }
+    
+    passingGrade, err := strconv.ParseFloat(value, 64)
+    if err != nil || passingGrade < 0 || passingGrade > 100 {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidParameter, "analytics.invalid_passing_grade")
+        return 0, false
+    }
+    return passingGrade, true
+}
+
+// respondBindingError responde 400 a un cuerpo que no se pudo leer o que no pasó la validación.
+// Los errores de validación se devuelven campo por campo con el código VALIDATION_FAILED.
+func respondBindingError(c *gin.Context, err error) {
+    var fieldErrors validator.ValidationErrors
+    if errors.As(err, &fieldErrors) {
+        details := make([]utils.FieldError, len(fieldErrors))
+        for i, fe := range fieldErrors {
+            details[i] = utils.FieldError{
+                Field:   fieldPath(fe),
+                Rule:    fe.Tag(),
+                Message: i18n.FieldMessage(utils.Language(c), fe),
+            }
+        }
+        utils.RespondWithValidationErrors(c, "request.invalid_data", details)
+        return
+    }
+    
+    // Un valor con el tipo equivocado se reporta como error del campo
+    var typeError *json.UnmarshalTypeError
+    if errors.As(err, &typeError) && typeError.Field != "" {
+        utils.RespondWithValidationErrors(c, "request.invalid_data", []utils.FieldError{{
+            Field:   typeError.Field,
+            Rule:    "type",
+            Message: utils.Translate(c, "validation.type", typeError.Field, typeError.Type.String()),
+        }})
+        return
+    }
+    
+    utils.RespondWithError(c, http.StatusBadRequest, utils.CodeMalformedBody, "request.malformed_body")
+}
+
+// fieldPath devuelve la ruta JSON del campo con error, sin el nombre del struct (p. ej. grades[2].grade)
+func fieldPath(fe validator.FieldError) string {
+    if _, path, found := strings.Cut(fe.Namespace(), "."); found {
+        return path
+    }
+    return fe.Field()
+}
+
+// jsonFieldName devuelve el nombre JSON de un campo del struct al que apunta model
+func jsonFieldName(model interface{}, field string) string {
+    if f, ok := reflect.TypeOf(model).Elem().FieldByName(field); ok {
+        name := strings.Split(f.Tag.Get("json"), ",")[0]
+        if name != "" && name != "-" {
+            return name
+        }
+    }
+    return field
+}
+
+// currentTeacher devuelve el maestro vinculado a la cuenta autenticada, o nil si la cuenta no
+// tiene rol teacher. Si la cuenta es de maestro pero no está vinculada ya respondió 403 y ok es false.
+func currentTeacher(c *gin.Context, teachers repositories.TeacherRepository) (teacher *models.Teacher, ok bool) {
+    claims := auth.CurrentUser(c)
+    if claims == nil || !claims.HasRole(models.RoleTeacher) {
+        return nil, true
+    }
+    
+    teacher, err := teachers.FindByUserID(claims.UserID)
+    if err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusForbidden, utils.CodeTeacherNotLinked, "teacher.not_linked")
+            return nil, false
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return nil, false
+    }
+    return teacher, true
+}
+
+// currentGuardian devuelve el tutor vinculado a la cuenta autenticada. Si la cuenta no está
+// vinculada a un tutor ya respondió 403 y ok es false.
+func currentGuardian(c *gin.Context, guardians repositories.GuardianRepository) (guardian *models.Guardian, ok bool) {
+    claims := auth.CurrentUser(c)
+    if claims == nil {
+        utils.RespondWithError(c, http.StatusForbidden, utils.CodeForbidden, "auth.forbidden")
+        return nil, false
+    }
+    
+    guardian, err := guardians.FindByUserID(claims.UserID)
+    if err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusForbidden, utils.CodeGuardianNotLinked, "guardian.not_linked")
+            return nil, false
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return nil, false
+    }
+    return guardian, true
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/repositories"
+)
+
+// parseListOptions lee page, limit, sort y order de la query string.
+// sort debe ser uno de allowedSorts; order acepta "asc" o "desc".
+func parseListOptions(c *gin.Context, allowedSorts []string) (repositories.ListOptions, error) {
+    var opts repositories.ListOptions
+    
+    if value := c.Query("page"); value != "" {
+        page, err := strconv.Atoi(value)
+        if err != nil || page < 1 {
+            return opts, i18n.NewMessage("param.page")
+        }
+        opts.Page = page
+    }
+    
+    if value := c.Query("limit"); value != "" {
+        limit, err := strconv.Atoi(value)
+        if err != nil || limit < 1 || limit > repositories.MaxPageSize {
+            return opts, i18n.NewMessage("param.limit", repositories.MaxPageSize)
+        }
+        opts.Limit = limit
+    }
+    
+    if sortBy := c.Query("sort"); sortBy != "" {
+        if !contains(allowedSorts, sortBy) {
+            return opts, i18n.NewMessage("param.sort", strings.Join(allowedSorts, ", "))
+        }
+        opts.SortBy = sortBy
+    }
+    
+    switch strings.ToLower(c.DefaultQuery("order", "asc")) {
+    case "asc":
+    case "desc":
+        opts.SortDesc = true
+    default:
+        return opts, i18n.NewMessage("param.order")
+    }
+    
+    return opts.Normalize(), nil
+}
+
+// contains indica si value está en values
+func contains(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+    "bytes"
+    "fmt"
+    "log"
+    "math"
+    "net/http"
+    "sort"
+    "strconv"
+    "time"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/models"
+    "ControlEscolar/reports"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// ReportHandler agrupa los endpoints de reportes de estudiantes
+type ReportHandler struct {
+    students   repositories.StudentRepository
+    subjects   repositories.SubjectRepository
+    grades     repositories.GradeRepository
+    terms      repositories.TermRepository
+    attendance repositories.AttendanceRepository
+    scales     repositories.GradingScaleRepository
+    // passingGrade es la calificación mínima aprobatoria de las materias sin escala asignada
+    passingGrade float64
+    // maxAbsencePercentage es el porcentaje máximo de faltas permitido en una materia
+    maxAbsencePercentage float64
+}
+
+// NewReportHandler crea un ReportHandler con los repositorios indicados
+func NewReportHandler(students repositories.StudentRepository, subjects repositories.SubjectRepository, grades repositories.GradeRepository, terms repositories.TermRepository, attendance repositories.AttendanceRepository, scales repositories.GradingScaleRepository, passingGrade, maxAbsencePercentage float64) *ReportHandler {
+    return &ReportHandler{
+        students:             students,
+        subjects:             subjects,
+        grades:               grades,
+        terms:                terms,
+        attendance:           attendance,
+        scales:               scales,
+        passingGrade:         passingGrade,
+        maxAbsencePercentage: maxAbsencePercentage,
+    }
+}
+
+// GetReportCard godoc
+// @Summary      Generar la boleta de un estudiante
+// @Description  Genera en PDF la boleta con el nombre y grupo del estudiante, la calificación de cada materia, el promedio y si aprueba, junto con el porcentaje de faltas de cada materia. Con term_id solo considera ese periodo y sus parciales, y la asistencia entre sus fechas; si una materia tiene varias calificaciones se promedian. Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del estudiante (PASSING_GRADE si no tiene escala asignada). Las calificaciones, el promedio y el mínimo aprobatorio se imprimen en la escala de las materias; si usan escalas distintas el promedio se imprime de 0 a 100.
+// @Tags         reports
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        student_id  path      int  true   "ID del estudiante"
+// @Param        term_id     query     int  false  "Periodo de la boleta (incluye sus parciales)"
+// @Success      200         {file}    file
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /students/{student_id}/report-card [get]
+func (h *ReportHandler) GetReportCard(c *gin.Context) {
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    student, err := h.students.FindByID(studentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    term, termIDs, ok := parseTermFilter(c, h.terms)
+    if !ok {
+        return
+    }
+    
+    // La boleta y las estadísticas oficiales siempre usan el valor aprobatorio de la escala de cada materia
+    results, scaledAverage, err := h.subjectResults(student, term, termIDs)
+    if err != nil {
+        log.Printf("Error obteniendo calificaciones: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "report.error")
+        return
+    }
+    
+    card := buildReportCard(student, term, results, scaledAverage, h.passingGrade, h.maxAbsencePercentage)
+    
+    var buffer bytes.Buffer
+    if err := reports.WriteReportCardPDF(&buffer, card); err != nil {
+        log.Printf("Error generando PDF: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "report.error")
+        return
+    }
+    
+    filename := fmt.Sprintf("boleta_%d.pdf", student.StudentID)
+    c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+    c.Data(http.StatusOK, "application/pdf", buffer.Bytes())
+}
+
+// GetStudentSummary godoc
+// @Summary      Obtener estadísticas de un estudiante
+// @Description  Calcula el promedio, la mínima, la máxima y el número de materias reprobadas a partir de la calificación final de cada materia (promedio de sus calificaciones). Si las materias tienen créditos también devuelve el promedio ponderado por créditos. Cada materia aprueba con el valor aprobatorio de su escala de calificación en el grado escolar del estudiante (PASSING_GRADE si no tiene escala asignada) e incluye su porcentaje de faltas y si supera MAX_ABSENCE_PERCENTAGE. Con term_id solo considera ese periodo y sus parciales, y la asistencia entre sus fechas.
+// @Tags         reports
+// @Produce      json
+// @Security     BearerAuth
+// @Param        student_id  path      int  true   "ID del estudiante"
+// @Param        term_id     query     int  false  "Periodo a considerar (incluye sus parciales)"
+// @Success      200         {object}  utils.SuccessResponse{data=models.StudentSummary}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /students/{student_id}/summary [get]
+func (h *ReportHandler) GetStudentSummary(c *gin.Context) {
+    studentID, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "student.invalid_id")
+        return
+    }
+    
+    student, err := h.students.FindByID(studentID)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    term, termIDs, ok := parseTermFilter(c, h.terms)
+    if !ok {
+        return
+    }
+    
+    // La boleta y las estadísticas oficiales siempre usan el valor aprobatorio de la escala de cada materia
+    results, scaledAverage, err := h.subjectResults(student, term, termIDs)
+    if err != nil {
+        log.Printf("Error obteniendo calificaciones: %v", err)
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "analytics.stats_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "analytics.stats_retrieved", buildStudentSummary(student, term, results, scaledAverage, h.passingGrade, h.maxAbsencePercentage))
+}
+
+// subjectResults calcula la calificación final, en 0 a 100 y en su escala, y el porcentaje de
+// faltas de cada materia del estudiante, ordenadas por nombre de materia. Cada materia aprueba con
+// el valor aprobatorio de su escala en el grado escolar del estudiante. Con term solo cuenta la
+// asistencia entre sus fechas. Si todas las materias usan la misma escala también devuelve el
+// promedio de sus calificaciones en ella.
+func (h *ReportHandler) subjectResults(student *models.Student, term *models.Term, termIDs []int) ([]models.SubjectSummary, *models.ScaledGrade, error) {
+    averages, err := h.grades.SubjectAverages(student.StudentID, termIDs)
+    if err != nil {
+        return nil, nil, err
+    }
+    
+    ids := make([]int, len(averages))
+    for i, average := range averages {
+        ids[i] = average.SubjectID
+    }
+    subjects, err := h.subjects.FindByIDs(ids)
+    if err != nil {
+        return nil, nil, err
+    }
+    
+    var from, to *time.Time
+    if term != nil {
+        from, to = &term.StartDate, &term.EndDate
+    }
+    counts, err := h.attendance.Counts(student.StudentID, from, to)
+    if err != nil {
+        return nil, nil, err
+    }
+    _, attendance := summarizeAttendance(counts, h.maxAbsencePercentage)
+    
+    scales := newScaleResolver(h.scales, h.passingGrade)
+    results := make([]models.SubjectSummary, len(averages))
+    var common *models.GradingScale
+    sameScale, total := true, 0.0
+    for i, average := range averages {
+        scale, err := scales.For(average.SubjectID, student)
+        if err != nil {
+            return nil, nil, err
+        }
+        grade := roundGrade(average.Average)
+        scaled := scale.Convert(grade)
+        results[i] = models.SubjectSummary{
+            SubjectID:    average.SubjectID,
+            Name:         fmt.Sprintf("Materia %d", average.SubjectID),
+            Grade:        grade,
+            Scaled:       scaled,
+            GradeCount:   average.GradeCount,
+            PassingGrade: scale.ToPercentage(scale.PassingValue),
+            PassingValue: scale.PassingValue,
+            Passed:       scaled.Passed,
+        }
+        if common == nil {
+            common = &scale
+        } else if common.ScaleID != scale.ScaleID {
+            sameScale = false
+        }
+        total += scaled.Value
+        if subject, ok := subjects[average.SubjectID]; ok {
+            results[i].Name = subject.Name
+            results[i].Credits = subject.Credits
+        }
+        if totals, ok := attendance[average.SubjectID]; ok {
+            percentage := totals.AbsencePercentage
+            results[i].AbsencePercentage = &percentage
+            results[i].ExceedsAbsenceLimit = totals.ExceedsLimit
+        }
+    }
+    
+    sort.Slice(results, func(i, j int) bool {
+        return results[i].Name < results[j].Name
+    })
+    
+    // Promediar valores de escalas distintas no tiene sentido; en ese caso solo queda el promedio de 0 a 100
+    if common == nil || !sameScale {
+        return results, nil, nil
+    }
+    scaledAverage := common.Represent(total / float64(len(results)))
+    return results, &scaledAverage, nil
+}
+
+// buildReportCard arma la boleta a partir de las calificaciones finales por materia.
+// El estudiante aprueba si tiene al menos una materia y no reprueba ninguna.
+func buildReportCard(student *models.Student, term *models.Term, results []models.SubjectSummary, scaledAverage *models.ScaledGrade, passingGrade, maxAbsencePercentage float64) *models.ReportCard {
+    card := &models.ReportCard{
+        Student:              newStudentBasic(student),
+        Term:                 newTermBasic(term),
+        Subjects:             make([]models.ReportCardSubject, len(results)),
+        ScaledAverage:        scaledAverage,
+        PassingGrade:         passingGrade,
+        Passed:               len(results) > 0,
+        MaxAbsencePercentage: maxAbsencePercentage,
+        GeneratedAt:          time.Now(),
+    }
+    
+    total := 0.0
+    for i, result := range results {
+        card.Subjects[i] = models.ReportCardSubject{
+            SubjectID:           result.SubjectID,
+            Name:                result.Name,
+            Grade:               result.Grade,
+            Scaled:              result.Scaled,
+            PassingGrade:        result.PassingGrade,
+            PassingValue:        result.PassingValue,
+            Passed:              result.Passed,
+            AbsencePercentage:   result.AbsencePercentage,
+            ExceedsAbsenceLimit: result.ExceedsAbsenceLimit,
+        }
+        total += result.Grade
+        card.Passed = card.Passed && result.Passed
+    }
+    
+    if len(results) > 0 {
+        card.Average = roundGrade(total / float64(len(results)))
+    }
+    
+    return card
+}
+
+// buildStudentSummary calcula las estadísticas del estudiante. El promedio ponderado
+// solo se informa si alguna materia tiene créditos; las materias sin créditos no cuentan en él.
+func buildStudentSummary(student *models.Student, term *models.Term, results []models.SubjectSummary, scaledAverage *models.ScaledGrade, passingGrade, maxAbsencePercentage float64) *models.StudentSummary {
+    summary := &models.StudentSummary{
+        Student:              newStudentBasic(student),
+        Term:                 newTermBasic(term),
+        SubjectCount:         len(results),
+        ScaledAverage:        scaledAverage,
+        PassingGrade:         passingGrade,
+        MaxAbsencePercentage: maxAbsencePercentage,
+        Subjects:             results,
+    }
+    if len(results) == 0 {
+        return summary
+    }
+    
+    total, weighted := 0.0, 0.0
+    min, max := results[0].Grade, results[0].Grade
+    for _, result := range results {
+        total += result.Grade
+        weighted += result.Grade * result.Credits
+        summary.TotalCredits += result.Credits
+        min = math.Min(min, result.Grade)
+        max = math.Max(max, result.Grade)
+        if !result.Passed {
+            summary.FailedSubjects++
+        }
+        if result.ExceedsAbsenceLimit {
+            summary.SubjectsOverAbsenceLimit++
+        }
+    }
+    
+    average := roundGrade(total / float64(len(results)))
+    summary.Average = &average
+    summary.Min = &min
+    summary.Max = &max
+    if summary.TotalCredits > 0 {
+        weightedAverage := roundGrade(weighted / summary.TotalCredits)
+        summary.WeightedAverage = &weightedAverage
+    }
+    
+    return summary
+}
+
+// roundGrade redondea una calificación a dos decimales
+func roundGrade(value float64) float64 {
+    return math.Round(value*100) / 100
+}
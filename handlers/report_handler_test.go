@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "testing"
+    
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+)
+
+func TestGetStudentSummaryUsesTheSubjectScale(t *testing.T) {
+    db := newTestDB(t)
+    group := models.Group{SchoolYear: "2025-2026", GradeLevel: 3, Section: "A", Name: "3A"}
+    math := models.Subject{Name: "Matemáticas"}
+    history := models.Subject{Name: "Historia"}
+    mustCreate(t, db, &group, &math, &history)
+    student := models.Student{Name: "Ana", Email: "ana@example.com", GroupID: &group.GroupID}
+    mustCreate(t, db, &student)
+    
+    scale := models.GradingScale{Name: "Primaria 5-10", MinValue: 5, MaxValue: 10, PassingValue: 6, Decimals: 1, Rounding: models.RoundingHalfUp}
+    mustCreate(t, db, &scale)
+    mustCreate(t, db, &models.GradingScaleAssignment{ScaleID: scale.ScaleID, GradeLevel: &group.GradeLevel})
+    grades := repositories.NewGormGradeRepository(db)
+    for _, grade := range []models.Grade{
+        {StudentID: student.StudentID, SubjectID: math.SubjectID, Grade: 85},
+        {StudentID: student.StudentID, SubjectID: history.SubjectID, Grade: 62},
+    } {
+        if err := grades.Create(&grade, models.GradeChange{}); err != nil {
+            t.Fatalf("crear calificación: %v", err)
+        }
+    }
+    
+    router := newTestRouter(db)
+    admin := newAccount(t, db, "admin", models.RoleAdmin)
+    
+    recorder := performRequest(router, http.MethodGet, fmt.Sprintf("/api/students/%d/summary", student.StudentID), admin, nil)
+    assertStatus(t, recorder, http.StatusOK)
+    var response struct {
+        Data models.StudentSummary `json:"data"`
+    }
+    if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+        t.Fatalf("respuesta no es JSON: %v", err)
+    }
+    
+    // Las materias se ordenan por nombre: Historia (6.2) y Matemáticas (8.5)
+    summary := response.Data
+    if len(summary.Subjects) != 2 || summary.Subjects[0].Scaled.Value != 6.2 || summary.Subjects[1].Scaled.Value != 8.5 {
+        t.Fatalf("subjects = %+v, se esperaban 6.2 y 8.5 en la escala", summary.Subjects)
+    }
+    if summary.Subjects[0].PassingValue != 6 {
+        t.Errorf("passing_value = %v, se esperaba 6", summary.Subjects[0].PassingValue)
+    }
+    // (6.2 + 8.5) / 2 = 7.35, que con un decimal y half_up es 7.4
+    if summary.ScaledAverage == nil || summary.ScaledAverage.Value != 7.4 {
+        t.Errorf("scaled_average = %+v, se esperaba 7.4", summary.ScaledAverage)
+    }
+}
@@ -1,59 +1,127 @@
 package handlers
 
 import (
+    "errors"
     "net/http"
+    "sort"
     "strconv"
+    "strings"
     
     "github.com/gin-gonic/gin"
-    "ControlEscolar/config"
+    "github.com/gin-gonic/gin/binding"
+    "github.com/go-playground/validator/v10"
+    "ControlEscolar/i18n"
     "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/spreadsheet"
     "ControlEscolar/utils"
 )
 
+// StudentHandler agrupa los endpoints de estudiantes
+type StudentHandler struct {
+    students repositories.StudentRepository
+    groups   repositories.GroupRepository
+}
+
+// NewStudentHandler crea un StudentHandler con los repositorios indicados
+func NewStudentHandler(students repositories.StudentRepository, groups repositories.GroupRepository) *StudentHandler {
+    return &StudentHandler{
+        students: students,
+        groups:   groups,
+    }
+}
+
 // CreateStudent godoc
 // @Summary      Crear un nuevo estudiante
-// @Description  Registra un nuevo estudiante en el sistema
+// @Description  Registra un nuevo estudiante en el sistema dentro de un grupo existente
 // @Tags         students
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        student  body      models.Student  true  "Información del estudiante"
 // @Success      201      {object}  utils.SuccessResponse{data=models.Student}
 // @Failure      400      {object}  utils.ErrorResponse
+// @Failure      409      {object}  utils.ErrorResponse{details=models.StudentConflictDetails}
 // @Failure      500      {object}  utils.ErrorResponse
 // @Router       /students [post]
-func CreateStudent(c *gin.Context) {
+func (h *StudentHandler) CreateStudent(c *gin.Context) {
     var student models.Student
     
     if err := c.ShouldBindJSON(&student); err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "Datos inválidos: "+err.Error())
+        respondBindingError(c, err)
+        return
+    }
+    
+    group, err := h.groups.FindByID(*student.GroupID)
+    if err != nil {
+        respondGroupLookupError(c, err)
         return
     }
     
-    if err := config.GetDB().Create(&student).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al crear el estudiante")
+    if err := h.students.Create(&student); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            h.respondDuplicateEmail(c, student.Email)
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "student.create_error")
         return
     }
+    student.Group = group
     
-    utils.RespondWithSuccess(c, http.StatusCreated, "Estudiante creado exitosamente", student)
+    utils.RespondWithSuccess(c, http.StatusCreated, "student.created", student)
 }
 
 // GetAllStudents godoc
-// @Summary      Listar todos los estudiantes
-// @Description  Obtiene la lista completa de estudiantes registrados
+// @Summary      Listar estudiantes
+// @Description  Obtiene una página de estudiantes, con filtros por grupo o texto y orden configurable. Los estudiantes eliminados solo se incluyen con include_deleted (administradores).
 // @Tags         students
 // @Produce      json
-// @Success      200  {array}   models.Student
-// @Failure      500  {object}  utils.ErrorResponse
+// @Security     BearerAuth
+// @Param        page             query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit            query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort             query     string  false  "Campo de orden (group ordena por el nombre del grupo)"  Enums(student_id, name, group, group_id, email)
+// @Param        order            query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        group_id         query     int     false  "Filtrar por grupo"
+// @Param        group            query     string  false  "Filtrar por el nombre exacto del grupo"  example(5A)
+// @Param        q                query     string  false  "Buscar texto en nombre o email"
+// @Param        include_deleted  query     bool    false  "Incluir estudiantes eliminados (solo administradores)"  default(false)
+// @Success      200              {object}  utils.PaginatedResponse{data=[]models.Student}
+// @Failure      400              {object}  utils.ErrorResponse
+// @Failure      403              {object}  utils.ErrorResponse
+// @Failure      500              {object}  utils.ErrorResponse
 // @Router       /students [get]
-func GetAllStudents(c *gin.Context) {
-    var students []models.Student
+func (h *StudentHandler) GetAllStudents(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.StudentSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
     
-    if err := config.GetDB().Find(&students).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al obtener estudiantes")
+    filter := repositories.StudentFilter{
+        Group: strings.TrimSpace(c.Query("group")),
+        Query: strings.TrimSpace(c.Query("q")),
+    }
+    if value := c.Query("group_id"); value != "" {
+        groupID, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", "group_id"))
+            return
+        }
+        filter.GroupID = groupID
+    }
+    
+    var ok bool
+    if filter.IncludeDeleted, ok = parseIncludeDeleted(c); !ok {
         return
     }
     
-    c.JSON(http.StatusOK, students)
+    students, total, err := h.students.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "student.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "student.list_retrieved", students, opts.Page, opts.Limit, total)
 }
 
 // GetStudent godoc
@@ -61,25 +129,26 @@ func GetAllStudents(c *gin.Context) {
 // @Description  Obtiene la información detallada de un estudiante específico
 // @Tags         students
 // @Produce      json
+// @Security     BearerAuth
 // @Param        student_id  path      int  true  "ID del estudiante"
-// @Success      200         {object}  models.Student
+// @Success      200         {object}  utils.SuccessResponse{data=models.Student}
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
 // @Router       /students/{student_id} [get]
-func GetStudent(c *gin.Context) {
+func (h *StudentHandler) GetStudent(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("student_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var student models.Student
-    if err := config.GetDB().First(&student, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Estudiante no encontrado")
+    student, err := h.students.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
         return
     }
     
-    c.JSON(http.StatusOK, student)
+    utils.RespondWithSuccess(c, http.StatusOK, "student.retrieved", student)
 }
 
 // UpdateStudent godoc
@@ -88,72 +157,403 @@ func GetStudent(c *gin.Context) {
 // @Tags         students
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        student_id  path      int             true  "ID del estudiante"
 // @Param        student     body      models.Student  true  "Información actualizada del estudiante"
 // @Success      200         {object}  utils.SuccessResponse{data=models.Student}
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse{details=models.StudentConflictDetails}
 // @Failure      500         {object}  utils.ErrorResponse
 // @Router       /students/{student_id} [put]
-func UpdateStudent(c *gin.Context) {
+func (h *StudentHandler) UpdateStudent(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("student_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var student models.Student
-    if err := config.GetDB().First(&student, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Estudiante no encontrado")
+    student, err := h.students.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
         return
     }
     
     var updatedData models.Student
     if err := c.ShouldBindJSON(&updatedData); err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "Datos inválidos: "+err.Error())
+        respondBindingError(c, err)
+        return
+    }
+    
+    group, err := h.groups.FindByID(*updatedData.GroupID)
+    if err != nil {
+        respondGroupLookupError(c, err)
         return
     }
     
     student.Name = updatedData.Name
-    student.Group = updatedData.Group
+    student.GroupID = updatedData.GroupID
+    student.Group = group
     student.Email = updatedData.Email
     
-    if err := config.GetDB().Save(&student).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al actualizar estudiante")
+    if err := h.students.Update(student); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            h.respondDuplicateEmail(c, student.Email)
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "student.update_error")
         return
     }
     
-    utils.RespondWithSuccess(c, http.StatusOK, "Estudiante actualizado exitosamente", student)
+    utils.RespondWithSuccess(c, http.StatusOK, "student.updated", student)
+}
+
+// respondGroupLookupError responde 400 si el grupo indicado no existe o 500 ante cualquier otro error
+func respondGroupLookupError(c *gin.Context, err error) {
+    if errors.Is(err, repositories.ErrNotFound) {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeGroupNotFound, "student.group_not_found")
+        return
+    }
+    utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+}
+
+// respondDuplicateEmail responde 409 cuando el email ya está registrado. Si lo conserva un
+// estudiante eliminado, el código DELETED_STUDENT_EMAIL y su ID indican que hay que restaurarlo.
+func (h *StudentHandler) respondDuplicateEmail(c *gin.Context, email string) {
+    if deleted, err := h.students.FindDeletedByEmail(email); err == nil {
+        utils.RespondWithErrorDetails(c, http.StatusConflict, utils.CodeDeletedStudentEmail, "student.deleted_email", models.StudentConflictDetails{StudentID: deleted.StudentID})
+        return
+    }
+    utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateEmail, "student.duplicate_email")
 }
 
 // DeleteStudent godoc
 // @Summary      Eliminar un estudiante
-// @Description  Elimina un estudiante del sistema (también elimina sus calificaciones por CASCADE)
+// @Description  Marca un estudiante como eliminado. Sus calificaciones se conservan, pero dejan de aparecer en listados, estadísticas y exportaciones hasta que se restaure.
 // @Tags         students
 // @Produce      json
+// @Security     BearerAuth
 // @Param        student_id  path      int  true  "ID del estudiante"
 // @Success      200         {object}  utils.SuccessResponse
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
 // @Failure      500         {object}  utils.ErrorResponse
 // @Router       /students/{student_id} [delete]
-func DeleteStudent(c *gin.Context) {
+func (h *StudentHandler) DeleteStudent(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("student_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var student models.Student
-    if err := config.GetDB().First(&student, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Estudiante no encontrado")
+    if err := h.students.Delete(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeStudentNotFound, "student.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "student.delete_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "student.deleted", nil)
+}
+
+// RestoreStudent godoc
+// @Summary      Restaurar un estudiante eliminado
+// @Description  Quita la marca de eliminado de un estudiante; sus calificaciones vuelven a estar visibles
+// @Tags         students
+// @Produce      json
+// @Security     BearerAuth
+// @Param        student_id  path      int  true  "ID del estudiante"
+// @Success      200         {object}  utils.SuccessResponse{data=models.Student}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /students/{student_id}/restore [post]
+func (h *StudentHandler) RestoreStudent(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("student_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    student, err := h.students.Restore(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeStudentNotFound, "student.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "student.restored", student)
+}
+
+// Límites de la importación masiva de estudiantes
+const (
+    maxImportBytes    = 5 << 20
+    maxImportRows     = 5000
+    // maxImportOverhead es el margen para los encabezados y límites del formulario multipart
+    maxImportOverhead = 64 << 10
+)
+
+// studentImportColumns son las columnas requeridas en el encabezado del archivo de importación
+var studentImportColumns = []string{"name", "email", "group_id"}
+
+// studentImportRow es una fila del archivo ya convertida en estudiante
+type studentImportRow struct {
+    number  int
+    student models.Student
+    valid   bool
+}
+
+// ImportStudents godoc
+// @Summary      Importar estudiantes desde CSV o Excel
+// @Description  Crea estudiantes a partir de un archivo .csv o .xlsx con las columnas name, email y group_id en el encabezado. Cada fila se valida con las mismas reglas que al crear un estudiante; si alguna tiene errores no se importa ninguna. Con dry_run=true solo se valida el archivo.
+// @Tags         students
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file     formData  file  true   "Archivo .csv o .xlsx (máximo 5 MB y 5000 filas)"
+// @Param        dry_run  query     bool  false  "Validar sin guardar"  default(false)
+// @Success      200      {object}  utils.SuccessResponse{data=models.ImportReport}
+// @Success      201      {object}  utils.SuccessResponse{data=models.ImportReport}
+// @Failure      400      {object}  utils.ErrorResponse{details=models.ImportReport}
+// @Failure      409      {object}  utils.ErrorResponse
+// @Failure      413      {object}  utils.ErrorResponse
+// @Failure      500      {object}  utils.ErrorResponse
+// @Router       /students/import [post]
+func (h *StudentHandler) ImportStudents(c *gin.Context) {
+    dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+    if err != nil {
+        respondInvalidParameter(c, i18n.NewMessage("param.not_boolean", "dry_run"))
         return
     }
     
-    if err := config.GetDB().Delete(&student).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al eliminar estudiante")
+    // El límite se aplica al leer el cuerpo, antes de que el formulario se guarde en memoria o en disco
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes+maxImportOverhead)
+    file, err := c.FormFile("file")
+    if err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            utils.RespondWithError(c, http.StatusRequestEntityTooLarge, utils.CodeFileTooLarge, "import.file_too_large")
+            return
+        }
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidFile, "import.file_required")
+        return
+    }
+    if file.Size > maxImportBytes {
+        utils.RespondWithError(c, http.StatusRequestEntityTooLarge, utils.CodeFileTooLarge, "import.file_too_large")
+        return
+    }
+    
+    format, err := spreadsheet.FormatOf(file.Filename)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidFile, "request.invalid_data_detail", err)
         return
     }
     
-    utils.RespondWithSuccess(c, http.StatusOK, "Estudiante eliminado exitosamente", nil)
-}
\ No newline at end of file
+    src, err := file.Open()
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "import.read_error")
+        return
+    }
+    defer src.Close()
+    
+    rows, err := spreadsheet.ReadRows(src, format)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidFile, "request.invalid_data_detail", err)
+        return
+    }
+    
+    importRows, report, err := parseStudentRows(rows, utils.Language(c))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidFile, "request.invalid_data_detail", err)
+        return
+    }
+    report.DryRun = dryRun
+    
+    if err := h.checkStudentRows(importRows, &report, utils.Language(c)); err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "request.database_error")
+        return
+    }
+    
+    if dryRun {
+        utils.RespondWithSuccess(c, http.StatusOK, "import.validated", report)
+        return
+    }
+    if len(report.Errors) > 0 {
+        utils.RespondWithErrorDetails(c, http.StatusBadRequest, utils.CodeValidationFailed, "import.has_errors", report)
+        return
+    }
+    
+    students := make([]models.Student, len(importRows))
+    for i, row := range importRows {
+        students[i] = row.student
+    }
+    if err := h.students.CreateMany(students); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateEmail, "import.email_taken_meanwhile")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "import.error")
+        return
+    }
+    report.Imported = len(students)
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "import.imported", report)
+}
+
+// parseStudentRows convierte las filas del archivo en estudiantes y valida cada una con las
+// reglas de binding de models.Student; los errores de las filas se escriben en el idioma lang.
+// Devuelve error solo si el archivo completo es inválido.
+func parseStudentRows(rows [][]string, lang string) ([]studentImportRow, models.ImportReport, error) {
+    report := models.ImportReport{Errors: []models.ImportRowError{}}
+    if len(rows) == 0 {
+        return nil, report, i18n.NewMessage("import.empty_file")
+    }
+    
+    columns := make(map[string]int)
+    for i, name := range rows[0] {
+        columns[strings.ToLower(name)] = i
+    }
+    for _, name := range studentImportColumns {
+        if _, ok := columns[name]; !ok {
+            return nil, report, i18n.NewMessage("import.missing_column", name)
+        }
+    }
+    
+    importRows := []studentImportRow{}
+    emailRows := make(map[string]int)
+    for i, cells := range rows[1:] {
+        if strings.Join(cells, "") == "" {
+            continue
+        }
+        if len(importRows) == maxImportRows {
+            return nil, report, i18n.NewMessage("import.too_many_rows", maxImportRows)
+        }
+        
+        cell := func(name string) string {
+            if index := columns[name]; index < len(cells) {
+                return cells[index]
+            }
+            return ""
+        }
+        row := studentImportRow{
+            number: i + 2,
+            student: models.Student{
+                Name:  cell("name"),
+                Email: cell("email"),
+            },
+            valid: true,
+        }
+        addError := func(field, value, message string) {
+            report.Errors = append(report.Errors, models.ImportRowError{
+                Row:     row.number,
+                Field:   field,
+                Value:   value,
+                Message: message,
+            })
+            row.valid = false
+        }
+        
+        groupValue := cell("group_id")
+        groupInvalid := false
+        if groupValue != "" {
+            groupID, err := strconv.Atoi(groupValue)
+            if err != nil {
+                addError("group_id", groupValue, i18n.T(lang, "param.not_integer", "group_id"))
+                groupInvalid = true
+            } else {
+                row.student.GroupID = &groupID
+            }
+        }
+        
+        if err := binding.Validator.ValidateStruct(&row.student); err != nil {
+            var fieldErrors validator.ValidationErrors
+            if !errors.As(err, &fieldErrors) {
+                return nil, report, err
+            }
+            for _, fe := range fieldErrors {
+                field := jsonFieldName(&row.student, fe.StructField())
+                if field == "group_id" && groupInvalid {
+                    continue
+                }
+                addError(field, cell(field), i18n.FieldMessage(lang, fe))
+            }
+        }
+        
+        if row.student.Email != "" {
+            email := strings.ToLower(row.student.Email)
+            if first, ok := emailRows[email]; ok {
+                addError("email", row.student.Email, i18n.T(lang, "import.repeated_email", first))
+            } else {
+                emailRows[email] = row.number
+            }
+        }
+        
+        importRows = append(importRows, row)
+    }
+    
+    report.TotalRows = len(importRows)
+    return importRows, report, nil
+}
+
+// checkStudentRows verifica contra la base de datos que los grupos existan y que los emails
+// no estén registrados, y completa el conteo de filas válidas del reporte
+func (h *StudentHandler) checkStudentRows(rows []studentImportRow, report *models.ImportReport, lang string) error {
+    groupExists := make(map[int]bool)
+    emails := []string{}
+    for _, row := range rows {
+        if row.student.GroupID != nil {
+            groupExists[*row.student.GroupID] = false
+        }
+        if row.student.Email != "" {
+            emails = append(emails, row.student.Email)
+        }
+    }
+    
+    for groupID := range groupExists {
+        _, err := h.groups.FindByID(groupID)
+        if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+            return err
+        }
+        groupExists[groupID] = err == nil
+    }
+    
+    existing, err := h.students.ExistingEmails(emails)
+    if err != nil {
+        return err
+    }
+    taken := make(map[string]bool)
+    for _, email := range existing {
+        taken[email] = true
+    }
+    
+    for i := range rows {
+        student := rows[i].student
+        if student.GroupID != nil && *student.GroupID > 0 && !groupExists[*student.GroupID] {
+            report.Errors = append(report.Errors, models.ImportRowError{
+                Row:     rows[i].number,
+                Field:   "group_id",
+                Value:   strconv.Itoa(*student.GroupID),
+                Message: i18n.T(lang, "import.group_not_found"),
+            })
+            rows[i].valid = false
+        }
+        if taken[strings.ToLower(student.Email)] {
+            report.Errors = append(report.Errors, models.ImportRowError{
+                Row:     rows[i].number,
+                Field:   "email",
+                Value:   student.Email,
+                Message: i18n.T(lang, "import.email_taken"),
+            })
+            rows[i].valid = false
+        }
+        if rows[i].valid {
+            report.ValidRows++
+        }
+    }
+    
+    sort.SliceStable(report.Errors, func(a, b int) bool {
+        return report.Errors[a].Row < report.Errors[b].Row
+    })
+    return nil
+}
@@ -0,0 +1,223 @@
+package handlers_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io"
+    "mime/multipart"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/routes"
+    "ControlEscolar/utils"
+)
+
+// stubGroupRepository resuelve los grupos de las pruebas de estudiantes; los demás métodos no se usan
+type stubGroupRepository struct {
+    repositories.GroupRepository
+    groups map[int]models.Group
+}
+
+func (r stubGroupRepository) FindByID(id int) (*models.Group, error) {
+    group, ok := r.groups[id]
+    if !ok {
+        return nil, repositories.ErrNotFound
+    }
+    return &group, nil
+}
+
+// newStudentTestRouter registra las rutas de la API con los estudiantes en memoria y los grupos
+// indicados; devuelve también el token de un administrador
+func newStudentTestRouter(t *testing.T, students *repositories.MemoryStudentRepository, groups ...models.Group) (*gin.Engine, string) {
+    t.Helper()
+    stub := stubGroupRepository{groups: make(map[int]models.Group)}
+    for _, group := range groups {
+        stub.groups[group.GroupID] = group
+    }
+    
+    db := newTestDB(t)
+    repos := routes.NewGormRepositories(db)
+    repos.Students = students
+    repos.Groups = stub
+    return newTestRouterWith(repos), newAccount(t, db, "admin", models.RoleAdmin)
+}
+
+func TestCreateStudentWithDeletedEmailPointsToRestore(t *testing.T) {
+    group := models.Group{GroupID: 1, GradeLevel: 3, Section: "A", Name: "3A"}
+    students := repositories.NewMemoryStudentRepository()
+    deleted := &models.Student{Name: "Ana", Email: "ana@example.com", GroupID: &group.GroupID}
+    if err := students.Create(deleted); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    if err := students.Delete(deleted.StudentID); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    router, admin := newStudentTestRouter(t, students, group)
+    
+    body := gin.H{"name": "Ana Nueva", "email": "ana@example.com", "group_id": group.GroupID}
+    response := assertError(t, performRequest(router, http.MethodPost, "/api/students", admin, body), http.StatusConflict, utils.CodeDeletedStudentEmail)
+    
+    details, _ := response.Details.(map[string]interface{})
+    if details["student_id"] != float64(deleted.StudentID) {
+        t.Errorf("details = %v, se esperaba student_id %d", response.Details, deleted.StudentID)
+    }
+    
+    // Un email ocupado por un estudiante activo sigue siendo un duplicado común
+    active := &models.Student{Name: "Beto", Email: "beto@example.com", GroupID: &group.GroupID}
+    if err := students.Create(active); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    body = gin.H{"name": "Beto Nuevo", "email": "beto@example.com", "group_id": group.GroupID}
+    assertError(t, performRequest(router, http.MethodPost, "/api/students", admin, body), http.StatusConflict, utils.CodeDuplicateEmail)
+}
+
+func TestGetAllStudentsFiltersAndSortsByGroupName(t *testing.T) {
+    groupA := models.Group{GroupID: 1, GradeLevel: 3, Section: "A", Name: "3A"}
+    groupB := models.Group{GroupID: 2, GradeLevel: 3, Section: "B", Name: "3B"}
+    students := repositories.NewMemoryStudentRepository()
+    for _, student := range []models.Student{
+        {Name: "Ana", Email: "ana@example.com", GroupID: &groupB.GroupID, Group: &groupB},
+        {Name: "Beto", Email: "beto@example.com", GroupID: &groupA.GroupID, Group: &groupA},
+        {Name: "Carla", Email: "carla@example.com", GroupID: &groupB.GroupID, Group: &groupB},
+    } {
+        if err := students.Create(&student); err != nil {
+            t.Fatalf("Create: %v", err)
+        }
+    }
+    router, admin := newStudentTestRouter(t, students, groupA, groupB)
+    
+    var page struct {
+        Data       []models.Student     `json:"data"`
+        Pagination utils.PaginationMeta `json:"pagination"`
+    }
+    
+    recorder := performRequest(router, http.MethodGet, "/api/students?group=3B", admin, nil)
+    assertStatus(t, recorder, http.StatusOK)
+    if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+        t.Fatalf("respuesta no es JSON: %v", err)
+    }
+    if page.Pagination.Total != 2 {
+        t.Fatalf("total del grupo 3B = %d, se esperaba 2", page.Pagination.Total)
+    }
+    for _, student := range page.Data {
+        if student.Group == nil || student.Group.Name != "3B" {
+            t.Errorf("estudiante %q fuera del grupo 3B", student.Name)
+        }
+    }
+    
+    recorder = performRequest(router, http.MethodGet, "/api/students?sort=group&order=desc", admin, nil)
+    assertStatus(t, recorder, http.StatusOK)
+    if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+        t.Fatalf("respuesta no es JSON: %v", err)
+    }
+    if len(page.Data) != 3 || page.Data[2].Name != "Beto" {
+        t.Errorf("con sort=group&order=desc el último estudiante debe ser Beto (3A), se obtuvo %+v", page.Data)
+    }
+}
+
+func TestCreateStudentRejectsUnknownGroup(t *testing.T) {
+    router, admin := newStudentTestRouter(t, repositories.NewMemoryStudentRepository())
+    
+    body := gin.H{"name": "Ana", "email": "ana@example.com", "group_id": 9}
+    assertError(t, performRequest(router, http.MethodPost, "/api/students", admin, body), http.StatusBadRequest, utils.CodeGroupNotFound)
+}
+
+// performUpload envía content como el archivo filename en el campo file de un formulario multipart
+func performUpload(router http.Handler, path, token, filename string, content []byte) *httptest.ResponseRecorder {
+    var body bytes.Buffer
+    form := multipart.NewWriter(&body)
+    part, _ := form.CreateFormFile("file", filename)
+    part.Write(content)
+    form.Close()
+    
+    req := httptest.NewRequest(http.MethodPost, path, &body)
+    req.Header.Set("Content-Type", form.FormDataContentType())
+    req.Header.Set("Authorization", "Bearer "+token)
+    
+    recorder := httptest.NewRecorder()
+    router.ServeHTTP(recorder, req)
+    return recorder
+}
+
+func TestImportStudentsValidatesBeforeSaving(t *testing.T) {
+    f := newSchoolFixture(t)
+    content := []byte(fmt.Sprintf("name,email,group_id\nBeto,beto@example.com,%d\nCarla,carla@example.com,%d\n", f.group.GroupID, f.group.GroupID))
+    
+    var response struct {
+        Data models.ImportReport `json:"data"`
+    }
+    recorder := performUpload(f.router, "/api/students/import?dry_run=true", f.admin, "alumnos.csv", content)
+    assertStatus(t, recorder, http.StatusOK)
+    if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+        t.Fatalf("respuesta no es JSON: %v", err)
+    }
+    if response.Data.ValidRows != 2 || response.Data.Imported != 0 {
+        t.Errorf("dry_run = %+v, se esperaban 2 filas válidas y ninguna importada", response.Data)
+    }
+    
+    recorder = performUpload(f.router, "/api/students/import", f.admin, "alumnos.csv", content)
+    assertStatus(t, recorder, http.StatusCreated)
+    
+    // Al repetir el archivo los emails ya existen y no se importa ninguna fila
+    recorder = performUpload(f.router, "/api/students/import", f.admin, "alumnos.csv", content)
+    assertError(t, recorder, http.StatusBadRequest, utils.CodeValidationFailed)
+    
+    var count int64
+    if err := f.db.Model(&models.Student{}).Count(&count).Error; err != nil {
+        t.Fatalf("contar estudiantes: %v", err)
+    }
+    if count != 3 {
+        t.Errorf("estudiantes = %d, se esperaban Ana y los 2 importados", count)
+    }
+    
+    assertError(t, performUpload(f.router, "/api/students/import", f.assigned, "alumnos.csv", content), http.StatusForbidden, utils.CodeForbidden)
+}
+
+// countingReader cuenta los bytes que el servidor lee del cuerpo de la petición
+type countingReader struct {
+    reader io.Reader
+    read   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+    n, err := r.reader.Read(p)
+    r.read += int64(n)
+    return n, err
+}
+
+// repeatedByte es un lector infinito del mismo byte
+type repeatedByte byte
+
+func (b repeatedByte) Read(p []byte) (int, error) {
+    for i := range p {
+        p[i] = byte(b)
+    }
+    return len(p), nil
+}
+
+func TestImportStudentsStopsReadingLargeBodies(t *testing.T) {
+    f := newSchoolFixture(t)
+    
+    // Un archivo de 50 MB: el servidor debe dejar de leer poco después de los 5 MB permitidos
+    var head bytes.Buffer
+    form := multipart.NewWriter(&head)
+    form.CreateFormFile("file", "alumnos.csv")
+    body := &countingReader{reader: io.MultiReader(&head, io.LimitReader(repeatedByte('x'), 50<<20), strings.NewReader("\r\n--"+form.Boundary()+"--\r\n"))}
+    
+    req := httptest.NewRequest(http.MethodPost, "/api/students/import", body)
+    req.Header.Set("Content-Type", form.FormDataContentType())
+    req.Header.Set("Authorization", "Bearer "+f.admin)
+    recorder := httptest.NewRecorder()
+    f.router.ServeHTTP(recorder, req)
+    
+    assertError(t, recorder, http.StatusRequestEntityTooLarge, utils.CodeFileTooLarge)
+    if body.read > 6<<20 {
+        t.Errorf("se leyeron %d bytes del cuerpo, se esperaba dejar de leer cerca de los 5 MB", body.read)
+    }
+}
@@ -1,40 +1,134 @@
 package handlers
 
 import (
+    "errors"
     "net/http"
     "strconv"
+    "strings"
     
     "github.com/gin-gonic/gin"
-    "ControlEscolar/config"
+    "ControlEscolar/i18n"
     "ControlEscolar/models"
+    "ControlEscolar/repositories"
     "ControlEscolar/utils"
 )
 
+// SubjectHandler agrupa los endpoints de materias
+type SubjectHandler struct {
+    subjects repositories.SubjectRepository
+}
+
+// NewSubjectHandler crea un SubjectHandler con el repositorio indicado
+func NewSubjectHandler(subjects repositories.SubjectRepository) *SubjectHandler {
+    return &SubjectHandler{subjects: subjects}
+}
+
 // CreateSubject godoc
 // @Summary      Crear una nueva materia
 // @Description  Registra una nueva materia en el sistema
 // @Tags         subjects
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        subject  body      models.Subject  true  "Información de la materia"
 // @Success      201      {object}  utils.SuccessResponse{data=models.Subject}
 // @Failure      400      {object}  utils.ErrorResponse
+// @Failure      409      {object}  utils.ErrorResponse{details=models.SubjectConflictDetails}
 // @Failure      500      {object}  utils.ErrorResponse
 // @Router       /subjects [post]
-func CreateSubject(c *gin.Context) {
+func (h *SubjectHandler) CreateSubject(c *gin.Context) {
     var subject models.Subject
     
     if err := c.ShouldBindJSON(&subject); err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "Datos inválidos: "+err.Error())
+        respondBindingError(c, err)
+        return
+    }
+    
+    if err := h.subjects.Create(&subject); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            h.respondDuplicateName(c, subject.Name)
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "subject.create_error")
         return
     }
     
-    if err := config.GetDB().Create(&subject).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al crear la materia")
+    utils.RespondWithSuccess(c, http.StatusCreated, "subject.created", subject)
+}
+
+// GetAllSubjects godoc
+// @Summary      Listar materias
+// @Description  Obtiene una página de materias con búsqueda por nombre y, opcionalmente, conteos de estudiantes y calificaciones. Las materias eliminadas solo se incluyen con include_deleted (administradores).
+// @Tags         subjects
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page             query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit            query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort             query     string  false  "Campo de orden"  Enums(subject_id, name)
+// @Param        order            query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        q                query     string  false  "Buscar texto en el nombre"
+// @Param        include_counts   query     bool    false  "Incluir conteos de estudiantes inscritos y calificaciones"
+// @Param        include_deleted  query     bool    false  "Incluir materias eliminadas (solo administradores)"  default(false)
+// @Success      200              {object}  utils.PaginatedResponse{data=[]models.SubjectListItem}
+// @Failure      400              {object}  utils.ErrorResponse
+// @Failure      403              {object}  utils.ErrorResponse
+// @Failure      500              {object}  utils.ErrorResponse
+// @Router       /subjects [get]
+func (h *SubjectHandler) GetAllSubjects(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.SubjectSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
         return
     }
     
-    utils.RespondWithSuccess(c, http.StatusCreated, "Materia creada exitosamente", subject)
+    includeCounts, err := strconv.ParseBool(c.DefaultQuery("include_counts", "false"))
+    if err != nil {
+        respondInvalidParameter(c, i18n.NewMessage("param.not_boolean", "include_counts"))
+        return
+    }
+    
+    filter := repositories.SubjectFilter{
+        Query: strings.TrimSpace(c.Query("q")),
+    }
+    var ok bool
+    if filter.IncludeDeleted, ok = parseIncludeDeleted(c); !ok {
+        return
+    }
+    
+    subjects, total, err := h.subjects.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "subject.list_error")
+        return
+    }
+    
+    items := make([]models.SubjectListItem, len(subjects))
+    ids := make([]int, len(subjects))
+    for i, subject := range subjects {
+        items[i] = models.SubjectListItem{
+            SubjectID: subject.SubjectID,
+            Name:      subject.Name,
+            Credits:   subject.Credits,
+        }
+        if subject.DeletedAt.Valid {
+            deletedAt := subject.DeletedAt.Time
+            items[i].DeletedAt = &deletedAt
+        }
+        ids[i] = subject.SubjectID
+    }
+    
+    if includeCounts {
+        counts, err := h.subjects.Counts(ids)
+        if err != nil {
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "subject.list_error")
+            return
+        }
+        for i := range items {
+            subjectCounts := counts[items[i].SubjectID]
+            items[i].Counts = &subjectCounts
+        }
+    }
+    
+    utils.RespondWithPage(c, "subject.list_retrieved", items, opts.Page, opts.Limit, total)
 }
 
 // GetSubject godoc
@@ -42,25 +136,26 @@ func CreateSubject(c *gin.Context) {
 // @Description  Obtiene la información de una materia específica
 // @Tags         subjects
 // @Produce      json
+// @Security     BearerAuth
 // @Param        subject_id  path      int  true  "ID de la materia"
-// @Success      200         {object}  models.Subject
+// @Success      200         {object}  utils.SuccessResponse{data=models.Subject}
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
 // @Router       /subjects/{subject_id} [get]
-func GetSubject(c *gin.Context) {
+func (h *SubjectHandler) GetSubject(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("subject_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var subject models.Subject
-    if err := config.GetDB().First(&subject, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Materia no encontrada")
+    subject, err := h.subjects.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
         return
     }
     
-    c.JSON(http.StatusOK, subject)
+    utils.RespondWithSuccess(c, http.StatusOK, "subject.retrieved", subject)
 }
 
 // UpdateSubject godoc
@@ -69,70 +164,114 @@ func GetSubject(c *gin.Context) {
 // @Tags         subjects
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        subject_id  path      int             true  "ID de la materia"
 // @Param        subject     body      models.Subject  true  "Información actualizada de la materia"
 // @Success      200         {object}  utils.SuccessResponse{data=models.Subject}
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse{details=models.SubjectConflictDetails}
 // @Failure      500         {object}  utils.ErrorResponse
 // @Router       /subjects/{subject_id} [put]
-func UpdateSubject(c *gin.Context) {
+func (h *SubjectHandler) UpdateSubject(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("subject_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var subject models.Subject
-    if err := config.GetDB().First(&subject, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Materia no encontrada")
+    subject, err := h.subjects.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
         return
     }
     
     var updatedData models.Subject
     if err := c.ShouldBindJSON(&updatedData); err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "Datos inválidos: "+err.Error())
+        respondBindingError(c, err)
         return
     }
     
     subject.Name = updatedData.Name
+    subject.Credits = updatedData.Credits
     
-    if err := config.GetDB().Save(&subject).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al actualizar materia")
+    if err := h.subjects.Update(subject); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            h.respondDuplicateName(c, subject.Name)
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "subject.update_error")
         return
     }
     
-    utils.RespondWithSuccess(c, http.StatusOK, "Materia actualizada exitosamente", subject)
+    utils.RespondWithSuccess(c, http.StatusOK, "subject.updated", subject)
 }
 
 // DeleteSubject godoc
 // @Summary      Eliminar una materia
-// @Description  Elimina una materia del sistema (también elimina sus calificaciones por CASCADE)
+// @Description  Marca una materia como eliminada. Sus calificaciones se conservan, pero dejan de aparecer en listados, estadísticas y exportaciones hasta que se restaure.
 // @Tags         subjects
 // @Produce      json
+// @Security     BearerAuth
 // @Param        subject_id  path      int  true  "ID de la materia"
 // @Success      200         {object}  utils.SuccessResponse
 // @Failure      400         {object}  utils.ErrorResponse
 // @Failure      404         {object}  utils.ErrorResponse
 // @Failure      500         {object}  utils.ErrorResponse
 // @Router       /subjects/{subject_id} [delete]
-func DeleteSubject(c *gin.Context) {
+func (h *SubjectHandler) DeleteSubject(c *gin.Context) {
     id, err := strconv.Atoi(c.Param("subject_id"))
     if err != nil {
-        utils.RespondWithError(c, http.StatusBadRequest, "ID inválido")
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    var subject models.Subject
-    if err := config.GetDB().First(&subject, id).Error; err != nil {
-        utils.RespondWithError(c, http.StatusNotFound, "Materia no encontrada")
+    if err := h.subjects.Delete(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeSubjectNotFound, "subject.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "subject.delete_error")
         return
     }
     
-    if err := config.GetDB().Delete(&subject).Error; err != nil {
-        utils.RespondWithError(c, http.StatusInternalServerError, "Error al eliminar materia")
+    utils.RespondWithSuccess(c, http.StatusOK, "subject.deleted", nil)
+}
+
+// RestoreSubject godoc
+// @Summary      Restaurar una materia eliminada
+// @Description  Quita la marca de eliminada de una materia; sus calificaciones vuelven a estar visibles
+// @Tags         subjects
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subject_id  path      int  true  "ID de la materia"
+// @Success      200         {object}  utils.SuccessResponse{data=models.Subject}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /subjects/{subject_id}/restore [post]
+func (h *SubjectHandler) RestoreSubject(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("subject_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
         return
     }
     
-    utils.RespondWithSuccess(c, http.StatusOK, "Materia eliminada exitosamente", nil)
-}
\ No newline at end of file
+    subject, err := h.subjects.Restore(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeSubjectNotFound, "subject.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "subject.restored", subject)
+}
+
+// respondDuplicateName responde 409 cuando el nombre ya está registrado. Si lo conserva una
+// materia eliminada, el código DELETED_SUBJECT_NAME y su ID indican que hay que restaurarla.
+func (h *SubjectHandler) respondDuplicateName(c *gin.Context, name string) {
+    if deleted, err := h.subjects.FindDeletedByName(name); err == nil {
+        utils.RespondWithErrorDetails(c, http.StatusConflict, utils.CodeDeletedSubjectName, "subject.deleted_name", models.SubjectConflictDetails{SubjectID: deleted.SubjectID})
+        return
+    }
+    utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateSubjectName, "subject.duplicate_name")
+}
@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+    "encoding/json"
+    "net/http"
+    "testing"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/routes"
+    "ControlEscolar/utils"
+)
+
+// newSubjectTestRouter registra las rutas de la API con las materias en memoria; devuelve también
+// el token de un administrador
+func newSubjectTestRouter(t *testing.T, subjects *repositories.MemorySubjectRepository) (*gin.Engine, string) {
+    t.Helper()
+    db := newTestDB(t)
+    repos := routes.NewGormRepositories(db)
+    repos.Subjects = subjects
+    return newTestRouterWith(repos), newAccount(t, db, "admin", models.RoleAdmin)
+}
+
+func TestCreateSubjectWithDeletedNamePointsToRestore(t *testing.T) {
+    subjects := repositories.NewMemorySubjectRepository(nil)
+    deleted := &models.Subject{Name: "Historia"}
+    if err := subjects.Create(deleted); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    if err := subjects.Delete(deleted.SubjectID); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    router, admin := newSubjectTestRouter(t, subjects)
+    
+    response := assertError(t, performRequest(router, http.MethodPost, "/api/subjects", admin, gin.H{"name": "Historia"}), http.StatusConflict, utils.CodeDeletedSubjectName)
+    
+    details, _ := response.Details.(map[string]interface{})
+    if details["subject_id"] != float64(deleted.SubjectID) {
+        t.Errorf("details = %v, se esperaba subject_id %d", response.Details, deleted.SubjectID)
+    }
+}
+
+func TestGetAllSubjectsCountsEnrolledStudents(t *testing.T) {
+    grades := repositories.NewMemoryGradeRepository()
+    subjects := repositories.NewMemorySubjectRepository(grades)
+    subject := &models.Subject{Name: "Matemáticas"}
+    if err := subjects.Create(subject); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    
+    // Dos estudiantes inscritos, uno en dos periodos, y una sola calificación capturada
+    for _, enrollment := range []models.Enrollment{
+        {StudentID: 1, SubjectID: subject.SubjectID, TermID: 1},
+        {StudentID: 1, SubjectID: subject.SubjectID, TermID: 2},
+        {StudentID: 2, SubjectID: subject.SubjectID, TermID: 1},
+    } {
+        subjects.Enroll(enrollment)
+    }
+    termID := 1
+    if err := grades.Create(&models.Grade{StudentID: 1, SubjectID: subject.SubjectID, TermID: &termID, Grade: 80}, models.GradeChange{}); err != nil {
+        t.Fatalf("crear calificación: %v", err)
+    }
+    router, admin := newSubjectTestRouter(t, subjects)
+    
+    recorder := performRequest(router, http.MethodGet, "/api/subjects?include_counts=true", admin, nil)
+    assertStatus(t, recorder, http.StatusOK)
+    
+    var page struct {
+        Data []models.SubjectListItem `json:"data"`
+    }
+    if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+        t.Fatalf("respuesta no es JSON: %v", err)
+    }
+    if len(page.Data) != 1 || page.Data[0].Counts == nil {
+        t.Fatalf("se esperaba una materia con conteos, se obtuvo %s", recorder.Body.String())
+    }
+    want := models.SubjectCounts{StudentCount: 2, GradeCount: 1}
+    if got := *page.Data[0].Counts; got != want {
+        t.Errorf("counts = %+v, se esperaba %+v", got, want)
+    }
+}
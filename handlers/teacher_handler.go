@@ -0,0 +1,233 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// TeacherHandler agrupa los endpoints de maestros
+type TeacherHandler struct {
+    teachers repositories.TeacherRepository
+    users    repositories.UserRepository
+}
+
+// NewTeacherHandler crea un TeacherHandler con los repositorios indicados
+func NewTeacherHandler(teachers repositories.TeacherRepository, users repositories.UserRepository) *TeacherHandler {
+    return &TeacherHandler{
+        teachers: teachers,
+        users:    users,
+    }
+}
+
+// CreateTeacher godoc
+// @Summary      Crear un maestro
+// @Description  Registra un maestro. Para que pueda capturar calificaciones debe vincularse con user_id a una cuenta con rol teacher.
+// @Tags         teachers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        teacher  body      models.TeacherRequest  true  "Información del maestro"
+// @Success      201      {object}  utils.SuccessResponse{data=models.Teacher}
+// @Failure      400      {object}  utils.ErrorResponse
+// @Failure      409      {object}  utils.ErrorResponse
+// @Failure      500      {object}  utils.ErrorResponse
+// @Router       /teachers [post]
+func (h *TeacherHandler) CreateTeacher(c *gin.Context) {
+    var request models.TeacherRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    var teacher models.Teacher
+    if err := h.applyTeacherRequest(&teacher, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.teachers.Create(&teacher); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateTeacher, "teacher.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "teacher.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "teacher.created", teacher)
+}
+
+// GetAllTeachers godoc
+// @Summary      Listar maestros
+// @Description  Obtiene una página de maestros, con búsqueda por nombre o email
+// @Tags         teachers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit  query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort   query     string  false  "Campo de orden"  Enums(teacher_id, name, email)
+// @Param        order  query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        q      query     string  false  "Buscar texto en nombre o email"
+// @Success      200    {object}  utils.PaginatedResponse{data=[]models.Teacher}
+// @Failure      400    {object}  utils.ErrorResponse
+// @Failure      500    {object}  utils.ErrorResponse
+// @Router       /teachers [get]
+func (h *TeacherHandler) GetAllTeachers(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.TeacherSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    filter := repositories.TeacherFilter{
+        Query: strings.TrimSpace(c.Query("q")),
+    }
+    
+    teachers, total, err := h.teachers.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "teacher.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "teacher.list_retrieved", teachers, opts.Page, opts.Limit, total)
+}
+
+// GetTeacher godoc
+// @Summary      Obtener un maestro por ID
+// @Description  Obtiene la información de un maestro específico
+// @Tags         teachers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        teacher_id  path      int  true  "ID del maestro"
+// @Success      200         {object}  utils.SuccessResponse{data=models.Teacher}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Router       /teachers/{teacher_id} [get]
+func (h *TeacherHandler) GetTeacher(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("teacher_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    teacher, err := h.teachers.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTeacherNotFound, "teacher.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "teacher.retrieved", teacher)
+}
+
+// UpdateTeacher godoc
+// @Summary      Actualizar un maestro
+// @Description  Actualiza la información de un maestro existente
+// @Tags         teachers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        teacher_id  path      int                    true  "ID del maestro"
+// @Param        teacher     body      models.TeacherRequest  true  "Información actualizada del maestro"
+// @Success      200         {object}  utils.SuccessResponse{data=models.Teacher}
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      409         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /teachers/{teacher_id} [put]
+func (h *TeacherHandler) UpdateTeacher(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("teacher_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    teacher, err := h.teachers.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTeacherNotFound, "teacher.not_found")
+        return
+    }
+    
+    var request models.TeacherRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if err := h.applyTeacherRequest(teacher, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.teachers.Update(teacher); err != nil {
+        if errors.Is(err, repositories.ErrDuplicate) {
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeDuplicateTeacher, "teacher.duplicate")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "teacher.update_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "teacher.updated", teacher)
+}
+
+// DeleteTeacher godoc
+// @Summary      Eliminar un maestro
+// @Description  Elimina un maestro junto con sus asignaciones; los grupos de los que era titular quedan sin titular
+// @Tags         teachers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        teacher_id  path      int  true  "ID del maestro"
+// @Success      200         {object}  utils.SuccessResponse
+// @Failure      400         {object}  utils.ErrorResponse
+// @Failure      404         {object}  utils.ErrorResponse
+// @Failure      500         {object}  utils.ErrorResponse
+// @Router       /teachers/{teacher_id} [delete]
+func (h *TeacherHandler) DeleteTeacher(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("teacher_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.teachers.Delete(id); err != nil {
+        if errors.Is(err, repositories.ErrNotFound) {
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeTeacherNotFound, "teacher.not_found")
+            return
+        }
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "teacher.delete_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "teacher.deleted", nil)
+}
+
+// applyTeacherRequest valida la petición y copia sus datos al maestro.
+// La cuenta vinculada, si se indica, debe tener rol teacher.
+func (h *TeacherHandler) applyTeacherRequest(teacher *models.Teacher, request models.TeacherRequest) error {
+    if request.UserID != nil {
+        user, err := h.users.FindByID(*request.UserID)
+        if err != nil {
+            return i18n.NewMessage("teacher.user_not_found")
+        }
+        if user.Role != models.RoleTeacher {
+            return i18n.NewMessage("teacher.user_not_teacher", models.RoleTeacher)
+        }
+    }
+    
+    teacher.Name = strings.TrimSpace(request.Name)
+    teacher.Email = nil
+    if email := strings.TrimSpace(request.Email); email != "" {
+        teacher.Email = &email
+    }
+    teacher.UserID = request.UserID
+    return nil
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+    
+    "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
+    "ControlEscolar/models"
+    "ControlEscolar/repositories"
+    "ControlEscolar/utils"
+)
+
+// TermHandler agrupa los endpoints de periodos académicos
+type TermHandler struct {
+    terms repositories.TermRepository
+}
+
+// NewTermHandler crea un TermHandler con el repositorio indicado
+func NewTermHandler(terms repositories.TermRepository) *TermHandler {
+    return &TermHandler{terms: terms}
+}
+
+// CreateTerm godoc
+// @Summary      Crear un periodo académico
+// @Description  Registra un ciclo escolar, un semestre (dentro de un ciclo) o un parcial (dentro de un semestre)
+// @Tags         terms
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        term  body      models.TermRequest  true  "Información del periodo"
+// @Success      201   {object}  utils.SuccessResponse{data=models.Term}
+// @Failure      400   {object}  utils.ErrorResponse
+// @Failure      500   {object}  utils.ErrorResponse
+// @Router       /terms [post]
+func (h *TermHandler) CreateTerm(c *gin.Context) {
+    var request models.TermRequest
+    
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    var term models.Term
+    if err := h.applyTermRequest(&term, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.terms.Create(&term); err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.create_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusCreated, "term.created", term)
+}
+
+// GetAllTerms godoc
+// @Summary      Listar periodos académicos
+// @Description  Obtiene una página de periodos, filtrable por ciclo escolar, tipo o periodo padre
+// @Tags         terms
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page         query     int     false  "Número de página (desde 1)"  default(1)
+// @Param        limit        query     int     false  "Registros por página (máximo 100)"  default(20)
+// @Param        sort         query     string  false  "Campo de orden"  Enums(term_id, name, school_year, start_date)
+// @Param        order        query     string  false  "Dirección del orden"  Enums(asc, desc)
+// @Param        school_year  query     string  false  "Filtrar por ciclo escolar"  example(2025-2026)
+// @Param        kind         query     string  false  "Filtrar por tipo"  Enums(school_year, semester, partial)
+// @Param        parent_id    query     int     false  "Filtrar por periodo padre"
+// @Success      200          {object}  utils.PaginatedResponse{data=[]models.Term}
+// @Failure      400          {object}  utils.ErrorResponse
+// @Failure      500          {object}  utils.ErrorResponse
+// @Router       /terms [get]
+func (h *TermHandler) GetAllTerms(c *gin.Context) {
+    opts, err := parseListOptions(c, repositories.TermSortFields)
+    if err != nil {
+        respondInvalidParameter(c, err)
+        return
+    }
+    
+    filter := repositories.TermFilter{
+        SchoolYear: strings.TrimSpace(c.Query("school_year")),
+        Kind:       strings.TrimSpace(c.Query("kind")),
+    }
+    if value := c.Query("parent_id"); value != "" {
+        parentID, err := strconv.Atoi(value)
+        if err != nil {
+            respondInvalidParameter(c, i18n.NewMessage("param.not_integer", "parent_id"))
+            return
+        }
+        filter.ParentID = &parentID
+    }
+    
+    terms, total, err := h.terms.List(filter, opts)
+    if err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.list_error")
+        return
+    }
+    
+    utils.RespondWithPage(c, "term.list_retrieved", terms, opts.Page, opts.Limit, total)
+}
+
+// GetTerm godoc
+// @Summary      Obtener un periodo académico por ID
+// @Description  Obtiene la información de un periodo específico
+// @Tags         terms
+// @Produce      json
+// @Security     BearerAuth
+// @Param        term_id  path      int  true  "ID del periodo"
+// @Success      200      {object}  utils.SuccessResponse{data=models.Term}
+// @Failure      400      {object}  utils.ErrorResponse
+// @Failure      404      {object}  utils.ErrorResponse
+// @Router       /terms/{term_id} [get]
+func (h *TermHandler) GetTerm(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("term_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    term, err := h.terms.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "term.retrieved", term)
+}
+
+// UpdateTerm godoc
+// @Summary      Actualizar un periodo académico
+// @Description  Actualiza la información de un periodo existente
+// @Tags         terms
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        term_id  path      int                 true  "ID del periodo"
+// @Param        term     body      models.TermRequest  true  "Información actualizada del periodo"
+// @Success      200      {object}  utils.SuccessResponse{data=models.Term}
+// @Failure      400      {object}  utils.ErrorResponse
+// @Failure      404      {object}  utils.ErrorResponse
+// @Failure      500      {object}  utils.ErrorResponse
+// @Router       /terms/{term_id} [put]
+func (h *TermHandler) UpdateTerm(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("term_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    term, err := h.terms.FindByID(id)
+    if err != nil {
+        respondLookupError(c, err, utils.CodeTermNotFound, "term.not_found")
+        return
+    }
+    
+    var request models.TermRequest
+    if err := c.ShouldBindJSON(&request); err != nil {
+        respondBindingError(c, err)
+        return
+    }
+    
+    if request.ParentID != nil && *request.ParentID == term.TermID {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "term.contains_itself")
+        return
+    }
+    
+    if err := h.applyTermRequest(term, request); err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeValidationFailed, "request.invalid_data_detail", err)
+        return
+    }
+    
+    if err := h.terms.Update(term); err != nil {
+        utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.update_error")
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "term.updated", term)
+}
+
+// DeleteTerm godoc
+// @Summary      Eliminar un periodo académico
+// @Description  Elimina un periodo que no tenga subperiodos ni calificaciones registradas
+// @Tags         terms
+// @Produce      json
+// @Security     BearerAuth
+// @Param        term_id  path      int  true  "ID del periodo"
+// @Success      200      {object}  utils.SuccessResponse
+// @Failure      400      {object}  utils.ErrorResponse
+// @Failure      404      {object}  utils.ErrorResponse
+// @Failure      409      {object}  utils.ErrorResponse
+// @Failure      500      {object}  utils.ErrorResponse
+// @Router       /terms/{term_id} [delete]
+func (h *TermHandler) DeleteTerm(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("term_id"))
+    if err != nil {
+        utils.RespondWithError(c, http.StatusBadRequest, utils.CodeInvalidID, "request.invalid_id")
+        return
+    }
+    
+    if err := h.terms.Delete(id); err != nil {
+        switch {
+        case errors.Is(err, repositories.ErrNotFound):
+            utils.RespondWithError(c, http.StatusNotFound, utils.CodeTermNotFound, "term.not_found")
+        case errors.Is(err, repositories.ErrInUse):
+            utils.RespondWithError(c, http.StatusConflict, utils.CodeTermInUse, "term.in_use")
+        default:
+            utils.RespondWithError(c, http.StatusInternalServerError, utils.CodeInternalError, "term.delete_error")
+        }
+        return
+    }
+    
+    utils.RespondWithSuccess(c, http.StatusOK, "term.deleted", nil)
+}
+
+// applyTermRequest valida la petición y copia sus datos al periodo.
+// Un semestre debe pertenecer a un ciclo escolar y un parcial a un semestre, del mismo ciclo.
+func (h *TermHandler) applyTermRequest(term *models.Term, request models.TermRequest) error {
+    startDate, err := time.Parse("2006-01-02", request.StartDate)
+    if err != nil {
+        return i18n.NewMessage("term.invalid_start_date")
+    }
+    endDate, err := time.Parse("2006-01-02", request.EndDate)
+    if err != nil {
+        return i18n.NewMessage("term.invalid_end_date")
+    }
+    if endDate.Before(startDate) {
+        return i18n.NewMessage("term.end_before_start")
+    }
+    
+    parentKind, needsParent := models.TermParentKind[request.Kind]
+    if !needsParent && request.ParentID != nil {
+        return i18n.NewMessage("term.school_year_parent")
+    }
+    if needsParent {
+        if request.ParentID == nil {
+            return i18n.NewMessage("term.parent_required", request.Kind)
+        }
+        parent, err := h.terms.FindByID(*request.ParentID)
+        if err != nil {
+            return i18n.NewMessage("term.parent_not_found")
+        }
+        if parent.Kind != parentKind {
+            return i18n.NewMessage("term.parent_kind", request.Kind, parentKind)
+        }
+        if parent.SchoolYear != request.SchoolYear {
+            return i18n.NewMessage("term.parent_other_year")
+        }
+    }
+    
+    term.Name = strings.TrimSpace(request.Name)
+    term.SchoolYear = strings.TrimSpace(request.SchoolYear)
+    term.Kind = request.Kind
+    term.ParentID = request.ParentID
+    term.StartDate = startDate
+    term.EndDate = endDate
+    return nil
+}
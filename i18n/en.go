@@ -0,0 +1,341 @@
+package i18n
+
+// english contiene los mensajes en inglés
+var english = map[string]string{
+    // Peticiones
+    "request.invalid_data":        "Invalid data",
+    "request.invalid_data_detail": "Invalid data: %s",
+    "request.invalid_parameters":  "Invalid parameters: %s",
+    "request.malformed_body":      "The request body is not valid JSON",
+    "request.invalid_id":          "Invalid ID",
+    "request.route_not_found":     "The route %s %s does not exist",
+    "request.deleted_admin_only":  "Only administrators can view deleted records",
+    "request.database_error":      "Error querying the database",
+    "request.internal_error":      "Internal server error",
+    
+    // Parámetros de la query string
+    "param.not_integer": "%s must be an integer",
+    "param.not_boolean": "%s must be true or false",
+    "param.not_date":    "%s must use the YYYY-MM-DD format",
+    "param.date_range":  "from cannot be later than to",
+    "param.page":        "page must be an integer greater than or equal to 1",
+    "param.limit":       "limit must be an integer between 1 and %d",
+    "param.sort":        "sort must be one of: %s",
+    "param.order":       "order must be asc or desc",
+    "param.format":      "format must be csv, xlsx or ndjson",
+    
+    // Validación
+    "validation.type":        "%s must be of type %s",
+    "validation.datetime":    "%s must have the format %s",
+    "validation.date_layout": "YYYY-MM-DD",
+    
+    // Autenticación
+    "auth.token_required":      "An authentication token is required",
+    "auth.invalid_token":       "Invalid or expired token",
+    "auth.forbidden":           "You do not have permission to perform this action",
+    "auth.own_data_only":       "You can only view your own information",
+    "auth.invalid_credentials": "Incorrect username or password",
+    "auth.logged_in":           "Signed in successfully",
+    "auth.login_error":         "Error signing in",
+    "auth.token_error":         "Error generating the token",
+    
+    // Usuarios
+    "user.not_found":          "User not found",
+    "user.retrieved":          "User retrieved successfully",
+    "user.list_retrieved":     "Users retrieved successfully",
+    "user.created":            "User created successfully",
+    "user.list_error":         "Error retrieving users",
+    "user.create_error":       "Error creating the user",
+    "user.duplicate_username": "The username is already registered",
+    "user.student_required":   "Student accounts require student_id",
+    
+    // Estudiantes
+    "student.invalid_id":      "Invalid student ID",
+    "student.not_found":       "Student not found",
+    "student.retrieved":       "Student retrieved successfully",
+    "student.list_retrieved":  "Students retrieved successfully",
+    "student.created":         "Student created successfully",
+    "student.updated":         "Student updated successfully",
+    "student.deleted":         "Student deleted successfully",
+    "student.restored":        "Student restored successfully",
+    "student.list_error":      "Error retrieving students",
+    "student.create_error":    "Error creating the student",
+    "student.update_error":    "Error updating the student",
+    "student.delete_error":    "Error deleting the student",
+    "student.duplicate_email": "A student with that email already exists",
+    "student.deleted_email":   "A deleted student uses that email; restore it instead of registering it again",
+    "student.group_not_found": "Invalid data: the group does not exist",
+    
+    // Importación de estudiantes
+    "import.file_required":         "Invalid data: the file is required in the file field",
+    "import.file_too_large":        "The file exceeds the maximum size of 5 MB",
+    "import.read_error":            "Error reading the file",
+    "import.validated":             "File validated; no student was saved",
+    "import.has_errors":            "The file has errors; no student was imported",
+    "import.email_taken_meanwhile": "Another student registered one of the emails during the import; no student was imported",
+    "import.error":                 "Error importing students",
+    "import.imported":              "Students imported successfully",
+    "import.empty_file":            "the file is empty",
+    "import.missing_column":        "the header is missing the %s column",
+    "import.too_many_rows":         "the file exceeds the maximum of %d rows",
+    "import.repeated_email":        "email repeated in row %d",
+    "import.group_not_found":       "the group does not exist",
+    "import.email_taken":           "a student with that email already exists",
+    
+    // Archivos de hoja de cálculo
+    "spreadsheet.unsupported_format": "unsupported format; use a .csv or .xlsx file",
+    "spreadsheet.invalid_csv":        "invalid CSV: %v",
+    "spreadsheet.invalid_xlsx":       "invalid XLSX: %v",
+    "spreadsheet.no_sheets":          "invalid XLSX: the workbook has no sheets",
+    
+    // Grupos
+    "group.invalid_id":         "Invalid group ID",
+    "group.not_found":          "Group not found",
+    "group.retrieved":          "Group retrieved successfully",
+    "group.list_retrieved":     "Groups retrieved successfully",
+    "group.created":            "Group created successfully",
+    "group.updated":            "Group updated successfully",
+    "group.deleted":            "Group deleted successfully",
+    "group.list_error":         "Error retrieving groups",
+    "group.create_error":       "Error creating the group",
+    "group.update_error":       "Error updating the group",
+    "group.delete_error":       "Error deleting the group",
+    "group.duplicate":          "A group with that grade, section and shift already exists in the school year",
+    "group.in_use":             "The group has students assigned",
+    "group.homeroom_not_found": "the homeroom teacher does not exist",
+    
+    // Maestros
+    "teacher.not_found":        "Teacher not found",
+    "teacher.retrieved":        "Teacher retrieved successfully",
+    "teacher.list_retrieved":   "Teachers retrieved successfully",
+    "teacher.created":          "Teacher created successfully",
+    "teacher.updated":          "Teacher updated successfully",
+    "teacher.deleted":          "Teacher deleted successfully",
+    "teacher.list_error":       "Error retrieving teachers",
+    "teacher.create_error":     "Error creating the teacher",
+    "teacher.update_error":     "Error updating the teacher",
+    "teacher.delete_error":     "Error deleting the teacher",
+    "teacher.duplicate":        "A teacher with that email or linked to that account already exists",
+    "teacher.not_linked":       "The account is not linked to a teacher",
+    "teacher.not_assigned":     "Only the teacher assigned to the subject and group can record its grades",
+    "teacher.user_not_found":   "the user account does not exist",
+    "teacher.user_not_teacher": "the linked account must have the %s role",
+    
+    // Tutores
+    "guardian.not_found":       "Guardian not found",
+    "guardian.retrieved":       "Guardian retrieved successfully",
+    "guardian.created":         "Guardian created successfully",
+    "guardian.updated":         "Guardian updated successfully",
+    "guardian.deleted":         "Guardian deleted successfully",
+    "guardian.list_retrieved":  "Guardians retrieved successfully",
+    "guardian.list_error":      "Error retrieving guardians",
+    "guardian.create_error":    "Error creating the guardian",
+    "guardian.update_error":    "Error updating the guardian",
+    "guardian.delete_error":    "Error deleting the guardian",
+    "guardian.query_error":     "Error querying the guardian's students",
+    "guardian.duplicate":       "A guardian with that email or linked to that account already exists",
+    "guardian.linked":          "Student linked to the guardian successfully",
+    "guardian.unlinked":        "Student unlinked from the guardian successfully",
+    "guardian.duplicate_link":  "The student is already linked to that guardian",
+    "guardian.link_not_found":  "The student is not linked to that guardian",
+    "guardian.link_error":      "Error linking the student",
+    "guardian.unlink_error":    "Error unlinking the student",
+    "guardian.not_linked":      "The account is not linked to a guardian",
+    "guardian.not_own_student": "You can only access the students linked to your account",
+    "guardian.user_not_found":  "the user account does not exist",
+    "guardian.user_not_parent": "the linked account must have the %s role",
+    
+    // Asignaciones
+    "assignment.not_found":      "Assignment not found",
+    "assignment.list_retrieved": "Assignments retrieved successfully",
+    "assignment.created":        "Assignment created successfully",
+    "assignment.deleted":        "Assignment deleted successfully",
+    "assignment.list_error":     "Error retrieving assignments",
+    "assignment.create_error":   "Error creating the assignment",
+    "assignment.delete_error":   "Error deleting the assignment",
+    "assignment.query_error":    "Error querying assignments",
+    "assignment.duplicate":      "The subject already has a teacher assigned in that group and term",
+    
+    // Inscripciones
+    "enrollment.not_found":            "Enrollment not found",
+    "enrollment.list_retrieved":       "Enrollments retrieved successfully",
+    "enrollment.created":              "Enrollment created successfully",
+    "enrollment.deleted":              "Enrollment deleted successfully",
+    "enrollment.group_enrolled":       "Group enrolled successfully",
+    "enrollment.group_unenrolled":     "Group unenrolled successfully",
+    "enrollment.list_error":           "Error retrieving enrollments",
+    "enrollment.create_error":         "Error creating the enrollment",
+    "enrollment.delete_error":         "Error deleting the enrollment",
+    "enrollment.group_enroll_error":   "Error enrolling the group",
+    "enrollment.group_unenroll_error": "Error unenrolling the group",
+    "enrollment.query_error":          "Error querying enrollments",
+    "enrollment.duplicate":            "The student is already enrolled in the subject in that term",
+    "enrollment.has_grades":           "The student already has grades for the subject in that term",
+    "enrollment.not_enrolled":         "The student is not enrolled in the subject in that term",
+    
+    // Materias
+    "subject.invalid_id":     "Invalid subject ID",
+    "subject.not_found":      "Subject not found",
+    "subject.retrieved":      "Subject retrieved successfully",
+    "subject.list_retrieved": "Subjects retrieved successfully",
+    "subject.created":        "Subject created successfully",
+    "subject.updated":        "Subject updated successfully",
+    "subject.deleted":        "Subject deleted successfully",
+    "subject.restored":       "Subject restored successfully",
+    "subject.list_error":     "Error retrieving subjects",
+    "subject.create_error":   "Error creating the subject",
+    "subject.update_error":   "Error updating the subject",
+    "subject.delete_error":   "Error deleting the subject",
+    "subject.duplicate_name": "A subject with that name already exists",
+    "subject.deleted_name":   "A deleted subject uses that name; restore it instead of registering it again",
+    
+    // Periodos
+    "term.invalid_id":          "Invalid term ID",
+    "term.not_found":           "Term not found",
+    "term.retrieved":           "Term retrieved successfully",
+    "term.list_retrieved":      "Terms retrieved successfully",
+    "term.created":             "Term created successfully",
+    "term.updated":             "Term updated successfully",
+    "term.deleted":             "Term deleted successfully",
+    "term.list_error":          "Error retrieving terms",
+    "term.create_error":        "Error creating the term",
+    "term.update_error":        "Error updating the term",
+    "term.delete_error":        "Error deleting the term",
+    "term.query_error":         "Error querying terms",
+    "term.in_use":              "The term has subterms or recorded grades",
+    "term.group_year_mismatch": "Invalid data: the term and the group belong to different school years",
+    "term.contains_itself":     "Invalid data: a term cannot contain itself",
+    "term.invalid_start_date":  "start_date must have the format YYYY-MM-DD",
+    "term.invalid_end_date":    "end_date must have the format YYYY-MM-DD",
+    "term.end_before_start":    "end_date cannot be earlier than start_date",
+    "term.school_year_parent":  "a school year cannot have a parent term",
+    "term.parent_required":     "parent_id is required for the %s kind",
+    "term.parent_not_found":    "the parent term does not exist",
+    "term.parent_kind":         "the parent term of a %s must be of kind %s",
+    "term.parent_other_year":   "the parent term belongs to another school year",
+    
+    // Calificaciones
+    "grade.invalid_id":             "Invalid grade ID",
+    "grade.not_found":              "Grade not found",
+    "grade.retrieved":              "Grade retrieved successfully",
+    "grade.list_retrieved":         "Grades retrieved successfully",
+    "grade.created":                "Grade created successfully",
+    "grade.updated":                "Grade updated successfully",
+    "grade.deleted":                "Grade deleted successfully",
+    "grade.restored":               "Grade restored successfully",
+    "grade.saved":                  "Grades saved successfully",
+    "grade.history_retrieved":      "History retrieved successfully",
+    "grade.list_error":             "Error retrieving grades",
+    "grade.create_error":           "Error creating the grade",
+    "grade.update_error":           "Error updating the grade",
+    "grade.delete_error":           "Error deleting the grade",
+    "grade.save_error":             "Error saving the grade",
+    "grade.batch_save_error":       "Error saving the grades",
+    "grade.history_error":          "Error retrieving the history",
+    "grade.duplicate":              "The student already has a grade in this subject and term",
+    "grade.duplicate_deleted":      "The student has a deleted grade in this subject and term; restore it or record it with PUT",
+    "grade.parent_deleted":         "Restore the student and the subject of the grade first",
+    "grade.locked":                 "The grades of the subject in this group and term are locked",
+    "grade.computed":               "The grade for this subject and term is computed from its evaluation criteria; record the criterion scores instead",
+    "grade.out_of_scale":           "The grade must be between %g and %g on the subject's grading scale",
+    "grade.reason_too_long":        "The reason cannot exceed 255 characters",
+    "grade.batch_rejected":         "Some grades were rejected; none were saved",
+    "grade.batch_repeated_student": "The student already appears at position %d",
+    
+    // Cierres de calificaciones
+    "grade_lock.not_found":        "Grade lock not found",
+    "grade_lock.list_retrieved":   "Grade locks retrieved successfully",
+    "grade_lock.events_retrieved": "Grade lock log retrieved successfully",
+    "grade_lock.closed":           "Grades locked successfully",
+    "grade_lock.reopened":         "Grades reopened successfully",
+    "grade_lock.already_locked":   "The grades of the subject in this group and term are already locked",
+    "grade_lock.not_assigned":     "Only the teacher assigned to the subject and group can lock its grades",
+    "grade_lock.close_error":      "Error locking the grades",
+    "grade_lock.list_error":       "Error retrieving the grade locks",
+    "grade_lock.events_error":     "Error retrieving the grade lock log",
+    "grade_lock.query_error":      "Error querying grade locks",
+    
+    // Asistencia
+    "attendance.not_found":         "Attendance record not found",
+    "attendance.list_retrieved":    "Attendance records retrieved successfully",
+    "attendance.recorded":          "Roll call recorded successfully",
+    "attendance.updated":           "Attendance record updated successfully",
+    "attendance.deleted":           "Attendance record deleted successfully",
+    "attendance.summary_retrieved": "Attendance summary retrieved successfully",
+    "attendance.future_date":       "Roll call cannot be taken for a future date",
+    "attendance.not_in_group":      "The student does not belong to the group",
+    "attendance.repeated_student":  "The student already appears at position %d",
+    "attendance.rejected":          "Some records were rejected; none were saved",
+    "attendance.concurrent":        "Another roll call recorded a student's attendance for this date at the same time; no records were saved, send the roll call again",
+    "attendance.not_assigned":      "Only the teacher assigned to the subject and group can take its roll call",
+    "attendance.not_homeroom":      "Only the group's homeroom teacher can take the daily roll call",
+    "attendance.invalid_status":    "status must be one of: %s",
+    "attendance.daily":             "Daily roll call",
+    "attendance.record_error":      "Error recording attendance",
+    "attendance.list_error":        "Error retrieving attendance records",
+    "attendance.update_error":      "Error updating the attendance record",
+    "attendance.delete_error":      "Error deleting the attendance record",
+    "attendance.summary_error":     "Error calculating the attendance summary",
+    
+    // Criterios de evaluación
+    "evaluation.criteria_retrieved":  "Evaluation criteria retrieved successfully",
+    "evaluation.criteria_saved":      "Evaluation criteria saved successfully",
+    "evaluation.criteria_deleted":    "Evaluation criteria deleted successfully",
+    "evaluation.criteria_not_found":  "The subject has no evaluation criteria in this term",
+    "evaluation.criterion_not_found": "Evaluation criterion not found",
+    "evaluation.weights_sum":         "The criteria weights must add up to 100; they add up to %g",
+    "evaluation.repeated_name":       "The criterion %s is repeated",
+    "evaluation.has_scores":          "A criterion that already has recorded scores cannot be removed",
+    "evaluation.manual_grades":       "The subject already has grades entered directly in this term; delete them before defining evaluation criteria",
+    "evaluation.not_assigned":        "Only a teacher assigned to the subject in the term can define its evaluation criteria",
+    "evaluation.scores_saved":        "Criterion scores saved successfully",
+    "evaluation.breakdown_retrieved": "Grade breakdown retrieved successfully",
+    "evaluation.query_error":         "Error querying the evaluation criteria",
+    "evaluation.save_error":          "Error saving the evaluation criteria",
+    "evaluation.delete_error":        "Error deleting the evaluation criteria",
+    "evaluation.scores_error":        "Error saving the criterion scores",
+    
+    // Escalas de calificación
+    "grading_scale.created":               "Grading scale created successfully",
+    "grading_scale.retrieved":             "Grading scale retrieved successfully",
+    "grading_scale.list_retrieved":        "Grading scales retrieved successfully",
+    "grading_scale.assignments_retrieved": "Grading scale assignments retrieved successfully",
+    "grading_scale.updated":               "Grading scale updated successfully",
+    "grading_scale.deleted":               "Grading scale deleted successfully",
+    "grading_scale.not_found":             "Grading scale not found",
+    "grading_scale.duplicate_name":        "A grading scale with that name already exists",
+    "grading_scale.in_use":                "An assigned grading scale cannot be deleted; remove its assignments first",
+    "grading_scale.range":                 "The scale maximum value must be greater than its minimum",
+    "grading_scale.passing_range":         "The passing value must be within the scale",
+    "grading_scale.letter_repeated":       "The letter %s is repeated",
+    "grading_scale.letter_range":          "The minimum value of the letter %s must be within the scale",
+    "grading_scale.letter_value_repeated": "Two letters start at the same value %g",
+    "grading_scale.letters_cover":         "The lowest letter must start at the scale minimum",
+    "grading_scale.assignment_target":     "Provide the grade level, the subject or both",
+    "grading_scale.assigned":              "Grading scale assigned successfully",
+    "grading_scale.unassigned":            "Grading scale assignment removed successfully",
+    "grading_scale.assignment_not_found":  "Grading scale assignment not found",
+    "grading_scale.duplicate_assignment":  "An identical grading scale assignment already exists",
+    "grading_scale.list_error":            "Error retrieving the grading scales",
+    "grading_scale.create_error":          "Error creating the grading scale",
+    "grading_scale.update_error":          "Error updating the grading scale",
+    "grading_scale.delete_error":          "Error deleting the grading scale",
+    "grading_scale.assign_error":          "Error assigning the grading scale",
+    "grading_scale.assignment_list_error": "Error retrieving the grading scale assignments",
+    "grading_scale.unassign_error":        "Error removing the grading scale assignment",
+    "grading_scale.query_error":           "Error querying the grading scale",
+    
+    // Estadísticas y boletas
+    "analytics.stats_retrieved":        "Statistics retrieved successfully",
+    "analytics.ranking_retrieved":      "Ranking retrieved successfully",
+    "analytics.distribution_retrieved": "Distribution retrieved successfully",
+    "analytics.stats_error":            "Error calculating the statistics",
+    "analytics.ranking_error":          "Error calculating the ranking",
+    "analytics.distribution_error":     "Error calculating the distribution",
+    "analytics.invalid_passing_grade":  "The passing grade must be a number between 0 and 100",
+    "analytics.invalid_bucket_size":    "bucket_size must be an integer between 1 and 50",
+    "report.error":                     "Error generating the report card",
+    
+    // Exportaciones
+    "export.not_acceptable": "Format not available; accept text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet or application/x-ndjson, or use the format parameter",
+}
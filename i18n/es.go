@@ -0,0 +1,342 @@
+package i18n
+
+// spanish contiene los mensajes en español, el idioma predeterminado. Cada mensaje de english
+// debe tener su llave aquí.
+var spanish = map[string]string{
+    // Peticiones
+    "request.invalid_data":        "Datos inválidos",
+    "request.invalid_data_detail": "Datos inválidos: %s",
+    "request.invalid_parameters":  "Parámetros inválidos: %s",
+    "request.malformed_body":      "El cuerpo de la petición no es un JSON válido",
+    "request.invalid_id":          "ID inválido",
+    "request.route_not_found":     "La ruta %s %s no existe",
+    "request.deleted_admin_only":  "Solo los administradores pueden consultar registros eliminados",
+    "request.database_error":      "Error al consultar la base de datos",
+    "request.internal_error":      "Error interno del servidor",
+    
+    // Parámetros de la query string
+    "param.not_integer": "%s debe ser un entero",
+    "param.not_boolean": "%s debe ser true o false",
+    "param.not_date":    "%s debe tener el formato AAAA-MM-DD",
+    "param.date_range":  "from no puede ser posterior a to",
+    "param.page":        "page debe ser un entero mayor o igual a 1",
+    "param.limit":       "limit debe ser un entero entre 1 y %d",
+    "param.sort":        "sort debe ser uno de: %s",
+    "param.order":       "order debe ser asc o desc",
+    "param.format":      "format debe ser csv, xlsx o ndjson",
+    
+    // Validación
+    "validation.type":        "%s debe ser de tipo %s",
+    "validation.datetime":    "%s debe tener el formato %s",
+    "validation.date_layout": "AAAA-MM-DD",
+    
+    // Autenticación
+    "auth.token_required":      "Se requiere un token de autenticación",
+    "auth.invalid_token":       "Token inválido o expirado",
+    "auth.forbidden":           "No tiene permisos para realizar esta acción",
+    "auth.own_data_only":       "Solo puede consultar su propia información",
+    "auth.invalid_credentials": "Usuario o contraseña incorrectos",
+    "auth.logged_in":           "Sesión iniciada exitosamente",
+    "auth.login_error":         "Error al iniciar sesión",
+    "auth.token_error":         "Error al generar el token",
+    
+    // Usuarios
+    "user.not_found":          "Usuario no encontrado",
+    "user.retrieved":          "Usuario obtenido exitosamente",
+    "user.list_retrieved":     "Usuarios obtenidos exitosamente",
+    "user.created":            "Usuario creado exitosamente",
+    "user.list_error":         "Error al obtener usuarios",
+    "user.create_error":       "Error al crear el usuario",
+    "user.duplicate_username": "El nombre de usuario ya está registrado",
+    "user.student_required":   "Las cuentas de alumno requieren student_id",
+    
+    // Estudiantes
+    "student.invalid_id":      "ID de estudiante inválido",
+    "student.not_found":       "Estudiante no encontrado",
+    "student.retrieved":       "Estudiante obtenido exitosamente",
+    "student.list_retrieved":  "Estudiantes obtenidos exitosamente",
+    "student.created":         "Estudiante creado exitosamente",
+    "student.updated":         "Estudiante actualizado exitosamente",
+    "student.deleted":         "Estudiante eliminado exitosamente",
+    "student.restored":        "Estudiante restaurado exitosamente",
+    "student.list_error":      "Error al obtener estudiantes",
+    "student.create_error":    "Error al crear el estudiante",
+    "student.update_error":    "Error al actualizar estudiante",
+    "student.delete_error":    "Error al eliminar estudiante",
+    "student.duplicate_email": "Ya existe un estudiante con ese email",
+    "student.deleted_email":   "Un estudiante eliminado usa ese email; restáurelo en lugar de registrarlo de nuevo",
+    "student.group_not_found": "Datos inválidos: el grupo no existe",
+    
+    // Importación de estudiantes
+    "import.file_required":         "Datos inválidos: se requiere el archivo en el campo file",
+    "import.file_too_large":        "El archivo excede el tamaño máximo de 5 MB",
+    "import.read_error":            "Error al leer el archivo",
+    "import.validated":             "Archivo validado; no se guardó ningún estudiante",
+    "import.has_errors":            "El archivo tiene errores; no se importó ningún estudiante",
+    "import.email_taken_meanwhile": "Otro estudiante se registró con alguno de los emails durante la importación; no se importó ningún estudiante",
+    "import.error":                 "Error al importar estudiantes",
+    "import.imported":              "Estudiantes importados exitosamente",
+    "import.empty_file":            "el archivo está vacío",
+    "import.missing_column":        "falta la columna %s en el encabezado",
+    "import.too_many_rows":         "el archivo excede el máximo de %d filas",
+    "import.repeated_email":        "email repetido en la fila %d",
+    "import.group_not_found":       "el grupo no existe",
+    "import.email_taken":           "ya existe un estudiante con ese email",
+    
+    // Archivos de hoja de cálculo
+    "spreadsheet.unsupported_format": "formato no soportado; use un archivo .csv o .xlsx",
+    "spreadsheet.invalid_csv":        "CSV inválido: %v",
+    "spreadsheet.invalid_xlsx":       "XLSX inválido: %v",
+    "spreadsheet.no_sheets":          "XLSX inválido: el libro no tiene hojas",
+    
+    // Grupos
+    "group.invalid_id":         "ID de grupo inválido",
+    "group.not_found":          "Grupo no encontrado",
+    "group.retrieved":          "Grupo obtenido exitosamente",
+    "group.list_retrieved":     "Grupos obtenidos exitosamente",
+    "group.created":            "Grupo creado exitosamente",
+    "group.updated":            "Grupo actualizado exitosamente",
+    "group.deleted":            "Grupo eliminado exitosamente",
+    "group.list_error":         "Error al obtener grupos",
+    "group.create_error":       "Error al crear el grupo",
+    "group.update_error":       "Error al actualizar grupo",
+    "group.delete_error":       "Error al eliminar grupo",
+    "group.duplicate":          "Ya existe un grupo con ese grado, sección y turno en el ciclo escolar",
+    "group.in_use":             "El grupo tiene estudiantes asignados",
+    "group.homeroom_not_found": "el maestro titular no existe",
+    
+    // Maestros
+    "teacher.not_found":        "Maestro no encontrado",
+    "teacher.retrieved":        "Maestro obtenido exitosamente",
+    "teacher.list_retrieved":   "Maestros obtenidos exitosamente",
+    "teacher.created":          "Maestro creado exitosamente",
+    "teacher.updated":          "Maestro actualizado exitosamente",
+    "teacher.deleted":          "Maestro eliminado exitosamente",
+    "teacher.list_error":       "Error al obtener maestros",
+    "teacher.create_error":     "Error al crear el maestro",
+    "teacher.update_error":     "Error al actualizar maestro",
+    "teacher.delete_error":     "Error al eliminar maestro",
+    "teacher.duplicate":        "Ya existe un maestro con ese email o vinculado a esa cuenta",
+    "teacher.not_linked":       "La cuenta no está vinculada a un maestro",
+    "teacher.not_assigned":     "Solo el maestro asignado a la materia y el grupo puede registrar sus calificaciones",
+    "teacher.user_not_found":   "la cuenta de usuario no existe",
+    "teacher.user_not_teacher": "la cuenta vinculada debe tener rol %s",
+    
+    // Tutores
+    "guardian.not_found":       "Tutor no encontrado",
+    "guardian.retrieved":       "Tutor obtenido exitosamente",
+    "guardian.created":         "Tutor creado exitosamente",
+    "guardian.updated":         "Tutor actualizado exitosamente",
+    "guardian.deleted":         "Tutor eliminado exitosamente",
+    "guardian.list_retrieved":  "Tutores obtenidos exitosamente",
+    "guardian.list_error":      "Error al obtener tutores",
+    "guardian.create_error":    "Error al crear el tutor",
+    "guardian.update_error":    "Error al actualizar tutor",
+    "guardian.delete_error":    "Error al eliminar tutor",
+    "guardian.query_error":     "Error al consultar los estudiantes del tutor",
+    "guardian.duplicate":       "Ya existe un tutor con ese email o vinculado a esa cuenta",
+    "guardian.linked":          "Estudiante vinculado al tutor exitosamente",
+    "guardian.unlinked":        "Estudiante desvinculado del tutor exitosamente",
+    "guardian.duplicate_link":  "El estudiante ya está vinculado a ese tutor",
+    "guardian.link_not_found":  "El estudiante no está vinculado a ese tutor",
+    "guardian.link_error":      "Error al vincular el estudiante",
+    "guardian.unlink_error":    "Error al desvincular el estudiante",
+    "guardian.not_linked":      "La cuenta no está vinculada a un tutor",
+    "guardian.not_own_student": "Solo puede consultar a los estudiantes vinculados a su cuenta",
+    "guardian.user_not_found":  "la cuenta de usuario no existe",
+    "guardian.user_not_parent": "la cuenta vinculada debe tener rol %s",
+    
+    // Asignaciones
+    "assignment.not_found":      "Asignación no encontrada",
+    "assignment.list_retrieved": "Asignaciones obtenidas exitosamente",
+    "assignment.created":        "Asignación creada exitosamente",
+    "assignment.deleted":        "Asignación eliminada exitosamente",
+    "assignment.list_error":     "Error al obtener asignaciones",
+    "assignment.create_error":   "Error al crear la asignación",
+    "assignment.delete_error":   "Error al eliminar asignación",
+    "assignment.query_error":    "Error al consultar asignaciones",
+    "assignment.duplicate":      "La materia ya tiene un maestro asignado en ese grupo y periodo",
+    
+    // Inscripciones
+    "enrollment.not_found":            "Inscripción no encontrada",
+    "enrollment.list_retrieved":       "Inscripciones obtenidas exitosamente",
+    "enrollment.created":              "Inscripción creada exitosamente",
+    "enrollment.deleted":              "Inscripción eliminada exitosamente",
+    "enrollment.group_enrolled":       "Grupo inscrito exitosamente",
+    "enrollment.group_unenrolled":     "Grupo dado de baja exitosamente",
+    "enrollment.list_error":           "Error al obtener inscripciones",
+    "enrollment.create_error":         "Error al crear la inscripción",
+    "enrollment.delete_error":         "Error al eliminar inscripción",
+    "enrollment.group_enroll_error":   "Error al inscribir al grupo",
+    "enrollment.group_unenroll_error": "Error al dar de baja al grupo",
+    "enrollment.query_error":          "Error al consultar inscripciones",
+    "enrollment.duplicate":            "El estudiante ya está inscrito en la materia en ese periodo",
+    "enrollment.has_grades":           "El estudiante ya tiene calificaciones de la materia en ese periodo",
+    "enrollment.not_enrolled":         "El estudiante no está inscrito en la materia en ese periodo",
+    
+    // Materias
+    "subject.invalid_id":     "ID de materia inválido",
+    "subject.not_found":      "Materia no encontrada",
+    "subject.retrieved":      "Materia obtenida exitosamente",
+    "subject.list_retrieved": "Materias obtenidas exitosamente",
+    "subject.created":        "Materia creada exitosamente",
+    "subject.updated":        "Materia actualizada exitosamente",
+    "subject.deleted":        "Materia eliminada exitosamente",
+    "subject.restored":       "Materia restaurada exitosamente",
+    "subject.list_error":     "Error al obtener materias",
+    "subject.create_error":   "Error al crear la materia",
+    "subject.update_error":   "Error al actualizar materia",
+    "subject.delete_error":   "Error al eliminar materia",
+    "subject.duplicate_name": "Ya existe una materia con ese nombre",
+    "subject.deleted_name":   "Una materia eliminada usa ese nombre; restáurela en lugar de registrarla de nuevo",
+    
+    // Periodos
+    "term.invalid_id":          "ID de periodo inválido",
+    "term.not_found":           "Periodo no encontrado",
+    "term.retrieved":           "Periodo obtenido exitosamente",
+    "term.list_retrieved":      "Periodos obtenidos exitosamente",
+    "term.created":             "Periodo creado exitosamente",
+    "term.updated":             "Periodo actualizado exitosamente",
+    "term.deleted":             "Periodo eliminado exitosamente",
+    "term.list_error":          "Error al obtener periodos",
+    "term.create_error":        "Error al crear el periodo",
+    "term.update_error":        "Error al actualizar periodo",
+    "term.delete_error":        "Error al eliminar periodo",
+    "term.query_error":         "Error al consultar periodos",
+    "term.in_use":              "El periodo tiene subperiodos o calificaciones registradas",
+    "term.group_year_mismatch": "Datos inválidos: el periodo y el grupo pertenecen a ciclos escolares distintos",
+    "term.contains_itself":     "Datos inválidos: un periodo no puede contenerse a sí mismo",
+    "term.invalid_start_date":  "start_date debe tener el formato AAAA-MM-DD",
+    "term.invalid_end_date":    "end_date debe tener el formato AAAA-MM-DD",
+    "term.end_before_start":    "end_date no puede ser anterior a start_date",
+    "term.school_year_parent":  "un ciclo escolar no puede tener periodo padre",
+    "term.parent_required":     "parent_id es requerido para el tipo %s",
+    "term.parent_not_found":    "el periodo padre no existe",
+    "term.parent_kind":         "el periodo padre de un %s debe ser de tipo %s",
+    "term.parent_other_year":   "el periodo padre pertenece a otro ciclo escolar",
+    
+    // Calificaciones
+    "grade.invalid_id":             "ID de calificación inválido",
+    "grade.not_found":              "Calificación no encontrada",
+    "grade.retrieved":              "Calificación obtenida exitosamente",
+    "grade.list_retrieved":         "Calificaciones obtenidas exitosamente",
+    "grade.created":                "Calificación creada exitosamente",
+    "grade.updated":                "Calificación actualizada exitosamente",
+    "grade.deleted":                "Calificación eliminada exitosamente",
+    "grade.restored":               "Calificación restaurada exitosamente",
+    "grade.saved":                  "Calificaciones guardadas exitosamente",
+    "grade.history_retrieved":      "Historial obtenido exitosamente",
+    "grade.list_error":             "Error al obtener calificaciones",
+    "grade.create_error":           "Error al crear la calificación",
+    "grade.update_error":           "Error al actualizar calificación",
+    "grade.delete_error":           "Error al eliminar calificación",
+    "grade.save_error":             "Error al guardar la calificación",
+    "grade.batch_save_error":       "Error al guardar las calificaciones",
+    "grade.history_error":          "Error al obtener el historial",
+    "grade.duplicate":              "El estudiante ya tiene una calificación en esta materia y periodo",
+    "grade.duplicate_deleted":      "El estudiante tiene una calificación eliminada en esta materia y periodo; restáurela o regístrela con PUT",
+    "grade.parent_deleted":         "Restaure primero al estudiante y la materia de la calificación",
+    "grade.locked":                 "Las calificaciones de la materia en este grupo y periodo están cerradas",
+    "grade.computed":               "La calificación de esta materia y periodo se calcula con sus criterios de evaluación; capture las calificaciones por criterio",
+    "grade.out_of_scale":           "La calificación debe estar entre %g y %g en la escala de la materia",
+    "grade.reason_too_long":        "El motivo no puede exceder 255 caracteres",
+    "grade.batch_rejected":         "Algunas calificaciones fueron rechazadas; no se guardó ninguna",
+    "grade.batch_repeated_student": "El estudiante ya aparece en la posición %d",
+    
+    // Cierres de calificaciones
+    "grade_lock.not_found":        "Cierre de calificaciones no encontrado",
+    "grade_lock.list_retrieved":   "Cierres de calificaciones obtenidos exitosamente",
+    "grade_lock.events_retrieved": "Bitácora de cierres obtenida exitosamente",
+    "grade_lock.closed":           "Calificaciones cerradas exitosamente",
+    "grade_lock.reopened":         "Calificaciones reabiertas exitosamente",
+    "grade_lock.already_locked":   "Las calificaciones de la materia en este grupo y periodo ya están cerradas",
+    "grade_lock.not_assigned":     "Solo el maestro asignado a la materia y el grupo puede cerrar sus calificaciones",
+    "grade_lock.close_error":      "Error al cerrar las calificaciones",
+    "grade_lock.list_error":       "Error al obtener los cierres de calificaciones",
+    "grade_lock.events_error":     "Error al obtener la bitácora de cierres",
+    "grade_lock.query_error":      "Error al consultar cierres de calificaciones",
+    
+    // Asistencia
+    "attendance.not_found":         "Registro de asistencia no encontrado",
+    "attendance.list_retrieved":    "Registros de asistencia obtenidos exitosamente",
+    "attendance.recorded":          "Lista registrada exitosamente",
+    "attendance.updated":           "Registro de asistencia actualizado exitosamente",
+    "attendance.deleted":           "Registro de asistencia eliminado exitosamente",
+    "attendance.summary_retrieved": "Resumen de asistencia obtenido exitosamente",
+    "attendance.future_date":       "No se puede tomar lista de una fecha futura",
+    "attendance.not_in_group":      "El estudiante no pertenece al grupo",
+    "attendance.repeated_student":  "El estudiante ya aparece en la posición %d",
+    "attendance.rejected":          "Algunos registros fueron rechazados; no se guardó ninguno",
+    "attendance.concurrent":        "Otra lista guardó al mismo tiempo la asistencia de un estudiante en esta fecha; no se guardó ningún registro, vuelva a enviar la lista",
+    "attendance.not_assigned":      "Solo el maestro asignado a la materia y el grupo puede tomar su lista",
+    "attendance.not_homeroom":      "Solo el titular del grupo puede tomar la lista diaria",
+    "attendance.invalid_status":    "status debe ser uno de: %s",
+    "attendance.daily":             "Lista diaria",
+    "attendance.record_error":      "Error al registrar la asistencia",
+    "attendance.list_error":        "Error al obtener los registros de asistencia",
+    "attendance.update_error":      "Error al actualizar el registro de asistencia",
+    "attendance.delete_error":      "Error al eliminar el registro de asistencia",
+    "attendance.summary_error":     "Error al calcular el resumen de asistencia",
+    
+    // Criterios de evaluación
+    "evaluation.criteria_retrieved":  "Criterios de evaluación obtenidos exitosamente",
+    "evaluation.criteria_saved":      "Criterios de evaluación guardados exitosamente",
+    "evaluation.criteria_deleted":    "Criterios de evaluación eliminados exitosamente",
+    "evaluation.criteria_not_found":  "La materia no tiene criterios de evaluación en este periodo",
+    "evaluation.criterion_not_found": "Criterio de evaluación no encontrado",
+    "evaluation.weights_sum":         "Los pesos de los criterios deben sumar 100; suman %g",
+    "evaluation.repeated_name":       "El criterio %s está repetido",
+    "evaluation.has_scores":          "No se puede quitar un criterio que ya tiene calificaciones capturadas",
+    "evaluation.manual_grades":       "La materia ya tiene calificaciones capturadas directamente en este periodo; elimínelas antes de definir criterios de evaluación",
+    "evaluation.not_assigned":        "Solo un maestro asignado a la materia en el periodo puede definir sus criterios de evaluación",
+    "evaluation.scores_saved":        "Calificaciones del criterio guardadas exitosamente",
+    "evaluation.breakdown_retrieved": "Desglose de la calificación obtenido exitosamente",
+    "evaluation.query_error":         "Error al consultar los criterios de evaluación",
+    "evaluation.save_error":          "Error al guardar los criterios de evaluación",
+    "evaluation.delete_error":        "Error al eliminar los criterios de evaluación",
+    "evaluation.scores_error":        "Error al guardar las calificaciones del criterio",
+    
+    // Escalas de calificación
+    "grading_scale.created":               "Escala de calificación creada exitosamente",
+    "grading_scale.retrieved":             "Escala de calificación obtenida exitosamente",
+    "grading_scale.list_retrieved":        "Escalas de calificación obtenidas exitosamente",
+    "grading_scale.assignments_retrieved": "Asignaciones de escalas obtenidas exitosamente",
+    "grading_scale.updated":               "Escala de calificación actualizada exitosamente",
+    "grading_scale.deleted":               "Escala de calificación eliminada exitosamente",
+    "grading_scale.not_found":             "Escala de calificación no encontrada",
+    "grading_scale.duplicate_name":        "Ya existe una escala de calificación con ese nombre",
+    "grading_scale.in_use":                "No se puede eliminar una escala asignada; primero quite sus asignaciones",
+    "grading_scale.range":                 "El valor máximo de la escala debe ser mayor que el mínimo",
+    "grading_scale.passing_range":         "El valor aprobatorio debe estar dentro de la escala",
+    "grading_scale.letter_repeated":       "La letra %s está repetida",
+    "grading_scale.letter_range":          "El valor mínimo de la letra %s debe estar dentro de la escala",
+    "grading_scale.letter_value_repeated": "Dos letras empiezan en el mismo valor %g",
+    "grading_scale.letters_cover":         "La letra con el menor valor debe empezar en el mínimo de la escala",
+    "grading_scale.assignment_target":     "Indique el grado escolar, la materia o ambos",
+    "grading_scale.assigned":              "Escala de calificación asignada exitosamente",
+    "grading_scale.unassigned":            "Asignación de escala eliminada exitosamente",
+    "grading_scale.assignment_not_found":  "Asignación de escala no encontrada",
+    "grading_scale.duplicate_assignment":  "Ya existe una asignación de escala igual",
+    "grading_scale.list_error":            "Error al obtener las escalas de calificación",
+    "grading_scale.create_error":          "Error al crear la escala de calificación",
+    "grading_scale.update_error":          "Error al actualizar la escala de calificación",
+    "grading_scale.delete_error":          "Error al eliminar la escala de calificación",
+    "grading_scale.assign_error":          "Error al asignar la escala de calificación",
+    "grading_scale.assignment_list_error": "Error al obtener las asignaciones de escalas",
+    "grading_scale.unassign_error":        "Error al eliminar la asignación de escala",
+    "grading_scale.query_error":           "Error al consultar la escala de calificación",
+    
+    // Estadísticas y boletas
+    "analytics.stats_retrieved":        "Estadísticas obtenidas exitosamente",
+    "analytics.ranking_retrieved":      "Ranking obtenido exitosamente",
+    "analytics.distribution_retrieved": "Distribución obtenida exitosamente",
+    "analytics.stats_error":            "Error al calcular las estadísticas",
+    "analytics.ranking_error":          "Error al calcular el ranking",
+    "analytics.distribution_error":     "Error al calcular la distribución",
+    "analytics.invalid_passing_grade":  "La calificación aprobatoria debe ser un número entre 0 y 100",
+    "analytics.invalid_bucket_size":    "bucket_size debe ser un entero entre 1 y 50",
+    "report.error":                     "Error al generar la boleta",
+    
+    // Exportaciones
+    "export.not_acceptable": "Formato no disponible; acepte text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet o application/x-ndjson, o use el parámetro format",
+}
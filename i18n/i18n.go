@@ -0,0 +1,91 @@
+package i18n
+
+import (
+    "fmt"
+    
+    "golang.org/x/text/language"
+)
+
+// Idiomas de los mensajes de la API
+const (
+    Spanish = "es"
+    English = "en"
+)
+
+// Default es el idioma que se usa si el cliente no pide uno disponible
+const Default = Spanish
+
+// catalogs contiene los mensajes de cada idioma indexados por su llave
+var catalogs = map[string]map[string]string{
+    Spanish: spanish,
+    English: english,
+}
+
+// El primer idioma de la lista es el que se elige si ninguno coincide
+var matcher = language.NewMatcher([]language.Tag{language.Spanish, language.English})
+
+// Negotiate elige el idioma de la respuesta a partir del header Accept-Language
+// (p. ej. "en-US,en;q=0.9"). Si el header falta o es inválido devuelve Default.
+func Negotiate(acceptLanguage string) string {
+    if acceptLanguage == "" {
+        return Default
+    }
+    tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+    if err != nil || len(tags) == 0 {
+        return Default
+    }
+    _, index, confidence := matcher.Match(tags...)
+    if confidence == language.No {
+        return Default
+    }
+    if index == 1 {
+        return English
+    }
+    return Spanish
+}
+
+// T devuelve el mensaje de la llave en el idioma lang con los argumentos aplicados al estilo de
+// fmt.Sprintf. Los argumentos de tipo *Message se traducen también. Si la llave no existe en el
+// idioma se usa el español, y si tampoco existe se devuelve la llave.
+func T(lang, key string, args ...interface{}) string {
+    format, ok := catalogs[lang][key]
+    if !ok {
+        if format, ok = catalogs[Default][key]; !ok {
+            return key
+        }
+    }
+    if len(args) == 0 {
+        return format
+    }
+    
+    translated := make([]interface{}, len(args))
+    for i, arg := range args {
+        if message, ok := arg.(*Message); ok {
+            arg = message.Translate(lang)
+        }
+        translated[i] = arg
+    }
+    return fmt.Sprintf(format, translated...)
+}
+
+// Message es un mensaje pendiente de traducir. Implementa error para que las funciones que validan
+// datos devuelvan el motivo sin conocer el idioma de la petición.
+type Message struct {
+    Key  string
+    Args []interface{}
+}
+
+// NewMessage crea el mensaje de la llave con sus argumentos
+func NewMessage(key string, args ...interface{}) *Message {
+    return &Message{Key: key, Args: args}
+}
+
+// Translate devuelve el mensaje en el idioma lang
+func (m *Message) Translate(lang string) string {
+    return T(lang, m.Key, m.Args...)
+}
+
+// Error devuelve el mensaje en el idioma predeterminado
+func (m *Message) Error() string {
+    return m.Translate(Default)
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+    "github.com/go-playground/locales/en"
+    "github.com/go-playground/locales/es"
+    ut "github.com/go-playground/universal-translator"
+    "github.com/go-playground/validator/v10"
+    en_translations "github.com/go-playground/validator/v10/translations/en"
+    es_translations "github.com/go-playground/validator/v10/translations/es"
+)
+
+// validationTranslators traduce los errores del validador a cada idioma
+var validationTranslators = map[string]ut.Translator{}
+
+// RegisterValidator registra en v las traducciones de las reglas de validación. Debe llamarse
+// una vez, antes de validar, con el validador que usa el binding de Gin.
+func RegisterValidator(v *validator.Validate) error {
+    universal := ut.New(es.New(), es.New(), en.New())
+    
+    spanishTranslator, _ := universal.GetTranslator(Spanish)
+    if err := es_translations.RegisterDefaultTranslations(v, spanishTranslator); err != nil {
+        return err
+    }
+    englishTranslator, _ := universal.GetTranslator(English)
+    if err := en_translations.RegisterDefaultTranslations(v, englishTranslator); err != nil {
+        return err
+    }
+    
+    // Las traducciones del validador no incluyen datetime en español, y en inglés muestran el
+    // layout de Go en lugar del formato de la fecha
+    for lang, translator := range map[string]ut.Translator{Spanish: spanishTranslator, English: englishTranslator} {
+        err := v.RegisterTranslation("datetime", translator,
+            func(ut.Translator) error { return nil },
+            func(_ ut.Translator, fe validator.FieldError) string {
+                return T(lang, "validation.datetime", fe.Field(), dateLayout(lang, fe.Param()))
+            })
+        if err != nil {
+            return err
+        }
+    }
+    
+    validationTranslators[Spanish] = spanishTranslator
+    validationTranslators[English] = englishTranslator
+    return nil
+}
+
+// FieldMessage devuelve el mensaje de la regla que incumplió el campo en el idioma lang
+func FieldMessage(lang string, fe validator.FieldError) string {
+    translator, ok := validationTranslators[lang]
+    if !ok {
+        translator = validationTranslators[Default]
+    }
+    if translator == nil {
+        return fe.Error()
+    }
+    return fe.Translate(translator)
+}
+
+// dateLayout muestra el layout de fecha de Go (2006-01-02) en el formato que conoce el cliente
+func dateLayout(lang, layout string) string {
+    if layout != "2006-01-02" {
+        return layout
+    }
+    return T(lang, "validation.date_layout")
+}
@@ -9,16 +9,19 @@ import (
     swaggerFiles "github.com/swaggo/files"
     ginSwagger "github.com/swaggo/gin-swagger"
     
+    "ControlEscolar/auth"
     "ControlEscolar/config"
     "ControlEscolar/models"
+    "ControlEscolar/repositories"
     "ControlEscolar/routes"
+    "ControlEscolar/utils"
     
     _ "ControlEscolar/docs"
 )
 
 // @title           API de Control Escolar
 // @version         1.0
-// @description     API REST para la gestión de estudiantes, materias y calificaciones en un sistema escolar
+// @description     API REST para la gestión de estudiantes, materias y calificaciones en un sistema escolar. Los mensajes se devuelven en español o inglés según el header Accept-Language
 
 // @contact.name   Estefany Montiel
 // @contact.email  estefany.montiel@example.com
@@ -30,6 +33,11 @@ import (
 // @BasePath  /api
 
 // @schemes http
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Token JWT con el formato "Bearer {token}", obtenido en /auth/login
 func main() {
     // Cargar variables de entorno
     err := godotenv.Load()
@@ -39,21 +47,17 @@ func main() {
         log.Println("✅ Archivo .env cargado correctamente")
     }
     
-    // Inicializar base de datos
-    config.InitDatabase()
+    // Inicializar base de datos (DB_DRIVER: mysql, postgres o sqlite)
+    if err := config.InitDatabase(); err != nil {
+        log.Fatal("❌ ", err)
+    }
     
     db := config.GetDB()
     
     // Ejecutar migraciones
     log.Println("📦 Ejecutando migraciones...")
-    if err := models.MigrateStudent(db); err != nil {
-        log.Fatal("❌ Error en migración de estudiantes:", err)
-    }
-    if err := models.MigrateSubject(db); err != nil {
-        log.Fatal("❌ Error en migración de materias:", err)
-    }
-    if err := models.MigrateGrade(db); err != nil {
-        log.Fatal("❌ Error en migración de calificaciones:", err)
+    if err := models.MigrateAll(db); err != nil {
+        log.Fatal("❌ ", err)
     }
     
     // Agregar llaves foráneas
@@ -63,15 +67,27 @@ func main() {
     
     log.Println("✅ Base de datos lista")
     
+    // Configurar autenticación y crear el administrador inicial si hace falta
+    authConfig, err := config.LoadAuthConfig()
+    if err != nil {
+        log.Fatal("❌ Configuración de autenticación inválida: ", err)
+    }
+    tokens := auth.NewTokenService(authConfig.JWTSecret, authConfig.TokenTTL)
+    if err := auth.EnsureAdmin(repositories.NewGormUserRepository(db), authConfig.AdminUsername, authConfig.AdminPassword); err != nil {
+        log.Fatal("❌ Error al crear el usuario administrador:", err)
+    }
+    
     // Configurar Gin
-    router := gin.Default()
+    router := gin.New()
+    router.Use(gin.Logger(), gin.CustomRecovery(utils.RespondPanic))
     router.SetTrustedProxies(nil)
+    router.NoRoute(utils.RespondRouteNotFound)
     
     // Middleware para CORS
     router.Use(CORSMiddleware())
     
     // Configurar rutas de la API
-    routes.SetupRoutes(router)
+    routes.SetupRoutes(router, db, tokens, config.LoadGradingConfig())
     
     // Ruta principal
     router.GET("/", func(c *gin.Context) {
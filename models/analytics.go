@@ -0,0 +1,48 @@
+package models
+
+// GroupSubjectStats estadísticas de una materia dentro de un grupo.
+// Se calculan sobre la calificación final de cada estudiante en la materia.
+type GroupSubjectStats struct {
+    GroupID      *int    `json:"group_id" example:"1"`
+    Group        string  `gorm:"column:group_name" json:"group" example:"5A"`
+    SubjectID    int     `json:"subject_id" example:"1"`
+    SubjectName  string  `json:"subject_name" example:"Matemáticas"`
+    StudentCount int64   `json:"student_count" example:"28"`
+    Average      float64 `json:"average" example:"81.25"`
+    Min          float64 `json:"min" example:"45"`
+    Max          float64 `json:"max" example:"100"`
+    PassedCount  int64   `json:"passed_count" example:"25"`
+    PassRate     float64 `json:"pass_rate" example:"89.29"`
+}
+
+// StudentRanking posición de un estudiante según su promedio
+type StudentRanking struct {
+    Rank           int     `json:"rank" example:"1"`
+    StudentID      int     `json:"student_id" example:"1"`
+    Name           string  `json:"name" example:"Juan Pérez"`
+    GroupID        *int    `json:"group_id" example:"1"`
+    Group          string  `gorm:"column:group_name" json:"group" example:"5A"`
+    Average        float64 `json:"average" example:"94.5"`
+    SubjectCount   int64   `json:"subject_count" example:"6"`
+    FailedSubjects int64   `json:"failed_subjects" example:"0"`
+}
+
+// DistributionBucket intervalo [From, To) del histograma; el último intervalo incluye el 100
+type DistributionBucket struct {
+    From  float64 `json:"from" example:"80"`
+    To    float64 `json:"to" example:"90"`
+    Count int64   `json:"count" example:"12"`
+}
+
+// GradeDistribution histograma de calificaciones finales con su tasa de aprobación
+type GradeDistribution struct {
+    GroupID      *int                 `json:"group_id,omitempty" example:"1"`
+    SubjectID    *int                 `json:"subject_id,omitempty" example:"1"`
+    BucketSize   int                  `json:"bucket_size" example:"10"`
+    Total        int64                `json:"total" example:"168"`
+    PassedCount  int64                `json:"passed_count" example:"150"`
+    PassRate     float64              `json:"pass_rate" example:"89.29"`
+    // PassingGrade es el passing_grade indicado o, sin él, el de las materias sin escala asignada
+    PassingGrade float64              `json:"passing_grade" example:"60"`
+    Buckets      []DistributionBucket `json:"buckets"`
+}
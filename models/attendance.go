@@ -0,0 +1,130 @@
+package models
+
+import (
+    "time"
+    
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// Estados de asistencia de un estudiante
+const (
+    AttendancePresent   = "present"
+    AttendanceAbsent    = "absent"
+    AttendanceLate      = "late"
+    AttendanceJustified = "justified"
+)
+
+// AttendanceStatuses lista de estados de asistencia válidos
+var AttendanceStatuses = []string{AttendancePresent, AttendanceAbsent, AttendanceLate, AttendanceJustified}
+
+// DefaultMaxAbsencePercentage porcentaje máximo de faltas permitido en una materia
+const DefaultMaxAbsencePercentage = 20.0
+
+// Attendance registra la asistencia de un estudiante en una fecha. Con SubjectID es la asistencia
+// a una materia; sin él es la lista diaria del grupo. Un estudiante tiene a lo sumo un registro
+// por materia (o lista diaria) y fecha.
+type Attendance struct {
+    AttendanceID   int       `gorm:"primaryKey;autoIncrement" json:"attendance_id" example:"1"`
+    StudentID      int       `gorm:"not null;index:idx_attendance_student_date,priority:1;uniqueIndex:idx_attendance_student_subject_date,priority:1" json:"student_id" example:"1"`
+    // GroupID es el grupo en el que se tomó la lista
+    GroupID        int       `gorm:"not null;index:idx_attendance_group_date,priority:1" json:"group_id" example:"1"`
+    SubjectID      *int      `gorm:"index" json:"subject_id" example:"1"`
+    // SubjectKey es SubjectID, o 0 en la lista diaria. Como subject_id puede ser nulo, el índice
+    // único por estudiante, materia y fecha se define sobre esta columna; BeforeSave la llena.
+    SubjectKey     int       `gorm:"not null;default:0;uniqueIndex:idx_attendance_student_subject_date,priority:2" json:"-" swaggerignore:"true"`
+    Date           time.Time `gorm:"type:date;not null;index:idx_attendance_student_date,priority:2;index:idx_attendance_group_date,priority:2;uniqueIndex:idx_attendance_student_subject_date,priority:3" json:"date" example:"2025-10-17T00:00:00Z"`
+    Status         string    `gorm:"type:varchar(10);not null" json:"status" example:"absent"`
+    Notes          string    `gorm:"type:varchar(255);not null;default:''" json:"notes" example:"Presentó justificante médico"`
+    // RecordedBy es la cuenta que tomó la lista o modificó el registro por última vez; el nombre de
+    // usuario se guarda aparte para conservarlo aunque la cuenta se elimine
+    RecordedBy     *int      `gorm:"index" json:"recorded_by" example:"2"`
+    RecordedByName string    `gorm:"type:varchar(100);not null" json:"recorded_by_name" example:"maestra.lopez"`
+    CreatedAt      time.Time `json:"created_at"`
+    UpdatedAt      time.Time `json:"updated_at"`
+    
+    Student        *Student  `gorm:"belongsTo:Student;foreignKey:StudentID;references:StudentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Group          *Group    `gorm:"belongsTo:Group;foreignKey:GroupID;references:GroupID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Subject        *Subject  `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    User           *User     `gorm:"belongsTo:User;foreignKey:RecordedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (Attendance) TableName() string {
+    return "attendance"
+}
+
+// BeforeSave copia la materia del registro a SubjectKey
+func (a *Attendance) BeforeSave(tx *gorm.DB) error {
+    a.SubjectKey = 0
+    if a.SubjectID != nil {
+        a.SubjectKey = *a.SubjectID
+    }
+    return nil
+}
+
+// AttendanceCount número de registros de un estudiante con un estado en una materia;
+// SubjectID nulo corresponde a la lista diaria del grupo
+type AttendanceCount struct {
+    SubjectID *int   `json:"subject_id"`
+    Status    string `json:"status"`
+    Count     int64  `json:"count"`
+}
+
+// AttendanceTotals resume los registros de asistencia. AbsencePercentage es el porcentaje de
+// faltas sin justificar sobre el total de registros; los retardos cuentan como asistencia.
+type AttendanceTotals struct {
+    Total             int64   `json:"total" example:"40"`
+    Present           int64   `json:"present" example:"33"`
+    Absent            int64   `json:"absent" example:"4"`
+    Late              int64   `json:"late" example:"2"`
+    Justified         int64   `json:"justified" example:"1"`
+    AbsencePercentage float64 `json:"absence_percentage" example:"10"`
+    // ExceedsLimit indica si el porcentaje de faltas supera el máximo permitido
+    ExceedsLimit      bool    `json:"exceeds_limit" example:"false"`
+}
+
+// SubjectAttendance asistencia de un estudiante en una materia o, sin materia, en la lista diaria
+type SubjectAttendance struct {
+    SubjectID *int   `json:"subject_id" example:"1"`
+    Name      string `json:"name" example:"Matemáticas"`
+    AttendanceTotals
+}
+
+// AttendanceSummary asistencia de un estudiante en un rango de fechas
+type AttendanceSummary struct {
+    Student              StudentBasic        `json:"student"`
+    Term                 *TermBasic          `json:"term,omitempty"`
+    From                 string              `json:"from,omitempty" example:"2025-08-25"`
+    To                   string              `json:"to,omitempty" example:"2025-10-10"`
+    MaxAbsencePercentage float64             `json:"max_absence_percentage" example:"20"`
+    AttendanceTotals
+    Subjects             []SubjectAttendance `json:"subjects"`
+}
+
+// MigrateAttendance crea la tabla de asistencia. En una tabla anterior al índice único llena
+// subject_key y, de los registros repetidos de un estudiante en la misma materia y fecha, conserva
+// solo el último antes de crear el índice.
+func MigrateAttendance(db *gorm.DB) error {
+    migrator := db.Migrator()
+    if migrator.HasTable(&Attendance{}) && !migrator.HasColumn(&Attendance{}, "SubjectKey") {
+        err := db.Transaction(func(tx *gorm.DB) error {
+            table := clause.Table{Name: Attendance{}.TableName()}
+            if err := tx.Migrator().AddColumn(&Attendance{}, "SubjectKey"); err != nil {
+                return err
+            }
+            if err := tx.Exec("UPDATE ? SET subject_key = subject_id WHERE subject_id IS NOT NULL", table).Error; err != nil {
+                return err
+            }
+            // La tabla derivada evita que MySQL rechace borrar de la tabla que se consulta
+            return tx.Exec(`DELETE FROM ? WHERE attendance_id IN (SELECT attendance_id FROM (
+                SELECT older.attendance_id FROM ? AS older
+                JOIN ? AS newer ON newer.student_id = older.student_id AND newer.subject_key = older.subject_key
+                    AND newer.date = older.date AND newer.attendance_id > older.attendance_id
+            ) AS duplicates)`, table, table, table).Error
+        })
+        if err != nil {
+            return err
+        }
+    }
+    return db.AutoMigrate(&Attendance{})
+}
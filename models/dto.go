@@ -1,15 +1,85 @@
 package models
 
+import (
+    "time"
+)
+
 // CreateGradeRequest representa la petición para crear una calificación
 type CreateGradeRequest struct {
-    StudentID int     `json:"student_id" binding:"required,min=1" example:"1"`
-    SubjectID int     `json:"subject_id" binding:"required,min=1" example:"1"`
-    Grade     float64 `json:"grade" binding:"required,min=0,max=100" example:"95.5"`
+    StudentID int      `json:"student_id" binding:"required,min=1" example:"1"`
+    SubjectID int      `json:"subject_id" binding:"required,min=1" example:"1"`
+    TermID    int      `json:"term_id" binding:"required,min=1" example:"3"`
+    // Grade se captura en la escala de la materia y el grado escolar del estudiante.
+    // Es un puntero para que required acepte 0 y solo rechace el campo ausente
+    Grade     *float64 `json:"grade" binding:"required,min=0,max=100" example:"95.5"`
 }
 
 // UpdateGradeRequest representa la petición para actualizar una calificación
 type UpdateGradeRequest struct {
-    Grade float64 `json:"grade" binding:"required,min=0,max=100" example:"98.0"`
+    // Grade se captura en la escala de la materia y el grado escolar del estudiante;
+    // es un puntero para que required acepte 0
+    Grade  *float64 `json:"grade" binding:"required,min=0,max=100" example:"98.0"`
+    // Reason es el motivo del cambio que queda en el historial de la calificación
+    Reason string   `json:"reason" binding:"max=255" example:"Revisión del examen solicitada por el tutor"`
+}
+
+// BatchGradeRequest representa la captura de calificaciones de varios estudiantes en una materia y periodo
+type BatchGradeRequest struct {
+    SubjectID int              `json:"subject_id" binding:"required,min=1" example:"1"`
+    TermID    int              `json:"term_id" binding:"required,min=1" example:"3"`
+    // Grades se valida elemento por elemento para reportar cada falla por separado
+    Grades    []BatchGradeItem `json:"grades" binding:"required,min=1,max=200"`
+    // Reason es el motivo que queda en el historial de cada calificación modificada
+    Reason    string           `json:"reason" binding:"max=255" example:"Captura del primer parcial"`
+}
+
+// BatchGradeItem calificación de un estudiante dentro de una captura masiva, en la escala de la
+// materia y su grado escolar; en la captura de un criterio de evaluación es de 0 a 100
+type BatchGradeItem struct {
+    StudentID int      `json:"student_id" binding:"required,min=1" example:"1"`
+    // Grade es un puntero para que required acepte 0 y solo rechace el campo ausente
+    Grade     *float64 `json:"grade" binding:"required,min=0,max=100" example:"95.5"`
+}
+
+// BatchGradeResult calificación guardada en una captura masiva
+type BatchGradeResult struct {
+    GradeID   int          `json:"grade_id" example:"12"`
+    StudentID int          `json:"student_id" example:"1"`
+    Grade     float64      `json:"grade" example:"95.5"`
+    Scaled    *ScaledGrade `json:"scaled,omitempty"`
+}
+
+// BatchGradeFailure elemento rechazado de una captura masiva
+type BatchGradeFailure struct {
+    // Index es la posición del elemento en grades, desde 0
+    Index     int    `json:"index" example:"3"`
+    StudentID int    `json:"student_id" example:"8"`
+    // Code es el código estable del motivo del rechazo, el mismo de la captura individual
+    Code      string `json:"code" example:"NOT_ENROLLED"`
+    Message   string `json:"message" example:"El estudiante no está inscrito en la materia en ese periodo"`
+}
+
+// BatchGradeResponse resultado de una captura masiva. Si hay elementos rechazados no se guarda
+// ninguna calificación y solo Failed tiene datos.
+type BatchGradeResponse struct {
+    Created []BatchGradeResult  `json:"created"`
+    Updated []BatchGradeResult  `json:"updated"`
+    Failed  []BatchGradeFailure `json:"failed"`
+}
+
+// GradeConflictDetails identifica la calificación existente cuando se intenta duplicarla
+type GradeConflictDetails struct {
+    GradeID int `json:"grade_id" example:"7"`
+}
+
+// StudentConflictDetails identifica al estudiante eliminado que conserva el email
+type StudentConflictDetails struct {
+    StudentID int `json:"student_id" example:"12"`
+}
+
+// SubjectConflictDetails identifica a la materia eliminada que conserva el nombre
+type SubjectConflictDetails struct {
+    SubjectID int `json:"subject_id" example:"4"`
 }
 
 // GradeResponse representa la respuesta de una calificación con información completa
@@ -17,15 +87,22 @@ type GradeResponse struct {
     GradeID   int             `json:"grade_id" example:"1"`
     StudentID int             `json:"student_id" example:"1"`
     SubjectID int             `json:"subject_id" example:"1"`
+    TermID    *int            `json:"term_id" example:"3"`
     Grade     float64         `json:"grade" example:"95.5"`
     Student   *StudentBasic   `json:"student,omitempty"`
     Subject   *SubjectBasic   `json:"subject,omitempty"`
+    Term      *TermBasic      `json:"term,omitempty"`
+    // Scaled es la calificación en la escala de la materia y el grado escolar del estudiante
+    Scaled    *ScaledGrade    `json:"scaled,omitempty"`
+    // DeletedAt solo aparece en las calificaciones eliminadas, que se listan con include_deleted
+    DeletedAt *time.Time      `json:"deleted_at,omitempty" example:"2025-10-14T16:30:00Z"`
 }
 
 // StudentBasic información básica de estudiante
 type StudentBasic struct {
     StudentID int    `json:"student_id" example:"1"`
     Name      string `json:"name" example:"María García"`
+    GroupID   *int   `json:"group_id" example:"1"`
     Group     string `json:"group" example:"5A"`
     Email     string `json:"email" example:"maria@escuela.com"`
 }
@@ -34,4 +111,286 @@ type StudentBasic struct {
 type SubjectBasic struct {
     SubjectID int    `json:"subject_id" example:"1"`
     Name      string `json:"name" example:"Matemáticas"`
-}
\ No newline at end of file
+}
+
+// TermBasic información básica de periodo académico
+type TermBasic struct {
+    TermID     int    `json:"term_id" example:"3"`
+    Name       string `json:"name" example:"Primer parcial"`
+    SchoolYear string `json:"school_year" example:"2025-2026"`
+    Kind       string `json:"kind" example:"partial"`
+}
+
+// TermRequest representa la petición para crear o actualizar un periodo académico
+type TermRequest struct {
+    Name       string `json:"name" binding:"required,min=2,max=100" example:"Primer parcial"`
+    SchoolYear string `json:"school_year" binding:"required,min=4,max=20" example:"2025-2026"`
+    Kind       string `json:"kind" binding:"required,oneof=school_year semester partial" example:"partial"`
+    ParentID   *int   `json:"parent_id" binding:"omitempty,min=1" example:"2"`
+    StartDate  string `json:"start_date" binding:"required,datetime=2006-01-02" example:"2025-08-25"`
+    EndDate    string `json:"end_date" binding:"required,datetime=2006-01-02" example:"2025-10-10"`
+}
+
+// GroupRequest representa la petición para crear o actualizar un grupo
+type GroupRequest struct {
+    SchoolYear        string `json:"school_year" binding:"required,min=4,max=20" example:"2025-2026"`
+    GradeLevel        int    `json:"grade_level" binding:"required,min=1,max=12" example:"5"`
+    Section           string `json:"section" binding:"required,alpha,max=5" example:"A"`
+    Shift             string `json:"shift" binding:"omitempty,oneof=morning afternoon evening" example:"morning"`
+    HomeroomTeacherID *int   `json:"homeroom_teacher_id" binding:"omitempty,min=1" example:"2"`
+}
+
+// TeacherRequest representa la petición para crear o actualizar un maestro
+type TeacherRequest struct {
+    Name   string `json:"name" binding:"required,min=2,max=100" example:"Laura López"`
+    Email  string `json:"email" binding:"omitempty,email,max=100" example:"laura.lopez@escuela.com"`
+    UserID *int   `json:"user_id" binding:"omitempty,min=1" example:"2"`
+}
+
+// GuardianRequest representa la petición para crear o actualizar un tutor
+type GuardianRequest struct {
+    Name   string `json:"name" binding:"required,min=2,max=100" example:"Rosa Martínez"`
+    Email  string `json:"email" binding:"omitempty,email,max=100" example:"rosa.martinez@correo.com"`
+    Phone  string `json:"phone" binding:"omitempty,max=20" example:"5551234567"`
+    UserID *int   `json:"user_id" binding:"omitempty,min=1" example:"5"`
+}
+
+// GuardianStudentRequest representa la petición para vincular un tutor con un estudiante
+type GuardianStudentRequest struct {
+    StudentID    int    `json:"student_id" binding:"required,min=1" example:"1"`
+    Relationship string `json:"relationship" binding:"required,oneof=mother father guardian other" example:"mother"`
+}
+
+// GuardianResponse tutor con los estudiantes que tiene vinculados
+type GuardianResponse struct {
+    Guardian
+    Students []GuardianChild `json:"students"`
+}
+
+// GuardianChild estudiante vinculado a un tutor
+type GuardianChild struct {
+    Student      StudentBasic `json:"student"`
+    Relationship string       `json:"relationship" example:"mother"`
+}
+
+// StudentGuardian tutor de un estudiante con su parentesco
+type StudentGuardian struct {
+    Guardian     Guardian `json:"guardian"`
+    Relationship string   `json:"relationship" example:"mother"`
+}
+
+// AssignmentRequest representa la petición para asignar un maestro a una materia de un grupo
+type AssignmentRequest struct {
+    TeacherID int `json:"teacher_id" binding:"required,min=1" example:"1"`
+    SubjectID int `json:"subject_id" binding:"required,min=1" example:"1"`
+    GroupID   int `json:"group_id" binding:"required,min=1" example:"1"`
+    TermID    int `json:"term_id" binding:"required,min=1" example:"2"`
+}
+
+// EnrollmentRequest representa la petición para inscribir a un estudiante en una materia
+type EnrollmentRequest struct {
+    StudentID int `json:"student_id" binding:"required,min=1" example:"1"`
+    SubjectID int `json:"subject_id" binding:"required,min=1" example:"1"`
+    TermID    int `json:"term_id" binding:"required,min=1" example:"2"`
+}
+
+// GroupEnrollmentRequest representa la petición para inscribir o dar de baja a un grupo completo
+type GroupEnrollmentRequest struct {
+    GroupID   int `json:"group_id" form:"group_id" binding:"required,min=1" example:"1"`
+    SubjectID int `json:"subject_id" form:"subject_id" binding:"required,min=1" example:"1"`
+    TermID    int `json:"term_id" form:"term_id" binding:"required,min=1" example:"2"`
+}
+
+// GroupEnrollmentResponse resultado de inscribir a un grupo completo
+type GroupEnrollmentResponse struct {
+    Enrolled        int `json:"enrolled" example:"28"`
+    AlreadyEnrolled int `json:"already_enrolled" example:"2"`
+}
+
+// GroupUnenrollmentResponse resultado de dar de baja a un grupo completo
+type GroupUnenrollmentResponse struct {
+    Unenrolled int `json:"unenrolled" example:"28"`
+    // WithGrades son los estudiantes que conservan la inscripción por tener calificaciones
+    WithGrades int `json:"with_grades" example:"2"`
+}
+
+// SubjectCounts conteos de estudiantes inscritos y calificaciones de una materia
+type SubjectCounts struct {
+    StudentCount int64 `json:"student_count" example:"32"`
+    GradeCount   int64 `json:"grade_count" example:"64"`
+}
+
+// SubjectListItem materia del listado, con conteos opcionales
+type SubjectListItem struct {
+    SubjectID int            `json:"subject_id" example:"1"`
+    Name      string         `json:"name" example:"Matemáticas"`
+    Credits   float64        `json:"credits" example:"8"`
+    Counts    *SubjectCounts `json:"counts,omitempty"`
+    // DeletedAt solo aparece en las materias eliminadas, que se listan con include_deleted
+    DeletedAt *time.Time     `json:"deleted_at,omitempty" example:"2025-10-14T16:30:00Z"`
+}
+
+// GradeLockRequest representa la petición para cerrar las calificaciones de una materia de un grupo
+type GradeLockRequest struct {
+    SubjectID int    `json:"subject_id" binding:"required,min=1" example:"1"`
+    GroupID   int    `json:"group_id" binding:"required,min=1" example:"1"`
+    TermID    int    `json:"term_id" binding:"required,min=1" example:"3"`
+    Reason    string `json:"reason" binding:"max=255" example:"Calificaciones entregadas a control escolar"`
+}
+
+// ReopenGradeLockRequest representa la petición para reabrir calificaciones cerradas
+type ReopenGradeLockRequest struct {
+    Reason string `json:"reason" binding:"required,max=255" example:"Corrección de la calificación de un examen extraordinario"`
+}
+
+// RollCallRequest representa la lista de asistencia de un grupo en una fecha. Sin subject_id es
+// la lista diaria del grupo.
+type RollCallRequest struct {
+    GroupID   int            `json:"group_id" binding:"required,min=1" example:"1"`
+    SubjectID *int           `json:"subject_id" binding:"omitempty,min=1" example:"1"`
+    Date      string         `json:"date" binding:"required,datetime=2006-01-02" example:"2025-10-17"`
+    // Records se valida elemento por elemento para reportar cada falla por separado
+    Records   []RollCallItem `json:"records" binding:"required,min=1,max=200"`
+}
+
+// RollCallItem asistencia de un estudiante dentro de una lista
+type RollCallItem struct {
+    StudentID int    `json:"student_id" binding:"required,min=1" example:"1"`
+    Status    string `json:"status" binding:"required,oneof=present absent late justified" example:"absent"`
+    Notes     string `json:"notes" binding:"max=255" example:""`
+}
+
+// RollCallFailure elemento rechazado de una lista de asistencia
+type RollCallFailure struct {
+    // Index es la posición del elemento en records, desde 0
+    Index     int    `json:"index" example:"2"`
+    StudentID int    `json:"student_id" example:"8"`
+    Code      string `json:"code" example:"STUDENT_NOT_IN_GROUP"`
+    Message   string `json:"message" example:"El estudiante no pertenece al grupo"`
+}
+
+// RollCallResponse resultado de una lista de asistencia. Si hay elementos rechazados no se guarda
+// ningún registro y solo Failed tiene datos.
+type RollCallResponse struct {
+    Created []Attendance      `json:"created"`
+    Updated []Attendance      `json:"updated"`
+    Failed  []RollCallFailure `json:"failed"`
+}
+
+// AttendanceUpdateRequest representa la corrección de un registro de asistencia (p. ej. justificar una falta)
+type AttendanceUpdateRequest struct {
+    Status string `json:"status" binding:"required,oneof=present absent late justified" example:"justified"`
+    Notes  string `json:"notes" binding:"max=255" example:"Presentó justificante médico"`
+}
+
+// EvaluationCriteriaRequest representa los criterios de evaluación de una materia en un periodo;
+// reemplaza a los anteriores y sus pesos deben sumar 100
+type EvaluationCriteriaRequest struct {
+    Criteria []EvaluationCriterionItem `json:"criteria" binding:"required,min=1,max=20,dive"`
+    // Reason es el motivo que queda en el historial de las calificaciones recalculadas
+    Reason   string                    `json:"reason" binding:"max=255" example:"Se agregó el proyecto final"`
+}
+
+// EvaluationCriterionItem criterio dentro de EvaluationCriteriaRequest; un criterio existente se
+// identifica por su nombre, sin distinguir mayúsculas
+type EvaluationCriterionItem struct {
+    Name   string  `json:"name" binding:"required,max=50" example:"Examen"`
+    Weight float64 `json:"weight" binding:"required,gt=0,max=100" example:"50"`
+}
+
+// EvaluationCriteriaResponse criterios de evaluación de una materia en un periodo. Grades lista
+// las calificaciones finales recalculadas al cambiar los criterios.
+type EvaluationCriteriaResponse struct {
+    SubjectID int                   `json:"subject_id" example:"1"`
+    TermID    int                   `json:"term_id" example:"3"`
+    Criteria  []EvaluationCriterion `json:"criteria"`
+    Grades    []BatchGradeResult    `json:"grades,omitempty"`
+}
+
+// ComponentScoreRequest representa la captura de un criterio de evaluación para varios
+// estudiantes; grade es la calificación del estudiante en el criterio
+type ComponentScoreRequest struct {
+    CriterionID int              `json:"criterion_id" binding:"required,min=1" example:"1"`
+    // Scores se valida elemento por elemento para reportar cada falla por separado; como en la
+    // captura masiva, una calificación de 0 es válida y solo se rechaza la calificación ausente
+    Scores      []BatchGradeItem `json:"scores" binding:"required,min=1,max=200"`
+    // Reason es el motivo que queda en el historial de cada calificación final modificada
+    Reason      string           `json:"reason" binding:"max=255" example:"Captura del examen del primer parcial"`
+}
+
+// ComponentScoreResponse resultado de la captura de un criterio: las calificaciones del criterio
+// y las calificaciones finales recalculadas
+type ComponentScoreResponse struct {
+    Criterion EvaluationCriterion `json:"criterion"`
+    Scores    []ComponentScore    `json:"scores"`
+    Grades    []BatchGradeResult  `json:"grades"`
+}
+
+// GradeBreakdown desglose de la calificación de un estudiante en una materia y periodo por criterio
+type GradeBreakdown struct {
+    Student    StudentBasic         `json:"student"`
+    Subject    SubjectBasic         `json:"subject"`
+    Term       *TermBasic           `json:"term"`
+    Components []ComponentBreakdown `json:"components"`
+    // Grade es la calificación final; nula si todavía no se captura ningún criterio
+    Grade      *float64             `json:"grade" example:"86.5"`
+}
+
+// ComponentBreakdown calificación de un estudiante en un criterio. Score es nulo si no se ha
+// capturado y cuenta como 0; Contribution son los puntos que aporta a la calificación final.
+type ComponentBreakdown struct {
+    CriterionID  int      `json:"criterion_id" example:"1"`
+    Name         string   `json:"name" example:"Examen"`
+    Weight       float64  `json:"weight" example:"50"`
+    Score        *float64 `json:"score" example:"88"`
+    Contribution float64  `json:"contribution" example:"44"`
+}
+
+// GradingScaleRequest representa la petición para crear o actualizar una escala de calificación
+type GradingScaleRequest struct {
+    Name         string               `json:"name" binding:"required,min=2,max=50" example:"Primaria 5-10"`
+    MinValue     float64              `json:"min_value" binding:"min=0,max=100" example:"5"`
+    MaxValue     float64              `json:"max_value" binding:"required,gt=0,max=100" example:"10"`
+    PassingValue float64              `json:"passing_value" binding:"min=0,max=100" example:"6"`
+    Decimals     int                  `json:"decimals" binding:"min=0,max=2" example:"1"`
+    // Rounding es half_up si se omite
+    Rounding     string               `json:"rounding" binding:"omitempty,oneof=half_up down up" example:"half_up"`
+    Letters      []ScaleLetterRequest `json:"letters" binding:"max=20,dive"`
+}
+
+// ScaleLetterRequest letra o valor cualitativo de una escala
+type ScaleLetterRequest struct {
+    Letter   string  `json:"letter" binding:"required,max=5" example:"A"`
+    Label    string  `json:"label" binding:"max=50" example:"Excelente"`
+    MinValue float64 `json:"min_value" binding:"min=0,max=100" example:"90"`
+}
+
+// GradingScaleAssignmentRequest asigna una escala a un grado escolar, a una materia o a ambos;
+// se requiere al menos uno de grade_level y subject_id
+type GradingScaleAssignmentRequest struct {
+    ScaleID    int  `json:"scale_id" binding:"required,min=1" example:"1"`
+    GradeLevel *int `json:"grade_level" binding:"omitempty,min=1,max=12" example:"3"`
+    SubjectID  *int `json:"subject_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// LoginRequest representa las credenciales para iniciar sesión
+type LoginRequest struct {
+    Username string `json:"username" binding:"required" example:"maestra.lopez"`
+    Password string `json:"password" binding:"required" example:"secreto123"`
+}
+
+// LoginResponse representa el token emitido al iniciar sesión
+type LoginResponse struct {
+    Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+    TokenType string `json:"token_type" example:"Bearer"`
+    ExpiresAt int64  `json:"expires_at" example:"1767225600"`
+    User      User   `json:"user"`
+}
+
+// CreateUserRequest representa la petición para crear una cuenta
+type CreateUserRequest struct {
+    Username  string `json:"username" binding:"required,min=3,max=100" example:"maestra.lopez"`
+    Password  string `json:"password" binding:"required,min=8,max=72" example:"secreto123"`
+    Role      string `json:"role" binding:"required,oneof=admin teacher student parent" example:"teacher"`
+    StudentID *int   `json:"student_id" binding:"omitempty,min=1" example:"1"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+    "gorm.io/gorm"
+)
+
+// Enrollment inscribe a un estudiante en una materia durante un periodo.
+// La inscripción cubre también los subperiodos del periodo (p. ej. los parciales de un semestre).
+type Enrollment struct {
+    EnrollmentID int      `gorm:"primaryKey;autoIncrement" json:"enrollment_id" example:"1"`
+    // Un estudiante se inscribe una sola vez en cada materia por periodo
+    StudentID    int      `gorm:"not null;uniqueIndex:idx_enrollments_student_subject_term,priority:1" json:"student_id" example:"1"`
+    SubjectID    int      `gorm:"not null;index;uniqueIndex:idx_enrollments_student_subject_term,priority:2" json:"subject_id" example:"1"`
+    TermID       int      `gorm:"not null;index;uniqueIndex:idx_enrollments_student_subject_term,priority:3" json:"term_id" example:"2"`
+    
+    Student      *Student `gorm:"belongsTo:Student;foreignKey:StudentID;references:StudentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Subject      *Subject `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Term         *Term    `gorm:"belongsTo:Term;foreignKey:TermID;references:TermID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+}
+
+func (Enrollment) TableName() string {
+    return "enrollments"
+}
+
+// MigrateEnrollment crea la tabla de inscripciones. Al crearla inscribe a cada estudiante en
+// las materias y periodos en los que ya tiene calificaciones, para que sigan siendo válidas.
+func MigrateEnrollment(db *gorm.DB) error {
+    existed := db.Migrator().HasTable(&Enrollment{})
+    if err := db.AutoMigrate(&Enrollment{}); err != nil {
+        return err
+    }
+    if existed {
+        return nil
+    }
+    
+    return db.Exec(
+        "INSERT INTO enrollments (student_id, subject_id, term_id) ?",
+        db.Model(&Grade{}).
+            Select("DISTINCT student_id, subject_id, term_id").
+            Where("term_id IS NOT NULL"),
+    ).Error
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+    "time"
+    
+    "gorm.io/gorm"
+)
+
+// EvaluationCriterion es un componente de la calificación de una materia en un periodo
+// (p. ej. examen 50%, tareas 30%, proyecto 20%). Los pesos de los criterios de una materia en
+// un periodo suman 100; mientras existan, la calificación final se calcula con ellos.
+type EvaluationCriterion struct {
+    CriterionID int       `gorm:"primaryKey;autoIncrement" json:"criterion_id" example:"1"`
+    // Un criterio no se repite en la misma materia y periodo
+    SubjectID   int       `gorm:"not null;uniqueIndex:idx_evaluation_criteria_subject_term_name,priority:1" json:"subject_id" example:"1"`
+    TermID      int       `gorm:"not null;index;uniqueIndex:idx_evaluation_criteria_subject_term_name,priority:2" json:"term_id" example:"3"`
+    Name        string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_evaluation_criteria_subject_term_name,priority:3" json:"name" example:"Examen"`
+    // Weight es el porcentaje de la calificación final que aporta el criterio
+    Weight      float64   `gorm:"type:decimal(5,2);not null" json:"weight" example:"50"`
+    CreatedAt   time.Time `json:"created_at"`
+    UpdatedAt   time.Time `json:"updated_at"`
+    
+    Subject     *Subject  `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Term        *Term     `gorm:"belongsTo:Term;foreignKey:TermID;references:TermID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+}
+
+func (EvaluationCriterion) TableName() string {
+    return "evaluation_criteria"
+}
+
+// ComponentScore es la calificación de un estudiante en un criterio de evaluación
+type ComponentScore struct {
+    ScoreID        int       `gorm:"primaryKey;autoIncrement" json:"score_id" example:"1"`
+    // Un estudiante tiene a lo sumo una calificación por criterio
+    StudentID      int       `gorm:"not null;uniqueIndex:idx_component_scores_student_criterion,priority:1" json:"student_id" example:"1"`
+    CriterionID    int       `gorm:"not null;index;uniqueIndex:idx_component_scores_student_criterion,priority:2" json:"criterion_id" example:"1"`
+    Score          float64   `gorm:"type:decimal(5,2);not null" json:"score" example:"88.5"`
+    // RecordedBy es la cuenta que capturó la calificación por última vez; el nombre de usuario se
+    // guarda aparte para conservarlo aunque la cuenta se elimine
+    RecordedBy     *int      `gorm:"index" json:"recorded_by" example:"2"`
+    RecordedByName string    `gorm:"type:varchar(100);not null" json:"recorded_by_name" example:"maestra.lopez"`
+    CreatedAt      time.Time `json:"created_at"`
+    UpdatedAt      time.Time `json:"updated_at"`
+    
+    Student        *Student             `gorm:"belongsTo:Student;foreignKey:StudentID;references:StudentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Criterion      *EvaluationCriterion `gorm:"belongsTo:EvaluationCriterion;foreignKey:CriterionID;references:CriterionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    User           *User                `gorm:"belongsTo:User;foreignKey:RecordedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (ComponentScore) TableName() string {
+    return "component_scores"
+}
+
+func MigrateEvaluation(db *gorm.DB) error {
+    return db.AutoMigrate(&EvaluationCriterion{}, &ComponentScore{})
+}
@@ -0,0 +1,26 @@
+package models
+
+// StudentExportRow fila de la exportación de estudiantes
+type StudentExportRow struct {
+    StudentID  int
+    Name       string
+    Email      string
+    GroupID    *int
+    Group      *string `gorm:"column:group_name"`
+    SchoolYear *string
+}
+
+// GradeExportRow fila de la exportación de calificaciones, con los nombres de cada relación
+type GradeExportRow struct {
+    GradeID     int
+    StudentID   int
+    StudentName string
+    GroupID     *int
+    Group       *string `gorm:"column:group_name"`
+    SubjectID   int
+    SubjectName string
+    TermID      *int
+    TermName    *string
+    SchoolYear  *string
+    Grade       float64
+}
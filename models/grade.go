@@ -1,51 +1,222 @@
 package models
 
 import (
+    "fmt"
+    "time"
+    
     "gorm.io/gorm"
 )
 
+// sqliteDialect es el nombre que GORM reporta para el driver de SQLite
+const sqliteDialect = "sqlite"
+
 // Grade representa una calificación en el sistema
 type Grade struct {
     GradeID   int      `gorm:"primaryKey;autoIncrement" json:"grade_id" example:"1"`
-    StudentID int      `gorm:"not null;index" json:"student_id" binding:"required,min=1" example:"1"`
-    SubjectID int      `gorm:"not null;index" json:"subject_id" binding:"required,min=1" example:"1"`
-    Grade     float64  `gorm:"type:decimal(5,2);not null" json:"grade" binding:"required,min=0,max=100" example:"95.5"`
+    // Un estudiante tiene a lo sumo una calificación por materia y periodo
+    StudentID int      `gorm:"not null;index;uniqueIndex:idx_grades_student_subject_term,priority:1" json:"student_id" binding:"required,min=1" example:"1"`
+    SubjectID int      `gorm:"not null;index;uniqueIndex:idx_grades_student_subject_term,priority:2" json:"subject_id" binding:"required,min=1" example:"1"`
+    Grade     float64  `gorm:"type:decimal(5,2);not null" json:"grade" binding:"min=0,max=100" example:"95.5"`
+    // TermID es el periodo académico de la calificación. Las calificaciones previas a los periodos
+    // se asignan al periodo LegacyTermName al migrar, así que ninguna queda sin periodo
+    TermID    *int     `gorm:"index;uniqueIndex:idx_grades_student_subject_term,priority:3" json:"term_id" example:"3"`
+    // DeletedAt marca la calificación como eliminada; sigue ocupando su llave de estudiante,
+    // materia y periodo hasta que se restaure
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+
+    // Relaciones usadas para declarar las llaves foráneas de forma portable entre dialectos
+    Student   *Student `gorm:"belongsTo:Student;foreignKey:StudentID;references:StudentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Subject   *Subject `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Term      *Term    `gorm:"belongsTo:Term;foreignKey:TermID;references:TermID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-" swaggerignore:"true"`
 }
 
 func (Grade) TableName() string {
     return "grades"
 }
 
+// LegacyTermName es el nombre del ciclo escolar al que se asignan las calificaciones
+// registradas antes de que existieran los periodos
+const LegacyTermName = "Calificaciones previas a los periodos"
+
 func MigrateGrade(db *gorm.DB) error {
-    return db.AutoMigrate(&Grade{})
+    if err := checkDuplicateGrades(db); err != nil {
+        return err
+    }
+    if err := db.AutoMigrate(&Grade{}); err != nil {
+        return err
+    }
+    return assignLegacyTerm(db)
 }
 
-func AddForeignKeys(db *gorm.DB) error {
-    if err := db.Exec(`
-        ALTER TABLE grades 
-        ADD CONSTRAINT fk_grades_student 
-        FOREIGN KEY (student_id) 
-        REFERENCES students(student_id) 
-        ON DELETE CASCADE 
-        ON UPDATE CASCADE
-    `).Error; err != nil {
-        if !db.Migrator().HasConstraint(&Grade{}, "fk_grades_student") {
+// assignLegacyTerm asigna las calificaciones sin periodo al ciclo escolar LegacyTermName, que se
+// crea con las fechas del ciclo actual. Sin periodo el índice único no las protege (NULL nunca
+// es igual a NULL) y no pasan las validaciones de inscripción, asignación ni cierre, así que no
+// se podrían editar desde la API. Si ya existe la tabla de inscripciones también inscribe a
+// cada estudiante en las materias de sus calificaciones en ese periodo.
+func assignLegacyTerm(db *gorm.DB) error {
+    var pending int64
+    if err := db.Unscoped().Model(&Grade{}).Where("term_id IS NULL").Count(&pending).Error; err != nil {
+        return err
+    }
+    if pending == 0 {
+        return nil
+    }
+    
+    // Dos calificaciones sin periodo de la misma materia violarían el índice único
+    var duplicates int64
+    err := db.Table("(?) AS duplicated",
+        db.Unscoped().Model(&Grade{}).
+            Select("student_id, subject_id").
+            Where("term_id IS NULL").
+            Group("student_id, subject_id").
+            Having("COUNT(*) > 1"),
+    ).Count(&duplicates).Error
+    if err != nil {
+        return err
+    }
+    if duplicates > 0 {
+        return fmt.Errorf("hay %d combinaciones de estudiante y materia con varias calificaciones sin periodo; elimine los duplicados antes de migrar", duplicates)
+    }
+    
+    now := time.Now()
+    start := schoolYearStart(now)
+    
+    return db.Transaction(func(tx *gorm.DB) error {
+        term := Term{
+            Name:       LegacyTermName,
+            SchoolYear: SchoolYearOf(now),
+            Kind:       TermKindSchoolYear,
+            StartDate:  start,
+            EndDate:    start.AddDate(1, 0, -1),
+        }
+        err := tx.Where(Term{Name: term.Name, Kind: term.Kind}).FirstOrCreate(&term).Error
+        if err != nil {
             return err
         }
+        
+        if tx.Migrator().HasTable(&Enrollment{}) {
+            err = tx.Exec(
+                "INSERT INTO enrollments (student_id, subject_id, term_id) ?",
+                tx.Unscoped().Model(&Grade{}).
+                    Select("DISTINCT student_id, subject_id, ?", term.TermID).
+                    Where("term_id IS NULL").
+                    Where("NOT EXISTS (SELECT 1 FROM enrollments WHERE enrollments.student_id = grades.student_id AND enrollments.subject_id = grades.subject_id AND enrollments.term_id = ?)", term.TermID),
+            ).Error
+            if err != nil {
+                return err
+            }
+        }
+        
+        return tx.Unscoped().Model(&Grade{}).
+            Where("term_id IS NULL").
+            Update("term_id", term.TermID).Error
+    })
+}
+
+// checkDuplicateGrades evita que la creación del índice único falle con un error
+// poco claro cuando ya existen calificaciones repetidas para la misma materia y periodo
+func checkDuplicateGrades(db *gorm.DB) error {
+    migrator := db.Migrator()
+    if !migrator.HasTable(&Grade{}) || !migrator.HasColumn(&Grade{}, "TermID") ||
+        migrator.HasIndex(&Grade{}, "idx_grades_student_subject_term") {
+        return nil
     }
     
-    if err := db.Exec(`
-        ALTER TABLE grades 
-        ADD CONSTRAINT fk_grades_subject 
-        FOREIGN KEY (subject_id) 
-        REFERENCES subjects(subject_id) 
-        ON DELETE CASCADE 
-        ON UPDATE CASCADE
-    `).Error; err != nil {
-        if !db.Migrator().HasConstraint(&Grade{}, "fk_grades_subject") {
+    var duplicates int64
+    err := db.Table("(?) AS duplicated",
+        db.Model(&Grade{}).
+            Select("student_id, subject_id, term_id").
+            Where("term_id IS NOT NULL").
+            Group("student_id, subject_id, term_id").
+            Having("COUNT(*) > 1"),
+    ).Count(&duplicates).Error
+    if err != nil {
+        return err
+    }
+    if duplicates > 0 {
+        return fmt.Errorf("hay %d combinaciones de estudiante, materia y periodo con calificaciones duplicadas; elimine los duplicados antes de migrar", duplicates)
+    }
+    return nil
+}
+
+// AddForeignKeys crea las llaves foráneas si todavía no existen.
+// Las constraints se toman de las relaciones de los modelos (fk_grades_student,
+// fk_grades_subject, fk_grades_term...), por lo que GORM genera el SQL adecuado
+// para MySQL, PostgreSQL o SQLite.
+func AddForeignKeys(db *gorm.DB) error {
+    return withoutForeignKeys(db, func(tx *gorm.DB) error {
+        if err := ensureConstraints(tx, &Group{}, "Homeroom"); err != nil {
+            return err
+        }
+        if err := ensureConstraints(tx, &Student{}, "Group"); err != nil {
+            return err
+        }
+        if err := ensureConstraints(tx, &Term{}, "Parent"); err != nil {
             return err
         }
+        return ensureConstraints(tx, &Grade{}, "Student", "Subject", "Term")
+    })
+}
+
+// ensureConstraints crea las constraints indicadas de un modelo que falten en la base de datos
+func ensureConstraints(db *gorm.DB, model interface{}, names ...string) error {
+    migrator := db.Migrator()
+    created := false
+    for _, name := range names {
+        if migrator.HasConstraint(model, name) {
+            continue
+        }
+        if err := migrator.CreateConstraint(model, name); err != nil {
+            return err
+        }
+        created = true
+    }
+    if !created {
+        return nil
+    }
+    
+    // SQLite crea la constraint recreando la tabla, lo que descarta sus índices
+    return ensureIndexes(db, model)
+}
+
+// ensureIndexes crea los índices declarados en un modelo que falten en la base de datos
+func ensureIndexes(db *gorm.DB, model interface{}) error {
+    stmt := &gorm.Statement{DB: db}
+    if err := stmt.Parse(model); err != nil {
+        return err
     }
     
+    migrator := db.Migrator()
+    for _, index := range stmt.Schema.ParseIndexes() {
+        if migrator.HasIndex(model, index.Name) {
+            continue
+        }
+        if err := migrator.CreateIndex(model, index.Name); err != nil {
+            return err
+        }
+    }
     return nil
-}
\ No newline at end of file
+}
+
+// withoutForeignKeys ejecuta fn con la validación de llaves foráneas desactivada en SQLite.
+// SQLite agrega columnas con llave foránea y constraints recreando la tabla, y el DROP TABLE
+// de la tabla original dispararía los ON DELETE CASCADE de las tablas que la referencian.
+// En MySQL y PostgreSQL fn se ejecuta sin cambios.
+func withoutForeignKeys(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+    if db.Dialector.Name() != sqliteDialect {
+        return fn(db)
+    }
+    
+    // El PRAGMA aplica a una sola conexión, así que todo se ejecuta sobre la misma
+    return db.Connection(func(tx *gorm.DB) error {
+        if err := tx.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+            return err
+        }
+        // Session evita que las consultas de fn compartan la misma sentencia
+        err := fn(tx.Session(&gorm.Session{}))
+        if restoreErr := tx.Exec("PRAGMA foreign_keys = ON").Error; err == nil {
+            err = restoreErr
+        }
+        return err
+    })
+}
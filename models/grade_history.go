@@ -0,0 +1,64 @@
+package models
+
+import (
+    "time"
+    
+    "gorm.io/gorm"
+)
+
+// Acciones registradas en el historial de calificaciones
+const (
+    GradeActionCreate  = "create"
+    GradeActionUpdate  = "update"
+    GradeActionDelete  = "delete"
+    GradeActionRestore = "restore"
+)
+
+// GradeHistory es un cambio en el valor de una calificación. La tabla solo admite inserciones:
+// los registros no se modifican ni se eliminan.
+type GradeHistory struct {
+    HistoryID     int       `gorm:"primaryKey;autoIncrement" json:"history_id" example:"1"`
+    // GradeID no tiene llave foránea para que el historial se conserve al eliminar la calificación
+    GradeID       int       `gorm:"not null;index" json:"grade_id" example:"7"`
+    Action        string    `gorm:"type:varchar(10);not null" json:"action" example:"update"`
+    // OldGrade es nulo al crear o restaurar la calificación y NewGrade al eliminarla
+    OldGrade      *float64  `gorm:"type:decimal(5,2)" json:"old_grade" example:"75"`
+    NewGrade      *float64  `gorm:"type:decimal(5,2)" json:"new_grade" example:"82.5"`
+    // ChangedBy es la cuenta que hizo el cambio; el nombre de usuario se guarda aparte para
+    // conservarlo aunque la cuenta se elimine
+    ChangedBy     *int      `gorm:"index" json:"changed_by" example:"2"`
+    ChangedByName string    `gorm:"type:varchar(100);not null" json:"changed_by_name" example:"maestra.lopez"`
+    Reason        string    `gorm:"type:varchar(255);not null;default:''" json:"reason" example:"Revisión del examen solicitada por el tutor"`
+    ChangedAt     time.Time `gorm:"not null;index" json:"changed_at" example:"2025-10-14T16:30:00Z"`
+    
+    User          *User     `gorm:"belongsTo:User;foreignKey:ChangedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (GradeHistory) TableName() string {
+    return "grade_history"
+}
+
+// GradeChange identifica quién modifica una calificación y por qué, para registrarlo en el historial
+type GradeChange struct {
+    UserID   *int
+    Username string
+    Reason   string
+}
+
+// NewGradeHistory crea el registro de un cambio de oldGrade a newGrade en la calificación gradeID
+func NewGradeHistory(gradeID int, action string, oldGrade, newGrade *float64, change GradeChange) GradeHistory {
+    return GradeHistory{
+        GradeID:       gradeID,
+        Action:        action,
+        OldGrade:      oldGrade,
+        NewGrade:      newGrade,
+        ChangedBy:     change.UserID,
+        ChangedByName: change.Username,
+        Reason:        change.Reason,
+        ChangedAt:     time.Now().UTC(),
+    }
+}
+
+func MigrateGradeHistory(db *gorm.DB) error {
+    return db.AutoMigrate(&GradeHistory{})
+}
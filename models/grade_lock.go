@@ -0,0 +1,80 @@
+package models
+
+import (
+    "time"
+    
+    "gorm.io/gorm"
+)
+
+// Acciones registradas en la bitácora de cierres de calificaciones
+const (
+    GradeLockActionClose  = "close"
+    GradeLockActionReopen = "reopen"
+)
+
+// GradeLock cierra las calificaciones de una materia en un grupo durante un periodo.
+// El cierre cubre también los subperiodos del periodo (p. ej. los parciales de un semestre);
+// mientras exista, esas calificaciones no se pueden crear, modificar, eliminar ni restaurar.
+type GradeLock struct {
+    LockID       int       `gorm:"primaryKey;autoIncrement" json:"lock_id" example:"1"`
+    // Una materia de un grupo se cierra una sola vez por periodo
+    SubjectID    int       `gorm:"not null;uniqueIndex:idx_grade_locks_subject_group_term,priority:1" json:"subject_id" example:"1"`
+    GroupID      int       `gorm:"not null;index;uniqueIndex:idx_grade_locks_subject_group_term,priority:2" json:"group_id" example:"1"`
+    TermID       int       `gorm:"not null;index;uniqueIndex:idx_grade_locks_subject_group_term,priority:3" json:"term_id" example:"3"`
+    // ClosedBy es la cuenta que cerró las calificaciones; el nombre de usuario se guarda aparte
+    // para conservarlo aunque la cuenta se elimine
+    ClosedBy     *int      `gorm:"index" json:"closed_by" example:"2"`
+    ClosedByName string    `gorm:"type:varchar(100);not null" json:"closed_by_name" example:"maestra.lopez"`
+    ClosedAt     time.Time `gorm:"not null" json:"closed_at" example:"2025-10-17T18:00:00Z"`
+    
+    Subject      *Subject  `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Group        *Group    `gorm:"belongsTo:Group;foreignKey:GroupID;references:GroupID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Term         *Term     `gorm:"belongsTo:Term;foreignKey:TermID;references:TermID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    User         *User     `gorm:"belongsTo:User;foreignKey:ClosedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (GradeLock) TableName() string {
+    return "grade_locks"
+}
+
+// GradeLockEvent es un cierre o una reapertura de calificaciones. La tabla solo admite
+// inserciones: los registros no se modifican ni se eliminan.
+type GradeLockEvent struct {
+    EventID   int       `gorm:"primaryKey;autoIncrement" json:"event_id" example:"1"`
+    // LockID y la materia, grupo y periodo no tienen llave foránea para que la bitácora se
+    // conserve al reabrir o al eliminar los registros
+    LockID    int       `gorm:"not null;index" json:"lock_id" example:"1"`
+    SubjectID int       `gorm:"not null" json:"subject_id" example:"1"`
+    GroupID   int       `gorm:"not null" json:"group_id" example:"1"`
+    TermID    int       `gorm:"not null;index" json:"term_id" example:"3"`
+    Action    string    `gorm:"type:varchar(10);not null" json:"action" example:"reopen"`
+    UserID    *int      `gorm:"index" json:"user_id" example:"1"`
+    Username  string    `gorm:"type:varchar(100);not null" json:"username" example:"admin"`
+    Reason    string    `gorm:"type:varchar(255);not null;default:''" json:"reason" example:"Corrección de la calificación de un examen extraordinario"`
+    CreatedAt time.Time `gorm:"not null;index" json:"created_at" example:"2025-10-20T09:15:00Z"`
+    
+    User      *User     `gorm:"belongsTo:User;foreignKey:UserID;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (GradeLockEvent) TableName() string {
+    return "grade_lock_events"
+}
+
+// NewGradeLockEvent crea el registro de una acción sobre el cierre lock
+func NewGradeLockEvent(lock GradeLock, action string, change GradeChange) GradeLockEvent {
+    return GradeLockEvent{
+        LockID:    lock.LockID,
+        SubjectID: lock.SubjectID,
+        GroupID:   lock.GroupID,
+        TermID:    lock.TermID,
+        Action:    action,
+        UserID:    change.UserID,
+        Username:  change.Username,
+        Reason:    change.Reason,
+        CreatedAt: time.Now().UTC(),
+    }
+}
+
+func MigrateGradeLock(db *gorm.DB) error {
+    return db.AutoMigrate(&GradeLock{}, &GradeLockEvent{})
+}
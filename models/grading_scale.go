@@ -0,0 +1,156 @@
+package models
+
+import (
+    "math"
+    "sort"
+    "time"
+    
+    "gorm.io/gorm"
+)
+
+// Reglas de redondeo de las escalas de calificación
+const (
+    RoundingHalfUp = "half_up"
+    RoundingDown   = "down"
+    RoundingUp     = "up"
+)
+
+// GradingScale define cómo se capturan y se muestran las calificaciones de un grado escolar o una
+// materia. Las calificaciones se guardan de 0 a 100; la escala las convierte de forma proporcional
+// a su valor máximo (p. ej. 85 es 8.5 en una escala de 5 a 10), con un valor mínimo, un valor
+// aprobatorio, un número de decimales y una regla de redondeo. Las letras, si las tiene, asignan a
+// cada valor la letra con el mayor valor mínimo que alcanza.
+type GradingScale struct {
+    ScaleID      int           `gorm:"primaryKey;autoIncrement" json:"scale_id" example:"1"`
+    Name         string        `gorm:"type:varchar(50);not null;uniqueIndex" json:"name" example:"Primaria 5-10"`
+    MinValue     float64       `gorm:"type:decimal(5,2);not null" json:"min_value" example:"5"`
+    MaxValue     float64       `gorm:"type:decimal(5,2);not null" json:"max_value" example:"10"`
+    PassingValue float64       `gorm:"type:decimal(5,2);not null" json:"passing_value" example:"6"`
+    Decimals     int           `gorm:"not null" json:"decimals" example:"1"`
+    Rounding     string        `gorm:"type:varchar(10);not null" json:"rounding" example:"half_up"`
+    Letters      []ScaleLetter `gorm:"foreignKey:ScaleID;references:ScaleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"letters"`
+    CreatedAt    time.Time     `json:"created_at"`
+    UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+func (GradingScale) TableName() string {
+    return "grading_scales"
+}
+
+// ScaleLetter es una letra o valor cualitativo de una escala, desde un valor mínimo de la escala
+type ScaleLetter struct {
+    LetterID int     `gorm:"primaryKey;autoIncrement" json:"-"`
+    ScaleID  int     `gorm:"not null;uniqueIndex:idx_scale_letters_scale_letter,priority:1" json:"-"`
+    Letter   string  `gorm:"type:varchar(5);not null;uniqueIndex:idx_scale_letters_scale_letter,priority:2" json:"letter" example:"A"`
+    Label    string  `gorm:"type:varchar(50);not null;default:''" json:"label" example:"Excelente"`
+    MinValue float64 `gorm:"type:decimal(5,2);not null" json:"min_value" example:"90"`
+}
+
+func (ScaleLetter) TableName() string {
+    return "scale_letters"
+}
+
+// GradingScaleAssignment asigna una escala a un grado escolar, a una materia o a una materia en un
+// grado escolar. Para una calificación se usa la asignación más específica: materia y grado, luego
+// materia, luego grado; sin asignación se usa la escala de 0 a 100.
+type GradingScaleAssignment struct {
+    AssignmentID int           `gorm:"primaryKey;autoIncrement" json:"assignment_id" example:"1"`
+    ScaleID      int           `gorm:"not null;index" json:"scale_id" example:"1"`
+    GradeLevel   *int          `gorm:"index" json:"grade_level" example:"3"`
+    SubjectID    *int          `gorm:"index" json:"subject_id" example:"1"`
+    CreatedAt    time.Time     `json:"created_at"`
+    
+    Scale        *GradingScale `gorm:"belongsTo:GradingScale;foreignKey:ScaleID;references:ScaleID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-" swaggerignore:"true"`
+    Subject      *Subject      `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+}
+
+func (GradingScaleAssignment) TableName() string {
+    return "grading_scale_assignments"
+}
+
+// ScaledGrade representación de una calificación en su escala
+type ScaledGrade struct {
+    // ScaleID es nulo con la escala predeterminada de 0 a 100
+    ScaleID *int    `json:"scale_id" example:"1"`
+    Scale   string  `json:"scale" example:"Primaria 5-10"`
+    Value   float64 `json:"value" example:"8.5"`
+    Letter  string  `json:"letter,omitempty" example:"B"`
+    Label   string  `json:"label,omitempty" example:"Muy bien"`
+    Passed  bool    `json:"passed" example:"true"`
+}
+
+// DefaultGradingScale es la escala de 0 a 100 que se usa sin asignación, con el valor aprobatorio configurado
+func DefaultGradingScale(passingGrade float64) GradingScale {
+    return GradingScale{
+        Name:         "0-100",
+        MinValue:     0,
+        MaxValue:     100,
+        PassingValue: passingGrade,
+        Decimals:     2,
+        Rounding:     RoundingHalfUp,
+    }
+}
+
+// InRange indica si value es una calificación válida en la escala
+func (s GradingScale) InRange(value float64) bool {
+    return value >= s.MinValue && value <= s.MaxValue
+}
+
+// ToPercentage convierte un valor de la escala a la calificación de 0 a 100 que se guarda
+func (s GradingScale) ToPercentage(value float64) float64 {
+    return math.Round(value/s.MaxValue*100*100) / 100
+}
+
+// Convert representa en la escala una calificación de 0 a 100. Los valores menores al mínimo de
+// la escala se muestran como el mínimo.
+func (s GradingScale) Convert(grade float64) ScaledGrade {
+    return s.Represent(grade * s.MaxValue / 100)
+}
+
+// Represent redondea un valor de la escala con sus decimales y su regla de redondeo y le asigna su
+// letra. Los valores menores al mínimo de la escala se muestran como el mínimo.
+func (s GradingScale) Represent(value float64) ScaledGrade {
+    value = s.round(value)
+    if value < s.MinValue {
+        value = s.MinValue
+    }
+    
+    scaled := ScaledGrade{
+        Scale:  s.Name,
+        Value:  value,
+        Passed: value >= s.PassingValue,
+    }
+    if s.ScaleID != 0 {
+        scaleID := s.ScaleID
+        scaled.ScaleID = &scaleID
+    }
+    
+    letters := append([]ScaleLetter(nil), s.Letters...)
+    sort.Slice(letters, func(i, j int) bool { return letters[i].MinValue > letters[j].MinValue })
+    for _, letter := range letters {
+        if value >= letter.MinValue {
+            scaled.Letter = letter.Letter
+            scaled.Label = letter.Label
+            break
+        }
+    }
+    return scaled
+}
+
+// round redondea value a los decimales de la escala con su regla de redondeo
+func (s GradingScale) round(value float64) float64 {
+    factor := math.Pow(10, float64(s.Decimals))
+    // El margen evita que errores de punto flotante (p. ej. 8.0000001 u 8.5499999) cambien el resultado
+    switch s.Rounding {
+    case RoundingDown:
+        return math.Floor(value*factor+1e-9) / factor
+    case RoundingUp:
+        return math.Ceil(value*factor-1e-9) / factor
+    default:
+        return math.Floor(value*factor+0.5+1e-9) / factor
+    }
+}
+
+func MigrateGradingScale(db *gorm.DB) error {
+    return db.AutoMigrate(&GradingScale{}, &ScaleLetter{}, &GradingScaleAssignment{})
+}
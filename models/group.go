@@ -0,0 +1,132 @@
+package models
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+    
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// Turnos de un grupo
+const (
+    ShiftMorning   = "morning"
+    ShiftAfternoon = "afternoon"
+    ShiftEvening   = "evening"
+)
+
+// Shifts lista de turnos válidos
+var Shifts = []string{ShiftMorning, ShiftAfternoon, ShiftEvening}
+
+// Group representa un grupo (grado y sección) de un ciclo escolar
+type Group struct {
+    GroupID           int      `gorm:"primaryKey;autoIncrement" json:"group_id" example:"1"`
+    // Un ciclo escolar no puede tener dos grupos con el mismo grado, sección y turno
+    SchoolYear        string   `gorm:"type:varchar(20);not null;uniqueIndex:idx_groups_year_level_section_shift,priority:1" json:"school_year" example:"2025-2026"`
+    GradeLevel        int      `gorm:"not null;uniqueIndex:idx_groups_year_level_section_shift,priority:2" json:"grade_level" example:"5"`
+    Section           string   `gorm:"type:varchar(5);not null;uniqueIndex:idx_groups_year_level_section_shift,priority:3" json:"section" example:"A"`
+    Shift             string   `gorm:"type:varchar(20);not null;default:morning;uniqueIndex:idx_groups_year_level_section_shift,priority:4" json:"shift" example:"morning"`
+    // Name se deriva del grado y la sección (p. ej. "5A")
+    Name              string   `gorm:"type:varchar(10);not null;index" json:"name" example:"5A"`
+    // HomeroomTeacherID es el maestro titular del grupo
+    HomeroomTeacherID *int     `gorm:"index" json:"homeroom_teacher_id" example:"2"`
+    
+    // La llave foránea del titular se crea en AddForeignKeys: teachers depende de users, users
+    // de students y students de groups, así que no puede declararse al crear la tabla
+    Homeroom          *Teacher `gorm:"-:migration;belongsTo:Teacher;foreignKey:HomeroomTeacherID;references:TeacherID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (Group) TableName() string {
+    return "groups"
+}
+
+// GroupName construye el nombre corto de un grupo a partir de su grado y sección
+func GroupName(gradeLevel int, section string) string {
+    return fmt.Sprintf("%d%s", gradeLevel, section)
+}
+
+// SchoolYearOf devuelve el ciclo escolar ("2025-2026") al que pertenece una fecha;
+// los ciclos comienzan en agosto
+func SchoolYearOf(date time.Time) string {
+    year := schoolYearStart(date).Year()
+    return fmt.Sprintf("%d-%d", year, year+1)
+}
+
+// schoolYearStart devuelve el 1 de agosto con el que comienza el ciclo escolar de una fecha
+func schoolYearStart(date time.Time) time.Time {
+    year := date.Year()
+    if date.Month() < time.August {
+        year--
+    }
+    return time.Date(year, time.August, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func MigrateGroup(db *gorm.DB) error {
+    return db.AutoMigrate(&Group{})
+}
+
+// legacyGroupPattern reconoce los grupos capturados como texto libre: "5A", "5 a", "5-A"
+var legacyGroupPattern = regexp.MustCompile(`^(\d{1,2})\s*-?\s*([A-Za-z]{1,5})$`)
+
+// migrateLegacyGroups convierte la antigua columna de texto students.group en registros
+// de groups, asigna group_id a cada estudiante y elimina la columna. Los grupos creados
+// pertenecen al ciclo escolar actual y al turno matutino.
+func migrateLegacyGroups(db *gorm.DB) error {
+    legacyColumn := clause.Column{Name: "group"}
+    if !db.Migrator().HasColumn(&Student{}, legacyColumn.Name) {
+        return nil
+    }
+    
+    var values []string
+    if err := db.Model(&Student{}).Distinct().Pluck(legacyColumn.Name, &values).Error; err != nil {
+        return err
+    }
+    
+    var invalid []string
+    for _, value := range values {
+        if !legacyGroupPattern.MatchString(strings.TrimSpace(value)) {
+            invalid = append(invalid, strconv.Quote(value))
+        }
+    }
+    if len(invalid) > 0 {
+        return fmt.Errorf("no se pudieron convertir los grupos %s; corríjalos con el formato grado y sección (p. ej. 5A) antes de migrar", strings.Join(invalid, ", "))
+    }
+    
+    schoolYear := SchoolYearOf(time.Now())
+    return db.Transaction(func(tx *gorm.DB) error {
+        for _, value := range values {
+            match := legacyGroupPattern.FindStringSubmatch(strings.TrimSpace(value))
+            gradeLevel, _ := strconv.Atoi(match[1])
+            group := Group{
+                SchoolYear: schoolYear,
+                GradeLevel: gradeLevel,
+                Section:    strings.ToUpper(match[2]),
+                Shift:      ShiftMorning,
+            }
+            group.Name = GroupName(group.GradeLevel, group.Section)
+            
+            // "5A", "5a" y "5 A" terminan en el mismo grupo
+            err := tx.Where(Group{
+                SchoolYear: group.SchoolYear,
+                GradeLevel: group.GradeLevel,
+                Section:    group.Section,
+                Shift:      group.Shift,
+            }).FirstOrCreate(&group).Error
+            if err != nil {
+                return err
+            }
+            
+            err = tx.Model(&Student{}).
+                Where(clause.Eq{Column: legacyColumn, Value: value}).
+                Update("group_id", group.GroupID).Error
+            if err != nil {
+                return err
+            }
+        }
+        
+        return tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: Student{}.TableName()}, legacyColumn).Error
+    })
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+    "time"
+    
+    "gorm.io/gorm"
+)
+
+// Parentescos de un tutor con un estudiante
+const (
+    RelationshipMother   = "mother"
+    RelationshipFather   = "father"
+    RelationshipGuardian = "guardian"
+    RelationshipOther    = "other"
+)
+
+// Guardian representa a un padre, madre o tutor de uno o varios estudiantes
+type Guardian struct {
+    GuardianID int     `gorm:"primaryKey;autoIncrement" json:"guardian_id" example:"1"`
+    Name       string  `gorm:"type:varchar(100);not null" json:"name" example:"Rosa Martínez"`
+    Email      *string `gorm:"type:varchar(100);unique" json:"email" example:"rosa.martinez@correo.com"`
+    Phone      string  `gorm:"type:varchar(20);not null;default:''" json:"phone" example:"5551234567"`
+    // UserID vincula al tutor con su cuenta de acceso con rol parent; sin cuenta no puede
+    // consultar las calificaciones ni la asistencia de sus estudiantes
+    UserID     *int    `gorm:"uniqueIndex" json:"user_id" example:"5"`
+    
+    User       *User   `gorm:"belongsTo:User;foreignKey:UserID;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (Guardian) TableName() string {
+    return "guardians"
+}
+
+// GuardianStudent vincula a un tutor con un estudiante. Un estudiante puede tener varios tutores
+// y un tutor varios estudiantes.
+type GuardianStudent struct {
+    GuardianID   int       `gorm:"primaryKey;autoIncrement:false" json:"guardian_id" example:"1"`
+    StudentID    int       `gorm:"primaryKey;autoIncrement:false;index" json:"student_id" example:"1"`
+    Relationship string    `gorm:"type:varchar(20);not null" json:"relationship" example:"mother"`
+    CreatedAt    time.Time `json:"created_at"`
+    
+    Guardian     *Guardian `gorm:"belongsTo:Guardian;foreignKey:GuardianID;references:GuardianID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Student      *Student  `gorm:"belongsTo:Student;foreignKey:StudentID;references:StudentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+}
+
+func (GuardianStudent) TableName() string {
+    return "guardian_students"
+}
+
+func MigrateGuardian(db *gorm.DB) error {
+    return db.AutoMigrate(&Guardian{}, &GuardianStudent{})
+}
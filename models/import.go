@@ -0,0 +1,20 @@
+package models
+
+// ImportRowError describe un problema en una fila del archivo importado
+type ImportRowError struct {
+    // Row es el número de fila en el archivo; el encabezado es la fila 1
+    Row     int    `json:"row" example:"4"`
+    Field   string `json:"field,omitempty" example:"email"`
+    Value   string `json:"value,omitempty" example:"maria.garcia@escuela.com"`
+    Message string `json:"message" example:"ya existe un estudiante con ese email"`
+}
+
+// ImportReport resume el resultado de una importación masiva
+type ImportReport struct {
+    DryRun    bool             `json:"dry_run" example:"false"`
+    TotalRows int              `json:"total_rows" example:"120"`
+    ValidRows int              `json:"valid_rows" example:"118"`
+    // Imported es el número de registros creados; siempre es 0 en modo de prueba o si hubo errores
+    Imported  int              `json:"imported" example:"0"`
+    Errors    []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+    "fmt"
+    
+    "gorm.io/gorm"
+)
+
+// migrations son las migraciones de las tablas en el orden en que deben ejecutarse: cada tabla
+// después de las tablas a las que hace referencia
+var migrations = []struct {
+    name    string
+    migrate func(*gorm.DB) error
+}{
+    {"grupos", MigrateGroup},
+    {"estudiantes", MigrateStudent},
+    {"materias", MigrateSubject},
+    {"periodos", MigrateTerm},
+    {"calificaciones", MigrateGrade},
+    {"inscripciones", MigrateEnrollment},
+    {"usuarios", MigrateUser},
+    {"maestros", MigrateTeacher},
+    {"historial de calificaciones", MigrateGradeHistory},
+    {"cierres de calificaciones", MigrateGradeLock},
+    {"asistencia", MigrateAttendance},
+    {"criterios de evaluación", MigrateEvaluation},
+    {"escalas de calificación", MigrateGradingScale},
+    {"tutores", MigrateGuardian},
+}
+
+// MigrateAll crea o actualiza todas las tablas. Las llaves foráneas entre tablas se agregan
+// después con AddForeignKeys.
+func MigrateAll(db *gorm.DB) error {
+    for _, migration := range migrations {
+        if err := migration.migrate(db); err != nil {
+            return fmt.Errorf("error en migración de %s: %w", migration.name, err)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+    "time"
+)
+
+// DefaultPassingGrade calificación mínima aprobatoria en la escala de 0 a 100
+const DefaultPassingGrade = 60.0
+
+// ReportCardSubject renglón de la boleta con la calificación de una materia
+type ReportCardSubject struct {
+    SubjectID           int      `json:"subject_id" example:"1"`
+    Name                string   `json:"name" example:"Matemáticas"`
+    Grade               float64     `json:"grade" example:"85.5"`
+    // Scaled es la calificación en la escala de la materia, que es la que se imprime
+    Scaled              ScaledGrade `json:"scaled"`
+    // PassingGrade es el valor aprobatorio de la escala de la materia, de 0 a 100
+    PassingGrade        float64     `json:"passing_grade" example:"60"`
+    // PassingValue es el valor aprobatorio en la escala de la materia
+    PassingValue        float64     `json:"passing_value" example:"6"`
+    Passed              bool        `json:"passed" example:"true"`
+    // AbsencePercentage es nulo si no hay asistencia registrada en la materia
+    AbsencePercentage   *float64    `json:"absence_percentage" example:"12.5"`
+    ExceedsAbsenceLimit bool        `json:"exceeds_absence_limit" example:"false"`
+}
+
+// ReportCard boleta de calificaciones de un estudiante
+type ReportCard struct {
+    Student              StudentBasic        `json:"student"`
+    Term                 *TermBasic          `json:"term,omitempty"`
+    Subjects             []ReportCardSubject `json:"subjects"`
+    Average              float64             `json:"average" example:"88.25"`
+    // ScaledAverage es el promedio de las calificaciones en la escala de las materias; es nulo si
+    // las materias usan escalas distintas
+    ScaledAverage        *ScaledGrade        `json:"scaled_average"`
+    // PassingGrade es la calificación aprobatoria de las materias sin escala asignada
+    PassingGrade         float64             `json:"passing_grade" example:"60"`
+    Passed               bool                `json:"passed" example:"true"`
+    MaxAbsencePercentage float64             `json:"max_absence_percentage" example:"20"`
+    GeneratedAt          time.Time           `json:"generated_at"`
+}
+
+// SubjectAverage promedio de las calificaciones de un estudiante en una materia
+type SubjectAverage struct {
+    SubjectID  int     `json:"subject_id" example:"1"`
+    Average    float64 `json:"average" example:"85.5"`
+    GradeCount int64   `json:"grade_count" example:"2"`
+}
+
+// SubjectSummary resultado de un estudiante en una materia
+type SubjectSummary struct {
+    SubjectID           int      `json:"subject_id" example:"1"`
+    Name                string   `json:"name" example:"Matemáticas"`
+    Credits             float64  `json:"credits" example:"8"`
+    Grade               float64     `json:"grade" example:"85.5"`
+    // Scaled es la calificación en la escala de la materia
+    Scaled              ScaledGrade `json:"scaled"`
+    GradeCount          int64       `json:"grade_count" example:"2"`
+    // PassingGrade es el valor aprobatorio de la escala de la materia, de 0 a 100
+    PassingGrade        float64     `json:"passing_grade" example:"60"`
+    // PassingValue es el valor aprobatorio en la escala de la materia
+    PassingValue        float64     `json:"passing_value" example:"6"`
+    Passed              bool        `json:"passed" example:"true"`
+    // AbsencePercentage es nulo si no hay asistencia registrada en la materia
+    AbsencePercentage   *float64    `json:"absence_percentage" example:"12.5"`
+    ExceedsAbsenceLimit bool        `json:"exceeds_absence_limit" example:"false"`
+}
+
+// StudentSummary estadísticas de las calificaciones de un estudiante.
+// Los valores se calculan sobre la calificación final de cada materia.
+type StudentSummary struct {
+    Student                  StudentBasic     `json:"student"`
+    Term                     *TermBasic       `json:"term,omitempty"`
+    SubjectCount             int              `json:"subject_count" example:"6"`
+    Average                  *float64         `json:"average" example:"84.3"`
+    // ScaledAverage es el promedio de las calificaciones en la escala de las materias; es nulo si
+    // no hay calificaciones o si las materias usan escalas distintas
+    ScaledAverage            *ScaledGrade     `json:"scaled_average"`
+    Min                      *float64         `json:"min" example:"58"`
+    Max                      *float64         `json:"max" example:"98.5"`
+    FailedSubjects           int              `json:"failed_subjects" example:"1"`
+    // PassingGrade es la calificación aprobatoria de las materias sin escala asignada
+    PassingGrade             float64          `json:"passing_grade" example:"60"`
+    WeightedAverage          *float64         `json:"weighted_average" example:"86.1"`
+    TotalCredits             float64          `json:"total_credits" example:"40"`
+    MaxAbsencePercentage     float64          `json:"max_absence_percentage" example:"20"`
+    // SubjectsOverAbsenceLimit es el número de materias con más faltas de las permitidas
+    SubjectsOverAbsenceLimit int              `json:"subjects_over_absence_limit" example:"0"`
+    Subjects                 []SubjectSummary `json:"subjects"`
+}
@@ -8,14 +8,25 @@ import (
 type Student struct {
     StudentID int    `gorm:"primaryKey;autoIncrement" json:"student_id" example:"1"`
     Name      string `gorm:"type:varchar(100);not null" json:"name" binding:"required,min=2,max=100" example:"María García"`
-    Group     string `gorm:"type:varchar(10);not null" json:"group" binding:"required,min=1,max=10" example:"5A"`
+    GroupID   *int   `gorm:"index" json:"group_id" binding:"required,min=1" example:"1"`
     Email     string `gorm:"type:varchar(100);unique;not null" json:"email" binding:"required,email" example:"maria.garcia@escuela.com"`
+    // DeletedAt marca al estudiante como eliminado sin borrar sus calificaciones; se ignora al crear o actualizar
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time" binding:"-"`
+    
+    // Group se incluye en las respuestas; se ignora al crear o actualizar
+    Group     *Group `gorm:"belongsTo:Group;foreignKey:GroupID;references:GroupID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"group,omitempty" binding:"-"`
 }
 
 func (Student) TableName() string {
     return "students"
 }
 
+// MigrateStudent crea la tabla de estudiantes y convierte los grupos capturados como texto
 func MigrateStudent(db *gorm.DB) error {
-    return db.AutoMigrate(&Student{})
-}
\ No newline at end of file
+    return withoutForeignKeys(db, func(tx *gorm.DB) error {
+        if err := tx.AutoMigrate(&Student{}); err != nil {
+            return err
+        }
+        return migrateLegacyGroups(tx)
+    })
+}
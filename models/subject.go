@@ -6,8 +6,12 @@ import (
 
 // Subject representa una materia en el sistema
 type Subject struct {
-    SubjectID int    `gorm:"primaryKey;autoIncrement" json:"subject_id" example:"1"`
-    Name      string `gorm:"type:varchar(100);unique;not null" json:"name" binding:"required,min=2,max=100" example:"Matemáticas"`
+    SubjectID int     `gorm:"primaryKey;autoIncrement" json:"subject_id" example:"1"`
+    Name      string  `gorm:"type:varchar(100);unique;not null" json:"name" binding:"required,min=2,max=100" example:"Matemáticas"`
+    // Credits pondera la materia en el promedio; 0 indica que no tiene créditos asignados
+    Credits   float64 `gorm:"type:decimal(4,1);not null;default:0" json:"credits" binding:"min=0,max=99" example:"8"`
+    // DeletedAt marca la materia como eliminada sin borrar sus calificaciones; se ignora al crear o actualizar
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time" binding:"-"`
 }
 
 func (Subject) TableName() string {
@@ -0,0 +1,102 @@
+package models
+
+import (
+    "gorm.io/gorm"
+)
+
+// Teacher representa a un maestro de la escuela
+type Teacher struct {
+    TeacherID int     `gorm:"primaryKey;autoIncrement" json:"teacher_id" example:"1"`
+    Name      string  `gorm:"type:varchar(100);not null" json:"name" example:"Laura López"`
+    Email     *string `gorm:"type:varchar(100);unique" json:"email" example:"laura.lopez@escuela.com"`
+    // UserID vincula al maestro con su cuenta de acceso; sin cuenta no puede capturar calificaciones
+    UserID    *int    `gorm:"uniqueIndex" json:"user_id" example:"2"`
+    
+    User      *User   `gorm:"belongsTo:User;foreignKey:UserID;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (Teacher) TableName() string {
+    return "teachers"
+}
+
+// TeachingAssignment asigna un maestro a una materia de un grupo durante un periodo.
+// La asignación cubre también los subperiodos del periodo (p. ej. los parciales de un semestre).
+type TeachingAssignment struct {
+    AssignmentID int      `gorm:"primaryKey;autoIncrement" json:"assignment_id" example:"1"`
+    TeacherID    int      `gorm:"not null;index" json:"teacher_id" example:"1"`
+    // Cada materia de un grupo tiene un solo maestro por periodo
+    SubjectID    int      `gorm:"not null;uniqueIndex:idx_assignments_subject_group_term,priority:1" json:"subject_id" example:"1"`
+    GroupID      int      `gorm:"not null;index;uniqueIndex:idx_assignments_subject_group_term,priority:2" json:"group_id" example:"1"`
+    TermID       int      `gorm:"not null;index;uniqueIndex:idx_assignments_subject_group_term,priority:3" json:"term_id" example:"2"`
+    
+    Teacher      *Teacher `gorm:"belongsTo:Teacher;foreignKey:TeacherID;references:TeacherID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Subject      *Subject `gorm:"belongsTo:Subject;foreignKey:SubjectID;references:SubjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Group        *Group   `gorm:"belongsTo:Group;foreignKey:GroupID;references:GroupID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+    Term         *Term    `gorm:"belongsTo:Term;foreignKey:TermID;references:TermID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-" swaggerignore:"true"`
+}
+
+func (TeachingAssignment) TableName() string {
+    return "teaching_assignments"
+}
+
+// MigrateTeacher crea las tablas de maestros y asignaciones, y convierte los titulares
+// de grupo que todavía apuntan a cuentas de usuario
+func MigrateTeacher(db *gorm.DB) error {
+    if err := db.AutoMigrate(&Teacher{}, &TeachingAssignment{}); err != nil {
+        return err
+    }
+    return withoutForeignKeys(db, migrateLegacyHomerooms)
+}
+
+// legacyHomeroomConstraint es la llave foránea con la que groups.homeroom_teacher_id apuntaba a users
+const legacyHomeroomConstraint = "fk_groups_homeroom_teacher"
+
+// migrateLegacyHomerooms cambia el titular de cada grupo de la cuenta de usuario al maestro
+// vinculado a esa cuenta, creando el maestro con el nombre de usuario si no existe
+func migrateLegacyHomerooms(db *gorm.DB) error {
+    if !db.Migrator().HasConstraint(&Group{}, legacyHomeroomConstraint) {
+        return nil
+    }
+    
+    var groups []Group
+    if err := db.Where("homeroom_teacher_id IS NOT NULL").Find(&groups).Error; err != nil {
+        return err
+    }
+    
+    // La llave foránea hacia users se elimina antes de cambiar los IDs; la nueva hacia
+    // teachers se crea en AddForeignKeys
+    if err := db.Migrator().DropConstraint(&Group{}, legacyHomeroomConstraint); err != nil {
+        return err
+    }
+    if err := ensureIndexes(db, &Group{}); err != nil {
+        return err
+    }
+    
+    return db.Transaction(func(tx *gorm.DB) error {
+        teacherIDs := make(map[int]int)
+        for _, group := range groups {
+            userID := *group.HomeroomTeacherID
+            teacherID, ok := teacherIDs[userID]
+            if !ok {
+                var user User
+                if err := tx.First(&user, userID).Error; err != nil {
+                    return err
+                }
+                teacher := Teacher{Name: user.Username, UserID: &user.UserID}
+                if err := tx.Where(Teacher{UserID: &user.UserID}).FirstOrCreate(&teacher).Error; err != nil {
+                    return err
+                }
+                teacherID = teacher.TeacherID
+                teacherIDs[userID] = teacherID
+            }
+            
+            err := tx.Model(&Group{}).
+                Where("group_id = ?", group.GroupID).
+                Update("homeroom_teacher_id", teacherID).Error
+            if err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+    "time"
+    
+    "gorm.io/gorm"
+)
+
+// Tipos de periodo académico, del más amplio al más específico
+const (
+    TermKindSchoolYear = "school_year"
+    TermKindSemester   = "semester"
+    TermKindPartial    = "partial"
+)
+
+// TermParentKind indica qué tipo de periodo debe contener a cada tipo
+var TermParentKind = map[string]string{
+    TermKindSemester: TermKindSchoolYear,
+    TermKindPartial:  TermKindSemester,
+}
+
+// Term representa un periodo académico: ciclo escolar, semestre o evaluación parcial
+type Term struct {
+    TermID     int       `gorm:"primaryKey;autoIncrement" json:"term_id" example:"3"`
+    Name       string    `gorm:"type:varchar(100);not null" json:"name" example:"Primer parcial"`
+    SchoolYear string    `gorm:"type:varchar(20);not null;index" json:"school_year" example:"2025-2026"`
+    Kind       string    `gorm:"type:varchar(20);not null" json:"kind" example:"partial"`
+    // ParentID apunta al semestre de un parcial o al ciclo escolar de un semestre
+    ParentID   *int      `gorm:"index" json:"parent_id,omitempty" example:"2"`
+    StartDate  time.Time `gorm:"type:date;not null" json:"start_date" example:"2025-08-25T00:00:00Z"`
+    EndDate    time.Time `gorm:"type:date;not null" json:"end_date" example:"2025-10-10T00:00:00Z"`
+    
+    Parent     *Term     `gorm:"belongsTo:Term;foreignKey:ParentID;references:TermID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-" swaggerignore:"true"`
+}
+
+func (Term) TableName() string {
+    return "terms"
+}
+
+func MigrateTerm(db *gorm.DB) error {
+    return db.AutoMigrate(&Term{})
+}
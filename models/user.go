@@ -0,0 +1,36 @@
+package models
+
+import (
+    "gorm.io/gorm"
+)
+
+// Roles de usuario soportados por la API
+const (
+    RoleAdmin   = "admin"
+    RoleTeacher = "teacher"
+    RoleStudent = "student"
+    RoleParent  = "parent"
+)
+
+// Roles lista de roles válidos
+var Roles = []string{RoleAdmin, RoleTeacher, RoleStudent, RoleParent}
+
+// User representa una cuenta de acceso a la API
+type User struct {
+    UserID       int    `gorm:"primaryKey;autoIncrement" json:"user_id" example:"1"`
+    Username     string `gorm:"type:varchar(100);unique;not null" json:"username" example:"maestra.lopez"`
+    PasswordHash string `gorm:"type:varchar(255);not null" json:"-"`
+    Role         string `gorm:"type:varchar(20);not null" json:"role" example:"teacher"`
+    // StudentID vincula la cuenta de un alumno con su registro de estudiante
+    StudentID    *int   `gorm:"index" json:"student_id,omitempty" example:"1"`
+
+    Student      *Student `gorm:"belongsTo:Student;foreignKey:StudentID;references:StudentID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"-" swaggerignore:"true"`
+}
+
+func (User) TableName() string {
+    return "users"
+}
+
+func MigrateUser(db *gorm.DB) error {
+    return db.AutoMigrate(&User{})
+}
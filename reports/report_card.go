@@ -0,0 +1,153 @@
+package reports
+
+import (
+    "fmt"
+    "io"
+    "strconv"
+
+    "github.com/go-pdf/fpdf"
+
+    "ControlEscolar/models"
+)
+
+// Anchos de las columnas de la tabla de materias, en milímetros
+const (
+    subjectColumnWidth  = 80.0
+    gradeColumnWidth    = 35.0
+    absencesColumnWidth = 30.0
+    statusColumnWidth   = 35.0
+)
+
+// WriteReportCardPDF genera la boleta en PDF y la escribe en w.
+// Solo usa las fuentes estándar de PDF, por lo que no requiere archivos externos.
+func WriteReportCardPDF(w io.Writer, card *models.ReportCard) error {
+    pdf := fpdf.New("P", "mm", "Letter", "")
+    pdf.SetTitle("Boleta de calificaciones", true)
+    pdf.SetCreator("API de Control Escolar", true)
+    pdf.AddPage()
+
+    // Las fuentes estándar usan cp1252; el traductor convierte acentos y eñes desde UTF-8
+    tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+    // Encabezado
+    pdf.SetFont("Helvetica", "B", 18)
+    pdf.CellFormat(0, 10, tr("Boleta de calificaciones"), "", 1, "C", false, 0, "")
+    if card.Term != nil {
+        pdf.SetFont("Helvetica", "", 12)
+        pdf.CellFormat(0, 7, tr(fmt.Sprintf("%s · Ciclo %s", card.Term.Name, card.Term.SchoolYear)), "", 1, "C", false, 0, "")
+    }
+    pdf.Ln(6)
+
+    // Datos del estudiante
+    writeField(pdf, tr, "Alumno:", card.Student.Name)
+    writeField(pdf, tr, "Grupo:", card.Student.Group)
+    writeField(pdf, tr, "Email:", card.Student.Email)
+    writeField(pdf, tr, "Fecha:", card.GeneratedAt.Format("02/01/2006"))
+    pdf.Ln(6)
+
+    // Tabla de materias
+    pdf.SetFont("Helvetica", "B", 11)
+    pdf.SetFillColor(230, 230, 230)
+    pdf.CellFormat(subjectColumnWidth, 8, tr("Materia"), "1", 0, "L", true, 0, "")
+    pdf.CellFormat(gradeColumnWidth, 8, tr("Calificación"), "1", 0, "C", true, 0, "")
+    pdf.CellFormat(absencesColumnWidth, 8, tr("Faltas"), "1", 0, "C", true, 0, "")
+    pdf.CellFormat(statusColumnWidth, 8, tr("Estado"), "1", 1, "C", true, 0, "")
+
+    pdf.SetFont("Helvetica", "", 11)
+    if len(card.Subjects) == 0 {
+        pdf.CellFormat(subjectColumnWidth+gradeColumnWidth+absencesColumnWidth+statusColumnWidth, 8,
+            tr("Sin calificaciones registradas"), "1", 1, "C", false, 0, "")
+    }
+    overLimit := false
+    for _, subject := range card.Subjects {
+        pdf.CellFormat(subjectColumnWidth, 8, tr(subject.Name), "1", 0, "L", false, 0, "")
+        pdf.CellFormat(gradeColumnWidth, 8, tr(scaledLabel(subject.Scaled)), "1", 0, "C", false, 0, "")
+        pdf.CellFormat(absencesColumnWidth, 8, absencesLabel(subject), "1", 0, "C", false, 0, "")
+        overLimit = overLimit || subject.ExceedsAbsenceLimit
+        pdf.CellFormat(statusColumnWidth, 8, tr(statusLabel(subject.Passed)), "1", 1, "C", false, 0, "")
+    }
+
+    // Promedio y resultado general
+    pdf.SetFont("Helvetica", "B", 11)
+    pdf.CellFormat(subjectColumnWidth, 8, tr("Promedio general"), "1", 0, "R", true, 0, "")
+    pdf.CellFormat(gradeColumnWidth, 8, tr(averageLabel(card)), "1", 0, "C", true, 0, "")
+    pdf.CellFormat(absencesColumnWidth, 8, "", "1", 0, "C", true, 0, "")
+    pdf.CellFormat(statusColumnWidth, 8, tr(statusLabel(card.Passed)), "1", 1, "C", true, 0, "")
+
+    pdf.Ln(4)
+    pdf.SetFont("Helvetica", "I", 9)
+    pdf.CellFormat(0, 6, tr(passingGradeLabel(card)), "", 1, "L", false, 0, "")
+    if card.ScaledAverage == nil && len(card.Subjects) > 0 {
+        pdf.CellFormat(0, 6, tr("El promedio general se muestra de 0 a 100 porque las materias usan escalas distintas"), "", 1, "L", false, 0, "")
+    }
+    pdf.CellFormat(0, 6, tr(fmt.Sprintf("Porcentaje máximo de faltas: %.2f%%", card.MaxAbsencePercentage)), "", 1, "L", false, 0, "")
+    if overLimit {
+        pdf.CellFormat(0, 6, tr("* Supera el porcentaje máximo de faltas"), "", 1, "L", false, 0, "")
+    }
+
+    return pdf.Output(w)
+}
+
+// writeField escribe una línea "etiqueta valor" en el encabezado de la boleta
+func writeField(pdf *fpdf.Fpdf, tr func(string) string, label, value string) {
+    pdf.SetFont("Helvetica", "B", 11)
+    pdf.CellFormat(25, 7, tr(label), "", 0, "L", false, 0, "")
+    pdf.SetFont("Helvetica", "", 11)
+    pdf.CellFormat(0, 7, tr(value), "", 1, "L", false, 0, "")
+}
+
+// absencesLabel muestra el porcentaje de faltas de una materia, marcado con * si supera el máximo;
+// sin asistencia registrada muestra un guion
+func absencesLabel(subject models.ReportCardSubject) string {
+    if subject.AbsencePercentage == nil {
+        return "-"
+    }
+    label := fmt.Sprintf("%.2f%%", *subject.AbsencePercentage)
+    if subject.ExceedsAbsenceLimit {
+        label += " *"
+    }
+    return label
+}
+
+// statusLabel traduce el resultado a texto para la boleta
+func statusLabel(passed bool) string {
+    if passed {
+        return "Aprobado"
+    }
+    return "Reprobado"
+}
+
+// scaledLabel muestra una calificación en su escala: el valor con los decimales que tenga y, si la
+// escala tiene letras, la letra antes del valor
+func scaledLabel(scaled models.ScaledGrade) string {
+    value := strconv.FormatFloat(scaled.Value, 'f', -1, 64)
+    if scaled.Letter != "" {
+        return fmt.Sprintf("%s (%s)", scaled.Letter, value)
+    }
+    return value
+}
+
+// averageLabel muestra el promedio general en la escala de las materias; si usan escalas distintas
+// muestra el promedio de 0 a 100
+func averageLabel(card *models.ReportCard) string {
+    if card.ScaledAverage != nil {
+        return scaledLabel(*card.ScaledAverage)
+    }
+    return fmt.Sprintf("%.2f", card.Average)
+}
+
+// passingGradeLabel describe la calificación mínima aprobatoria de la boleta en la escala de las
+// materias; si las materias usan escalas con valores aprobatorios distintos se indica que depende
+// de cada materia
+func passingGradeLabel(card *models.ReportCard) string {
+    passing := fmt.Sprintf("%.2f", card.PassingGrade)
+    for i, subject := range card.Subjects {
+        current := fmt.Sprintf("%s (escala %s)", strconv.FormatFloat(subject.PassingValue, 'f', -1, 64), subject.Scaled.Scale)
+        if i == 0 {
+            passing = current
+        } else if current != passing {
+            return "Calificación mínima aprobatoria: según la escala de cada materia"
+        }
+    }
+    return "Calificación mínima aprobatoria: " + passing
+}
@@ -0,0 +1,288 @@
+package repositories
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "strings"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "ControlEscolar/models"
+)
+
+// AnalyticsFilter acota las calificaciones que se consideran en las estadísticas
+type AnalyticsFilter struct {
+    // GroupID limita a los estudiantes de un grupo; 0 considera todos
+    GroupID int
+    // SubjectID limita a una materia; 0 considera todas
+    SubjectID int
+    // TermIDs limita a los periodos indicados; vacío considera todos
+    TermIDs []int
+}
+
+// PassingCriteria indica con qué calificación aprueba cada calificación final. Sin Override cada
+// materia usa el valor aprobatorio de su escala en el grado escolar del grupo del estudiante.
+type PassingCriteria struct {
+    // Default es el valor aprobatorio, de 0 a 100, de las materias sin escala asignada
+    Default float64
+    // Override, si se indica, se aplica a todas las materias en lugar de su escala
+    Override *float64
+}
+
+// PassingGrade devuelve el valor aprobatorio que se informa en las estadísticas
+func (p PassingCriteria) PassingGrade() float64 {
+    if p.Override != nil {
+        return *p.Override
+    }
+    return p.Default
+}
+
+// AnalyticsRepository calcula estadísticas agregadas de calificaciones.
+// Todas las consultas parten de la calificación final de cada estudiante en cada materia,
+// es decir, el promedio de sus calificaciones dentro del filtro.
+type AnalyticsRepository interface {
+    // GroupSubjectStats devuelve las estadísticas de cada materia por grupo
+    GroupSubjectStats(filter AnalyticsFilter, passing PassingCriteria) ([]models.GroupSubjectStats, error)
+    // Ranking devuelve los estudiantes ordenados de mayor a menor promedio
+    Ranking(filter AnalyticsFilter, passing PassingCriteria) ([]models.StudentRanking, error)
+    // Distribution cuenta las calificaciones finales en intervalos de bucketSize puntos
+    Distribution(filter AnalyticsFilter, bucketSize int, passing PassingCriteria) (*models.GradeDistribution, error)
+}
+
+// GormAnalyticsRepository implementa AnalyticsRepository con consultas de agregación en SQL
+type GormAnalyticsRepository struct {
+    db *gorm.DB
+}
+
+// NewGormAnalyticsRepository crea un repositorio de estadísticas respaldado por la base de datos
+func NewGormAnalyticsRepository(db *gorm.DB) *GormAnalyticsRepository {
+    return &GormAnalyticsRepository{db: db}
+}
+
+// finalGrades construye la subconsulta con la calificación final de cada estudiante por materia.
+// Se omiten las calificaciones eliminadas y las de estudiantes o materias eliminados.
+func (r *GormAnalyticsRepository) finalGrades(filter AnalyticsFilter) *gorm.DB {
+    query := r.db.Table("grades").
+        Select("grades.student_id, grades.subject_id, students.group_id, ROUND(AVG(grades.grade), 2) AS grade").
+        Joins("JOIN students ON students.student_id = grades.student_id").
+        Where("grades.deleted_at IS NULL AND students.deleted_at IS NULL").
+        Where("grades.subject_id IN (?)", activeSubjectIDs(r.db))
+
+    if filter.GroupID != 0 {
+        query = query.Where("students.group_id = ?", filter.GroupID)
+    }
+    if filter.SubjectID != 0 {
+        query = query.Where("grades.subject_id = ?", filter.SubjectID)
+    }
+    if len(filter.TermIDs) > 0 {
+        query = query.Where("grades.term_id IN ?", filter.TermIDs)
+    }
+
+    return query.Group("grades.student_id, grades.subject_id, students.group_id")
+}
+
+// joinGroups agrega el nombre del grupo de las calificaciones finales.
+// "groups" es palabra reservada en MySQL 8, así que la tabla se cita según el dialecto.
+func joinGroups(query *gorm.DB) *gorm.DB {
+    return query.Joins("LEFT JOIN ? AS class_groups ON class_groups.group_id = finals.group_id", clause.Table{Name: models.Group{}.TableName()})
+}
+
+// finalResult es la calificación final de un estudiante en una materia y si la aprueba
+type finalResult struct {
+    StudentID  int
+    SubjectID  int
+    GroupID    *int
+    GradeLevel *int
+    Grade      float64
+    Passed     bool `gorm:"-"`
+}
+
+// passedFinals devuelve las calificaciones finales del filtro e indica si aprueba cada una con la
+// misma regla que la boleta: la calificación se convierte a la escala de la materia en el grado
+// escolar del grupo, con sus decimales y su regla de redondeo, y se compara con su valor
+// aprobatorio. Con Override todas las materias usan la escala de 0 a 100 con ese valor.
+func (r *GormAnalyticsRepository) passedFinals(filter AnalyticsFilter, passing PassingCriteria) ([]finalResult, error) {
+    var finals []finalResult
+    err := joinGroups(r.db.Table("(?) AS finals", r.finalGrades(filter))).
+        Select("finals.student_id, finals.subject_id, finals.group_id, class_groups.grade_level, finals.grade").
+        Scan(&finals).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+
+    scales := NewGormGradingScaleRepository(r.db)
+    resolved := make(map[[2]int]models.GradingScale)
+    for i, final := range finals {
+        if passing.Override != nil {
+            finals[i].Passed = models.DefaultGradingScale(*passing.Override).Convert(final.Grade).Passed
+            continue
+        }
+
+        key := [2]int{final.SubjectID, 0}
+        if final.GradeLevel != nil {
+            key[1] = *final.GradeLevel
+        }
+        scale, ok := resolved[key]
+        if !ok {
+            scale = models.DefaultGradingScale(passing.Default)
+            found, err := scales.Resolve(final.SubjectID, final.GradeLevel)
+            switch {
+            case err == nil:
+                scale = *found
+            case !errors.Is(err, ErrNotFound):
+                return nil, err
+            }
+            resolved[key] = scale
+        }
+        finals[i].Passed = scale.Convert(final.Grade).Passed
+    }
+    return finals, nil
+}
+
+// groupKey identifica un grupo en los mapas de conteos; 0 son los estudiantes sin grupo
+func groupKey(groupID *int) int {
+    if groupID == nil {
+        return 0
+    }
+    return *groupID
+}
+
+func (r *GormAnalyticsRepository) GroupSubjectStats(filter AnalyticsFilter, passing PassingCriteria) ([]models.GroupSubjectStats, error) {
+    stats := []models.GroupSubjectStats{}
+    err := joinGroups(r.db.Table("(?) AS finals", r.finalGrades(filter))).
+        Select(`finals.group_id, class_groups.name AS group_name, finals.subject_id, subjects.name AS subject_name,
+            COUNT(*) AS student_count, ROUND(AVG(finals.grade), 2) AS average,
+            MIN(finals.grade) AS min, MAX(finals.grade) AS max`).
+        Joins("JOIN subjects ON subjects.subject_id = finals.subject_id").
+        Group("finals.group_id, class_groups.name, finals.subject_id, subjects.name").
+        Order("class_groups.name, finals.group_id, subjects.name").
+        Scan(&stats).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+
+    finals, err := r.passedFinals(filter, passing)
+    if err != nil {
+        return nil, err
+    }
+    passed := make(map[[2]int]int64)
+    for _, final := range finals {
+        if final.Passed {
+            passed[[2]int{groupKey(final.GroupID), final.SubjectID}]++
+        }
+    }
+
+    for i := range stats {
+        stats[i].PassedCount = passed[[2]int{groupKey(stats[i].GroupID), stats[i].SubjectID}]
+        stats[i].PassRate = passRate(stats[i].PassedCount, stats[i].StudentCount)
+    }
+    return stats, nil
+}
+
+func (r *GormAnalyticsRepository) Ranking(filter AnalyticsFilter, passing PassingCriteria) ([]models.StudentRanking, error) {
+    ranking := []models.StudentRanking{}
+    err := joinGroups(r.db.Table("(?) AS finals", r.finalGrades(filter))).
+        Select(`finals.student_id, students.name, finals.group_id, class_groups.name AS group_name,
+            ROUND(AVG(finals.grade), 2) AS average, COUNT(*) AS subject_count`).
+        Joins("JOIN students ON students.student_id = finals.student_id").
+        Group("finals.student_id, students.name, finals.group_id, class_groups.name").
+        Order("average DESC, students.name, finals.student_id").
+        Scan(&ranking).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+
+    finals, err := r.passedFinals(filter, passing)
+    if err != nil {
+        return nil, err
+    }
+    failed := make(map[int]int64)
+    for _, final := range finals {
+        if !final.Passed {
+            failed[final.StudentID]++
+        }
+    }
+
+    // Los empates comparten posición y la siguiente se salta (1, 2, 2, 4)
+    for i := range ranking {
+        ranking[i].FailedSubjects = failed[ranking[i].StudentID]
+        if i > 0 && ranking[i].Average == ranking[i-1].Average {
+            ranking[i].Rank = ranking[i-1].Rank
+        } else {
+            ranking[i].Rank = i + 1
+        }
+    }
+    return ranking, nil
+}
+
+func (r *GormAnalyticsRepository) Distribution(filter AnalyticsFilter, bucketSize int, passing PassingCriteria) (*models.GradeDistribution, error) {
+    results, err := r.passedFinals(filter, passing)
+    if err != nil {
+        return nil, err
+    }
+    total, passedCount := int64(len(results)), int64(0)
+    for _, result := range results {
+        if result.Passed {
+            passedCount++
+        }
+    }
+
+    // El intervalo se calcula con CASE para no depender de FLOOR, que SQLite no siempre incluye
+    bucketCount := (100 + bucketSize - 1) / bucketSize
+    var bucketExpr strings.Builder
+    bucketExpr.WriteString("CASE")
+    for i := bucketCount - 1; i > 0; i-- {
+        fmt.Fprintf(&bucketExpr, " WHEN finals.grade >= %d THEN %d", i*bucketSize, i)
+    }
+    bucketExpr.WriteString(" ELSE 0 END")
+
+    var rows []struct {
+        Bucket int
+        Count  int64
+    }
+    err = r.db.Table("(?) AS finals", r.finalGrades(filter)).
+        Select(bucketExpr.String() + " AS bucket, COUNT(*) AS count").
+        Group("bucket").
+        Scan(&rows).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+
+    distribution := &models.GradeDistribution{
+        BucketSize:   bucketSize,
+        Total:        total,
+        PassedCount:  passedCount,
+        PassRate:     passRate(passedCount, total),
+        PassingGrade: passing.PassingGrade(),
+        Buckets:      make([]models.DistributionBucket, bucketCount),
+    }
+    if filter.GroupID != 0 {
+        groupID := filter.GroupID
+        distribution.GroupID = &groupID
+    }
+    if filter.SubjectID != 0 {
+        subjectID := filter.SubjectID
+        distribution.SubjectID = &subjectID
+    }
+    for i := range distribution.Buckets {
+        distribution.Buckets[i].From = float64(i * bucketSize)
+        distribution.Buckets[i].To = float64(min((i+1)*bucketSize, 100))
+    }
+    for _, row := range rows {
+        if row.Bucket >= 0 && row.Bucket < bucketCount {
+            distribution.Buckets[row.Bucket].Count = row.Count
+        }
+    }
+
+    return distribution, nil
+}
+
+// passRate devuelve el porcentaje de aprobados redondeado a dos decimales
+func passRate(passed, total int64) float64 {
+    if total == 0 {
+        return 0
+    }
+    return math.Round(float64(passed)*10000/float64(total)) / 100
+}
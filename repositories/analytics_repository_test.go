@@ -0,0 +1,73 @@
+package repositories
+
+import (
+    "testing"
+
+    "ControlEscolar/models"
+)
+
+func TestGormAnalyticsRepositoryRoundsWithTheScale(t *testing.T) {
+    db := newTestDB(t)
+    analytics := NewGormAnalyticsRepository(db)
+    scales := NewGormGradingScaleRepository(db)
+    grades := NewGormGradeRepository(db)
+
+    term := newTestTerm(t, db)
+    group := &models.Group{SchoolYear: term.SchoolYear, GradeLevel: 3, Section: "A", Name: "3A"}
+    subject := &models.Subject{Name: "Matemáticas"}
+    mustCreate(t, db, group, subject)
+    student := &models.Student{Name: "Ana", Email: "ana@example.com", GroupID: &group.GroupID}
+    mustCreate(t, db, student)
+
+    // En la escala de 5 a 10 sin decimales un 55 se redondea a 6, que aprueba
+    scale := &models.GradingScale{Name: "Primaria 5-10", MinValue: 5, MaxValue: 10, PassingValue: 6, Decimals: 0, Rounding: models.RoundingHalfUp}
+    if err := scales.Create(scale); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    if err := scales.Assign(&models.GradingScaleAssignment{ScaleID: scale.ScaleID, GradeLevel: &group.GradeLevel}); err != nil {
+        t.Fatalf("Assign: %v", err)
+    }
+    if err := grades.Create(&models.Grade{StudentID: student.StudentID, SubjectID: subject.SubjectID, TermID: &term.TermID, Grade: 55}, models.GradeChange{}); err != nil {
+        t.Fatalf("crear calificación: %v", err)
+    }
+    if !scale.Convert(55).Passed {
+        t.Fatalf("la boleta debe aprobar un 55 en la escala %s", scale.Name)
+    }
+
+    passing := PassingCriteria{Default: 60}
+    filter := AnalyticsFilter{GroupID: group.GroupID}
+
+    stats, err := analytics.GroupSubjectStats(filter, passing)
+    if err != nil {
+        t.Fatalf("GroupSubjectStats: %v", err)
+    }
+    if len(stats) != 1 || stats[0].PassedCount != 1 {
+        t.Errorf("GroupSubjectStats = %+v, se esperaba un aprobado", stats)
+    }
+
+    ranking, err := analytics.Ranking(filter, passing)
+    if err != nil {
+        t.Fatalf("Ranking: %v", err)
+    }
+    if len(ranking) != 1 || ranking[0].FailedSubjects != 0 {
+        t.Errorf("Ranking = %+v, se esperaba ninguna materia reprobada", ranking)
+    }
+
+    distribution, err := analytics.Distribution(filter, 10, passing)
+    if err != nil {
+        t.Fatalf("Distribution: %v", err)
+    }
+    if distribution.Total != 1 || distribution.PassedCount != 1 {
+        t.Errorf("Distribution total = %d, aprobados = %d; se esperaba 1 y 1", distribution.Total, distribution.PassedCount)
+    }
+
+    // Con passing_grade se compara la calificación de 0 a 100 para todas las materias
+    override := 60.0
+    distribution, err = analytics.Distribution(filter, 10, PassingCriteria{Default: 60, Override: &override})
+    if err != nil {
+        t.Fatalf("Distribution: %v", err)
+    }
+    if distribution.PassedCount != 0 {
+        t.Errorf("con passing_grade 60 el 55 no debe aprobar, aprobados = %d", distribution.PassedCount)
+    }
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// AssignmentSortFields son las columnas por las que se puede ordenar el listado de asignaciones
+var AssignmentSortFields = []string{"assignment_id", "teacher_id", "subject_id", "group_id", "term_id"}
+
+// AssignmentFilter contiene los filtros del listado de asignaciones; 0 no filtra
+type AssignmentFilter struct {
+    TeacherID int
+    SubjectID int
+    GroupID   int
+    TermID    int
+}
+
+// AssignmentRepository define el acceso a datos de asignaciones de maestros
+type AssignmentRepository interface {
+    Create(assignment *models.TeachingAssignment) error
+    List(filter AssignmentFilter, opts ListOptions) ([]models.TeachingAssignment, int64, error)
+    FindByID(id int) (*models.TeachingAssignment, error)
+    Delete(id int) error
+    // IsAssigned indica si el maestro imparte la materia al grupo en alguno de los periodos;
+    // sin periodos basta con cualquier periodo
+    IsAssigned(teacherID, subjectID, groupID int, termIDs []int) (bool, error)
+    // TeachesSubject indica si el maestro imparte la materia a algún grupo en alguno de los periodos
+    TeachesSubject(teacherID, subjectID int, termIDs []int) (bool, error)
+}
+
+// GormAssignmentRepository implementa AssignmentRepository sobre GORM
+type GormAssignmentRepository struct {
+    db *gorm.DB
+}
+
+// NewGormAssignmentRepository crea un repositorio de asignaciones respaldado por la base de datos
+func NewGormAssignmentRepository(db *gorm.DB) *GormAssignmentRepository {
+    return &GormAssignmentRepository{db: db}
+}
+
+func (r *GormAssignmentRepository) Create(assignment *models.TeachingAssignment) error {
+    return translateError(r.db.Create(assignment).Error)
+}
+
+func (r *GormAssignmentRepository) List(filter AssignmentFilter, opts ListOptions) ([]models.TeachingAssignment, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.TeachingAssignment{})
+
+    if filter.TeacherID != 0 {
+        query = query.Where("teacher_id = ?", filter.TeacherID)
+    }
+    if filter.SubjectID != 0 {
+        query = query.Where("subject_id = ?", filter.SubjectID)
+    }
+    if filter.GroupID != 0 {
+        query = query.Where("group_id = ?", filter.GroupID)
+    }
+    if filter.TermID != 0 {
+        query = query.Where("term_id = ?", filter.TermID)
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    assignments := []models.TeachingAssignment{}
+    if err := paginate(query, opts, "assignment_id").Find(&assignments).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return assignments, total, nil
+}
+
+func (r *GormAssignmentRepository) FindByID(id int) (*models.TeachingAssignment, error) {
+    var assignment models.TeachingAssignment
+    if err := r.db.First(&assignment, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &assignment, nil
+}
+
+func (r *GormAssignmentRepository) Delete(id int) error {
+    result := r.db.Delete(&models.TeachingAssignment{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormAssignmentRepository) IsAssigned(teacherID, subjectID, groupID int, termIDs []int) (bool, error) {
+    query := r.db.Model(&models.TeachingAssignment{}).
+        Where("teacher_id = ? AND subject_id = ? AND group_id = ?", teacherID, subjectID, groupID)
+    if len(termIDs) > 0 {
+        query = query.Where("term_id IN ?", termIDs)
+    }
+
+    var count int64
+    if err := query.Count(&count).Error; err != nil {
+        return false, translateError(err)
+    }
+    return count > 0, nil
+}
+
+func (r *GormAssignmentRepository) TeachesSubject(teacherID, subjectID int, termIDs []int) (bool, error) {
+    var count int64
+    if err := r.db.Model(&models.TeachingAssignment{}).
+        Where("teacher_id = ? AND subject_id = ? AND term_id IN ?", teacherID, subjectID, termIDs).
+        Count(&count).Error; err != nil {
+        return false, translateError(err)
+    }
+    return count > 0, nil
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+    "errors"
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// AttendanceSortFields son las columnas por las que se puede ordenar el listado de asistencia
+var AttendanceSortFields = []string{"attendance_id", "date", "student_id", "status"}
+
+// AttendanceFilter contiene los filtros del listado de asistencia; 0, "" y nil no filtran
+type AttendanceFilter struct {
+    GroupID   int
+    StudentID int
+    SubjectID int
+    // DailyOnly limita el listado a las listas diarias de grupo, sin materia
+    DailyOnly bool
+    Status    string
+    From      *time.Time
+    To        *time.Time
+}
+
+// AttendanceRepository define el acceso a datos de la asistencia
+type AttendanceRepository interface {
+    // RecordMany guarda una lista en una sola transacción: crea el registro de cada estudiante o
+    // actualiza el que ya tenga en la misma materia (o lista diaria) y fecha. Indica por cada
+    // registro si se creó uno nuevo; si alguno falla no se guarda ninguno. Si otra lista crea al
+    // mismo tiempo el registro de un estudiante en la misma materia y fecha devuelve ErrDuplicate.
+    RecordMany(records []models.Attendance) ([]bool, error)
+    List(filter AttendanceFilter, opts ListOptions) ([]models.Attendance, int64, error)
+    FindByID(id int) (*models.Attendance, error)
+    Update(record *models.Attendance) error
+    Delete(id int) error
+    // Counts cuenta los registros del estudiante por materia y estado, opcionalmente solo entre
+    // las fechas from y to (inclusive)
+    Counts(studentID int, from, to *time.Time) ([]models.AttendanceCount, error)
+}
+
+// GormAttendanceRepository implementa AttendanceRepository sobre GORM
+type GormAttendanceRepository struct {
+    db *gorm.DB
+}
+
+// NewGormAttendanceRepository crea un repositorio de asistencia respaldado por la base de datos
+func NewGormAttendanceRepository(db *gorm.DB) *GormAttendanceRepository {
+    return &GormAttendanceRepository{db: db}
+}
+
+func (r *GormAttendanceRepository) RecordMany(records []models.Attendance) ([]bool, error) {
+    created := make([]bool, len(records))
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        for i := range records {
+            record := &records[i]
+
+            // subject_key es 0 en la lista diaria; el índice único rechaza un registro creado en paralelo
+            subjectKey := 0
+            if record.SubjectID != nil {
+                subjectKey = *record.SubjectID
+            }
+
+            var existing models.Attendance
+            err := tx.Where("student_id = ? AND subject_key = ? AND date = ?", record.StudentID, subjectKey, record.Date).
+                First(&existing).Error
+            switch {
+            case err == nil:
+                record.AttendanceID = existing.AttendanceID
+                record.CreatedAt = existing.CreatedAt
+                if err := tx.Save(record).Error; err != nil {
+                    return err
+                }
+            case errors.Is(err, gorm.ErrRecordNotFound):
+                if err := tx.Create(record).Error; err != nil {
+                    return err
+                }
+                created[i] = true
+            default:
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return created, nil
+}
+
+func (r *GormAttendanceRepository) List(filter AttendanceFilter, opts ListOptions) ([]models.Attendance, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Attendance{})
+
+    if filter.GroupID != 0 {
+        query = query.Where("group_id = ?", filter.GroupID)
+    }
+    if filter.StudentID != 0 {
+        query = query.Where("student_id = ?", filter.StudentID)
+    }
+    if filter.SubjectID != 0 {
+        query = query.Where("subject_id = ?", filter.SubjectID)
+    }
+    if filter.DailyOnly {
+        query = query.Where("subject_id IS NULL")
+    }
+    if filter.Status != "" {
+        query = query.Where("status = ?", filter.Status)
+    }
+    query = applyDateRange(query, filter.From, filter.To)
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    records := []models.Attendance{}
+    if err := paginate(query, opts, "attendance_id").Find(&records).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return records, total, nil
+}
+
+func (r *GormAttendanceRepository) FindByID(id int) (*models.Attendance, error) {
+    var record models.Attendance
+    if err := r.db.First(&record, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &record, nil
+}
+
+func (r *GormAttendanceRepository) Update(record *models.Attendance) error {
+    return translateError(r.db.Save(record).Error)
+}
+
+func (r *GormAttendanceRepository) Delete(id int) error {
+    result := r.db.Delete(&models.Attendance{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormAttendanceRepository) Counts(studentID int, from, to *time.Time) ([]models.AttendanceCount, error) {
+    query := r.db.Model(&models.Attendance{}).
+        Select("subject_id, status, COUNT(*) AS count").
+        Where("student_id = ?", studentID)
+    query = applyDateRange(query, from, to)
+
+    counts := []models.AttendanceCount{}
+    if err := query.Group("subject_id, status").Scan(&counts).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return counts, nil
+}
+
+// applyDateRange agrega a la consulta el rango de fechas de asistencia, inclusive
+func applyDateRange(query *gorm.DB, from, to *time.Time) *gorm.DB {
+    if from != nil {
+        query = query.Where("date >= ?", *from)
+    }
+    if to != nil {
+        query = query.Where("date <= ?", *to)
+    }
+    return query
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+    "errors"
+    "testing"
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+func TestGormAttendanceRepositoryKeepsOneRecordPerSubjectAndDate(t *testing.T) {
+    db := newTestDB(t)
+    attendance := NewGormAttendanceRepository(db)
+
+    group := &models.Group{SchoolYear: "2025-2026", GradeLevel: 1, Section: "A", Name: "1A"}
+    subject := &models.Subject{Name: "Matemáticas"}
+    mustCreate(t, db, group, subject)
+    student := &models.Student{Name: "Ana", Email: "ana@example.com", GroupID: &group.GroupID}
+    mustCreate(t, db, student)
+
+    date := time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC)
+    rollCall := func(status string) []models.Attendance {
+        return []models.Attendance{
+            {StudentID: student.StudentID, GroupID: group.GroupID, Date: date, Status: status, RecordedByName: "admin"},
+            {StudentID: student.StudentID, GroupID: group.GroupID, SubjectID: &subject.SubjectID, Date: date, Status: status, RecordedByName: "admin"},
+        }
+    }
+
+    // La lista diaria y la de la materia son registros distintos del mismo día
+    created, err := attendance.RecordMany(rollCall(models.AttendancePresent))
+    if err != nil || !created[0] || !created[1] {
+        t.Fatalf("RecordMany = %v, %v; se esperaban dos registros nuevos", created, err)
+    }
+    created, err = attendance.RecordMany(rollCall(models.AttendanceAbsent))
+    if err != nil || created[0] || created[1] {
+        t.Fatalf("RecordMany = %v, %v; se esperaba actualizar los dos registros", created, err)
+    }
+
+    // Una inserción en paralelo que no vio el registro existente la rechaza el índice único
+    for _, duplicate := range rollCall(models.AttendanceLate) {
+        if err := translateError(db.Create(&duplicate).Error); !errors.Is(err, ErrDuplicate) {
+            t.Errorf("crear registro repetido (subject_id %v) = %v, se esperaba ErrDuplicate", duplicate.SubjectID, err)
+        }
+    }
+}
+
+// legacyAttendance es la tabla de asistencia anterior al índice único, sin subject_key
+type legacyAttendance struct {
+    AttendanceID   int `gorm:"primaryKey;autoIncrement"`
+    StudentID      int
+    GroupID        int
+    SubjectID      *int
+    Date           time.Time `gorm:"type:date"`
+    Status         string
+    Notes          string
+    RecordedByName string
+    CreatedAt      time.Time
+    UpdatedAt      time.Time
+}
+
+func (legacyAttendance) TableName() string {
+    return "attendance"
+}
+
+func TestMigrateAttendanceRemovesDuplicates(t *testing.T) {
+    db := openTestDB(t)
+    for _, migrate := range []func(*gorm.DB) error{models.MigrateGroup, models.MigrateStudent, models.MigrateSubject, models.MigrateUser} {
+        if err := migrate(db); err != nil {
+            t.Fatalf("migrar base de datos: %v", err)
+        }
+    }
+    if err := db.AutoMigrate(&legacyAttendance{}); err != nil {
+        t.Fatalf("crear tabla anterior: %v", err)
+    }
+
+    group := &models.Group{SchoolYear: "2025-2026", GradeLevel: 1, Section: "A", Name: "1A"}
+    subject := &models.Subject{Name: "Matemáticas"}
+    mustCreate(t, db, group, subject)
+    student := &models.Student{Name: "Ana", Email: "ana@example.com", GroupID: &group.GroupID}
+    mustCreate(t, db, student)
+
+    date := time.Date(2025, time.October, 17, 0, 0, 0, 0, time.UTC)
+    mustCreate(t, db,
+        &legacyAttendance{StudentID: student.StudentID, GroupID: group.GroupID, Date: date, Status: models.AttendancePresent},
+        &legacyAttendance{StudentID: student.StudentID, GroupID: group.GroupID, Date: date, Status: models.AttendanceAbsent},
+        &legacyAttendance{StudentID: student.StudentID, GroupID: group.GroupID, SubjectID: &subject.SubjectID, Date: date, Status: models.AttendanceLate},
+    )
+
+    if err := models.MigrateAttendance(db); err != nil {
+        t.Fatalf("MigrateAttendance: %v", err)
+    }
+
+    // De la lista diaria repetida queda el último registro; el de la materia se conserva
+    var records []models.Attendance
+    if err := db.Order("attendance_id").Find(&records).Error; err != nil {
+        t.Fatalf("consultar asistencia: %v", err)
+    }
+    if len(records) != 2 || records[0].Status != models.AttendanceAbsent || records[1].SubjectKey != subject.SubjectID {
+        t.Fatalf("registros = %+v, se esperaba la falta diaria y el retardo de la materia", records)
+    }
+}
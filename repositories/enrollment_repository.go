@@ -0,0 +1,218 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// EnrollmentSortFields son las columnas por las que se puede ordenar el listado de inscripciones
+var EnrollmentSortFields = []string{"enrollment_id", "student_id", "subject_id", "term_id"}
+
+// EnrollmentFilter contiene los filtros del listado de inscripciones; 0 no filtra
+type EnrollmentFilter struct {
+    StudentID int
+    SubjectID int
+    TermID    int
+    // GroupID filtra por el grupo actual del estudiante
+    GroupID   int
+}
+
+// GroupEnrollmentResult resume una inscripción o baja de un grupo completo
+type GroupEnrollmentResult struct {
+    // Changed son los estudiantes inscritos o dados de baja
+    Changed int
+    // Skipped son los que ya estaban inscritos, o los que no se dieron de baja por tener calificaciones
+    Skipped int
+}
+
+// EnrollmentRepository define el acceso a datos de inscripciones
+type EnrollmentRepository interface {
+    Create(enrollment *models.Enrollment) error
+    List(filter EnrollmentFilter, opts ListOptions) ([]models.Enrollment, int64, error)
+    FindByID(id int) (*models.Enrollment, error)
+    // Delete devuelve ErrInUse si el estudiante ya tiene calificaciones de la materia en el periodo
+    Delete(id int) error
+    // EnrollGroup inscribe a todos los estudiantes del grupo que todavía no estén inscritos
+    EnrollGroup(groupID, subjectID, termID int) (GroupEnrollmentResult, error)
+    // UnenrollGroup da de baja a los estudiantes del grupo que no tengan calificaciones
+    UnenrollGroup(groupID, subjectID, termID int) (GroupEnrollmentResult, error)
+    // IsEnrolled indica si el estudiante está inscrito en la materia en alguno de los periodos
+    IsEnrolled(studentID, subjectID int, termIDs []int) (bool, error)
+    // EnrolledStudents devuelve cuáles de los estudiantes están inscritos en la materia en alguno de los periodos
+    EnrolledStudents(subjectID int, termIDs []int, studentIDs []int) ([]int, error)
+}
+
+// GormEnrollmentRepository implementa EnrollmentRepository sobre GORM
+type GormEnrollmentRepository struct {
+    db *gorm.DB
+}
+
+// NewGormEnrollmentRepository crea un repositorio de inscripciones respaldado por la base de datos
+func NewGormEnrollmentRepository(db *gorm.DB) *GormEnrollmentRepository {
+    return &GormEnrollmentRepository{db: db}
+}
+
+func (r *GormEnrollmentRepository) Create(enrollment *models.Enrollment) error {
+    return translateError(r.db.Create(enrollment).Error)
+}
+
+func (r *GormEnrollmentRepository) List(filter EnrollmentFilter, opts ListOptions) ([]models.Enrollment, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Enrollment{})
+
+    if filter.StudentID != 0 {
+        query = query.Where("student_id = ?", filter.StudentID)
+    }
+    if filter.SubjectID != 0 {
+        query = query.Where("subject_id = ?", filter.SubjectID)
+    }
+    if filter.TermID != 0 {
+        query = query.Where("term_id = ?", filter.TermID)
+    }
+    if filter.GroupID != 0 {
+        query = query.Where("student_id IN (?)", groupStudents(r.db, filter.GroupID))
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    enrollments := []models.Enrollment{}
+    if err := paginate(query, opts, "enrollment_id").Find(&enrollments).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return enrollments, total, nil
+}
+
+func (r *GormEnrollmentRepository) FindByID(id int) (*models.Enrollment, error) {
+    var enrollment models.Enrollment
+    if err := r.db.First(&enrollment, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &enrollment, nil
+}
+
+func (r *GormEnrollmentRepository) Delete(id int) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        var enrollment models.Enrollment
+        if err := tx.First(&enrollment, id).Error; err != nil {
+            return err
+        }
+
+        termIDs, err := NewGormTermRepository(tx).WithDescendants(enrollment.TermID)
+        if err != nil {
+            return err
+        }
+        var grades int64
+        if err := tx.Model(&models.Grade{}).
+            Where("student_id = ? AND subject_id = ? AND term_id IN ?", enrollment.StudentID, enrollment.SubjectID, termIDs).
+            Count(&grades).Error; err != nil {
+            return err
+        }
+        if grades > 0 {
+            return ErrInUse
+        }
+
+        return tx.Delete(&enrollment).Error
+    }))
+}
+
+func (r *GormEnrollmentRepository) EnrollGroup(groupID, subjectID, termID int) (GroupEnrollmentResult, error) {
+    var result GroupEnrollmentResult
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        var studentIDs []int
+        if err := groupStudents(tx, groupID).Pluck("student_id", &studentIDs).Error; err != nil {
+            return err
+        }
+
+        var enrolled []int
+        if err := tx.Model(&models.Enrollment{}).
+            Where("subject_id = ? AND term_id = ? AND student_id IN ?", subjectID, termID, studentIDs).
+            Pluck("student_id", &enrolled).Error; err != nil {
+            return err
+        }
+
+        enrollments := []models.Enrollment{}
+        for _, studentID := range studentIDs {
+            if containsInt(enrolled, studentID) {
+                continue
+            }
+            enrollments = append(enrollments, models.Enrollment{
+                StudentID: studentID,
+                SubjectID: subjectID,
+                TermID:    termID,
+            })
+        }
+        result.Changed = len(enrollments)
+        result.Skipped = len(enrolled)
+
+        if len(enrollments) == 0 {
+            return nil
+        }
+        return tx.Create(&enrollments).Error
+    })
+    return result, translateError(err)
+}
+
+func (r *GormEnrollmentRepository) UnenrollGroup(groupID, subjectID, termID int) (GroupEnrollmentResult, error) {
+    var result GroupEnrollmentResult
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        termIDs, err := NewGormTermRepository(tx).WithDescendants(termID)
+        if err != nil {
+            return err
+        }
+
+        enrollments := tx.Model(&models.Enrollment{}).
+            Where("subject_id = ? AND term_id = ?", subjectID, termID).
+            Where("student_id IN (?)", groupStudents(tx, groupID))
+
+        var total int64
+        if err := enrollments.Count(&total).Error; err != nil {
+            return err
+        }
+
+        // Los estudiantes con calificaciones conservan la inscripción
+        deleted := tx.Where("subject_id = ? AND term_id = ?", subjectID, termID).
+            Where("student_id IN (?)", groupStudents(tx, groupID)).
+            Where("student_id NOT IN (?)", tx.Model(&models.Grade{}).
+                Select("student_id").
+                Where("subject_id = ? AND term_id IN ?", subjectID, termIDs)).
+            Delete(&models.Enrollment{})
+        if deleted.Error != nil {
+            return deleted.Error
+        }
+
+        result.Changed = int(deleted.RowsAffected)
+        result.Skipped = int(total) - result.Changed
+        return nil
+    })
+    return result, translateError(err)
+}
+
+func (r *GormEnrollmentRepository) IsEnrolled(studentID, subjectID int, termIDs []int) (bool, error) {
+    var count int64
+    if err := r.db.Model(&models.Enrollment{}).
+        Where("student_id = ? AND subject_id = ? AND term_id IN ?", studentID, subjectID, termIDs).
+        Count(&count).Error; err != nil {
+        return false, translateError(err)
+    }
+    return count > 0, nil
+}
+
+func (r *GormEnrollmentRepository) EnrolledStudents(subjectID int, termIDs []int, studentIDs []int) ([]int, error) {
+    enrolled := []int{}
+    if err := r.db.Model(&models.Enrollment{}).
+        Distinct("student_id").
+        Where("subject_id = ? AND term_id IN ? AND student_id IN ?", subjectID, termIDs, studentIDs).
+        Pluck("student_id", &enrolled).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return enrolled, nil
+}
+
+// groupStudents es la subconsulta con los IDs de los estudiantes de un grupo
+func groupStudents(db *gorm.DB, groupID int) *gorm.DB {
+    return db.Model(&models.Student{}).Select("student_id").Where("group_id = ?", groupID)
+}
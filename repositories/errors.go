@@ -0,0 +1,35 @@
+package repositories
+
+import (
+    "errors"
+
+    "gorm.io/gorm"
+)
+
+// ErrNotFound indica que el registro solicitado no existe
+var ErrNotFound = errors.New("registro no encontrado")
+
+// ErrDuplicate indica que el registro viola una restricción de unicidad
+var ErrDuplicate = errors.New("registro duplicado")
+
+// ErrInUse indica que el registro no puede eliminarse porque otros registros lo referencian
+var ErrInUse = errors.New("registro en uso")
+
+// ErrManualGrades indica que la materia ya tiene calificaciones capturadas directamente en el periodo
+var ErrManualGrades = errors.New("calificaciones capturadas directamente")
+
+// translateError convierte los errores de GORM en errores del repositorio
+func translateError(err error) error {
+    switch {
+    case err == nil:
+        return nil
+    case errors.Is(err, gorm.ErrRecordNotFound):
+        return ErrNotFound
+    case errors.Is(err, gorm.ErrDuplicatedKey):
+        return ErrDuplicate
+    case errors.Is(err, gorm.ErrForeignKeyViolated):
+        return ErrInUse
+    default:
+        return err
+    }
+}
@@ -0,0 +1,284 @@
+package repositories
+
+import (
+    "errors"
+    "math"
+    "strings"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// EvaluationRepository define el acceso a datos de los criterios de evaluación y de las
+// calificaciones por criterio. Las operaciones que cambian criterios o calificaciones recalculan
+// en la misma transacción la calificación final de los estudiantes afectados, registrando el
+// cambio en su historial a nombre de change.
+type EvaluationRepository interface {
+    // Criteria devuelve los criterios de la materia en el periodo en el orden en que se definieron
+    Criteria(subjectID, termID int) ([]models.EvaluationCriterion, error)
+    // HasCriteria indica si la calificación de la materia en el periodo se calcula por criterios
+    HasCriteria(subjectID, termID int) (bool, error)
+    FindCriterion(id int) (*models.EvaluationCriterion, error)
+    // ReplaceCriteria reemplaza los criterios de la materia en el periodo. Los criterios con el
+    // mismo nombre (sin distinguir mayúsculas) conservan sus calificaciones; quitar uno que ya
+    // tiene calificaciones devuelve ErrInUse. Si algún estudiante tiene una calificación capturada
+    // directamente, sin calificaciones por criterio, devuelve ErrManualGrades para no reemplazarla
+    // con una calculada. Devuelve las calificaciones finales recalculadas.
+    ReplaceCriteria(subjectID, termID int, criteria []models.EvaluationCriterion, change models.GradeChange) ([]models.Grade, error)
+    // DeleteCriteria elimina los criterios de la materia en el periodo; devuelve ErrInUse si
+    // alguno tiene calificaciones y ErrNotFound si no había criterios
+    DeleteCriteria(subjectID, termID int) error
+    // RecordScores guarda las calificaciones del criterio, creando o actualizando la de cada
+    // estudiante, y devuelve sus calificaciones finales recalculadas en el mismo orden
+    RecordScores(criterion *models.EvaluationCriterion, scores []models.ComponentScore, change models.GradeChange) ([]models.Grade, error)
+    // Scores devuelve las calificaciones por criterio del estudiante en la materia y periodo
+    Scores(studentID, subjectID, termID int) ([]models.ComponentScore, error)
+    // ScoredStudents devuelve los estudiantes con alguna calificación por criterio en la materia y periodo
+    ScoredStudents(subjectID, termID int) ([]int, error)
+}
+
+// GormEvaluationRepository implementa EvaluationRepository sobre GORM
+type GormEvaluationRepository struct {
+    db *gorm.DB
+}
+
+// NewGormEvaluationRepository crea un repositorio de criterios de evaluación respaldado por la base de datos
+func NewGormEvaluationRepository(db *gorm.DB) *GormEvaluationRepository {
+    return &GormEvaluationRepository{db: db}
+}
+
+func (r *GormEvaluationRepository) Criteria(subjectID, termID int) ([]models.EvaluationCriterion, error) {
+    criteria := []models.EvaluationCriterion{}
+    if err := r.db.
+        Where("subject_id = ? AND term_id = ?", subjectID, termID).
+        Order("criterion_id").
+        Find(&criteria).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return criteria, nil
+}
+
+func (r *GormEvaluationRepository) HasCriteria(subjectID, termID int) (bool, error) {
+    var count int64
+    if err := r.db.Model(&models.EvaluationCriterion{}).
+        Where("subject_id = ? AND term_id = ?", subjectID, termID).
+        Count(&count).Error; err != nil {
+        return false, translateError(err)
+    }
+    return count > 0, nil
+}
+
+func (r *GormEvaluationRepository) FindCriterion(id int) (*models.EvaluationCriterion, error) {
+    var criterion models.EvaluationCriterion
+    if err := r.db.First(&criterion, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &criterion, nil
+}
+
+func (r *GormEvaluationRepository) ReplaceCriteria(subjectID, termID int, criteria []models.EvaluationCriterion, change models.GradeChange) ([]models.Grade, error) {
+    var grades []models.Grade
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        manual, err := manualGrades(tx, subjectID, termID)
+        if err != nil {
+            return err
+        }
+        if manual > 0 {
+            return ErrManualGrades
+        }
+
+        var existing []models.EvaluationCriterion
+        if err := tx.Where("subject_id = ? AND term_id = ?", subjectID, termID).Find(&existing).Error; err != nil {
+            return err
+        }
+        byName := make(map[string]models.EvaluationCriterion, len(existing))
+        for _, criterion := range existing {
+            byName[strings.ToLower(criterion.Name)] = criterion
+        }
+
+        for i := range criteria {
+            criterion := &criteria[i]
+            criterion.SubjectID = subjectID
+            criterion.TermID = termID
+            key := strings.ToLower(criterion.Name)
+            if previous, ok := byName[key]; ok {
+                criterion.CriterionID = previous.CriterionID
+                criterion.CreatedAt = previous.CreatedAt
+                delete(byName, key)
+                if err := tx.Save(criterion).Error; err != nil {
+                    return err
+                }
+                continue
+            }
+            if err := tx.Create(criterion).Error; err != nil {
+                return err
+            }
+        }
+
+        // Los criterios que quedan en byName ya no forman parte de la evaluación
+        for _, removed := range byName {
+            if err := deleteUnscoredCriterion(tx, removed.CriterionID); err != nil {
+                return err
+            }
+        }
+
+        studentIDs, err := scoredStudents(tx, subjectID, termID)
+        if err != nil {
+            return err
+        }
+        grades = make([]models.Grade, len(studentIDs))
+        for i, studentID := range studentIDs {
+            if grades[i], err = recomputeGrade(tx, studentID, subjectID, termID, change); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return grades, nil
+}
+
+func (r *GormEvaluationRepository) DeleteCriteria(subjectID, termID int) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        var criteria []models.EvaluationCriterion
+        if err := tx.Where("subject_id = ? AND term_id = ?", subjectID, termID).Find(&criteria).Error; err != nil {
+            return err
+        }
+        if len(criteria) == 0 {
+            return gorm.ErrRecordNotFound
+        }
+        for _, criterion := range criteria {
+            if err := deleteUnscoredCriterion(tx, criterion.CriterionID); err != nil {
+                return err
+            }
+        }
+        return nil
+    }))
+}
+
+// deleteUnscoredCriterion elimina el criterio dentro de la transacción tx si no tiene
+// calificaciones; si las tiene devuelve ErrInUse para no perderlas en cascada
+func deleteUnscoredCriterion(tx *gorm.DB, criterionID int) error {
+    var count int64
+    if err := tx.Model(&models.ComponentScore{}).Where("criterion_id = ?", criterionID).Count(&count).Error; err != nil {
+        return err
+    }
+    if count > 0 {
+        return ErrInUse
+    }
+    return tx.Delete(&models.EvaluationCriterion{}, criterionID).Error
+}
+
+func (r *GormEvaluationRepository) RecordScores(criterion *models.EvaluationCriterion, scores []models.ComponentScore, change models.GradeChange) ([]models.Grade, error) {
+    grades := make([]models.Grade, len(scores))
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        for i := range scores {
+            score := &scores[i]
+            score.CriterionID = criterion.CriterionID
+            score.RecordedBy = change.UserID
+            score.RecordedByName = change.Username
+
+            var existing models.ComponentScore
+            err := tx.Where("student_id = ? AND criterion_id = ?", score.StudentID, score.CriterionID).First(&existing).Error
+            switch {
+            case err == nil:
+                score.ScoreID = existing.ScoreID
+                score.CreatedAt = existing.CreatedAt
+                if err := tx.Save(score).Error; err != nil {
+                    return err
+                }
+            case errors.Is(err, gorm.ErrRecordNotFound):
+                if err := tx.Create(score).Error; err != nil {
+                    return err
+                }
+            default:
+                return err
+            }
+
+            if grades[i], err = recomputeGrade(tx, score.StudentID, criterion.SubjectID, criterion.TermID, change); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return grades, nil
+}
+
+func (r *GormEvaluationRepository) Scores(studentID, subjectID, termID int) ([]models.ComponentScore, error) {
+    scores := []models.ComponentScore{}
+    if err := r.db.
+        Where("student_id = ? AND criterion_id IN (?)", studentID, criteriaOf(r.db, subjectID, termID)).
+        Order("criterion_id").
+        Find(&scores).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return scores, nil
+}
+
+func (r *GormEvaluationRepository) ScoredStudents(subjectID, termID int) ([]int, error) {
+    studentIDs, err := scoredStudents(r.db, subjectID, termID)
+    return studentIDs, translateError(err)
+}
+
+// criteriaOf devuelve una subconsulta con los criterios de la materia en el periodo
+func criteriaOf(db *gorm.DB, subjectID, termID int) *gorm.DB {
+    return db.Model(&models.EvaluationCriterion{}).
+        Select("criterion_id").
+        Where("subject_id = ? AND term_id = ?", subjectID, termID)
+}
+
+// scoredStudents devuelve los estudiantes con alguna calificación por criterio en la materia y periodo
+func scoredStudents(db *gorm.DB, subjectID, termID int) ([]int, error) {
+    studentIDs := []int{}
+    err := db.Model(&models.ComponentScore{}).
+        Distinct("student_id").
+        Where("criterion_id IN (?)", criteriaOf(db, subjectID, termID)).
+        Order("student_id").
+        Pluck("student_id", &studentIDs).Error
+    return studentIDs, err
+}
+
+// manualGrades cuenta las calificaciones de la materia en el periodo de estudiantes sin
+// calificaciones por criterio, es decir, las capturadas directamente
+func manualGrades(db *gorm.DB, subjectID, termID int) (int64, error) {
+    var count int64
+    err := db.Model(&models.Grade{}).
+        Where("subject_id = ? AND term_id = ?", subjectID, termID).
+        Where("student_id NOT IN (?)", db.Model(&models.ComponentScore{}).
+            Select("student_id").
+            Where("criterion_id IN (?)", criteriaOf(db, subjectID, termID))).
+        Count(&count).Error
+    return count, err
+}
+
+// recomputeGrade calcula dentro de la transacción tx la calificación final del estudiante como la
+// suma ponderada de sus calificaciones por criterio, contando como 0 los criterios sin capturar,
+// y la guarda con upsertGrade
+func recomputeGrade(tx *gorm.DB, studentID, subjectID, termID int, change models.GradeChange) (models.Grade, error) {
+    var points float64
+    err := tx.Table("component_scores").
+        Joins("JOIN evaluation_criteria ON evaluation_criteria.criterion_id = component_scores.criterion_id").
+        Select("COALESCE(SUM(evaluation_criteria.weight * component_scores.score), 0)").
+        Where("component_scores.student_id = ? AND evaluation_criteria.subject_id = ? AND evaluation_criteria.term_id = ?", studentID, subjectID, termID).
+        Row().Scan(&points)
+    if err != nil {
+        return models.Grade{}, err
+    }
+
+    grade := models.Grade{
+        StudentID: studentID,
+        SubjectID: subjectID,
+        TermID:    &termID,
+        Grade:     math.Round(points) / 100,
+    }
+    if _, err := upsertGrade(tx, &grade, change); err != nil {
+        return models.Grade{}, err
+    }
+    return grade, nil
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+    "errors"
+    "testing"
+
+    "ControlEscolar/models"
+)
+
+func TestGormEvaluationRepositoryRejectsCriteriaOverManualGrades(t *testing.T) {
+    db := newTestDB(t)
+    evaluations := NewGormEvaluationRepository(db)
+    grades := NewGormGradeRepository(db)
+
+    term := newTestTerm(t, db)
+    subject := &models.Subject{Name: "Matemáticas"}
+    student := &models.Student{Name: "Ana", Email: "ana@example.com"}
+    mustCreate(t, db, subject, student)
+
+    manual := &models.Grade{StudentID: student.StudentID, SubjectID: subject.SubjectID, TermID: &term.TermID, Grade: 85}
+    if err := grades.Create(manual, models.GradeChange{}); err != nil {
+        t.Fatalf("crear calificación: %v", err)
+    }
+
+    // La calificación capturada directamente no tiene desglose; recalcularla la dejaría en 0
+    criteria := []models.EvaluationCriterion{{Name: "Examen", Weight: 100}}
+    if _, err := evaluations.ReplaceCriteria(subject.SubjectID, term.TermID, criteria, models.GradeChange{}); !errors.Is(err, ErrManualGrades) {
+        t.Fatalf("ReplaceCriteria = %v, se esperaba ErrManualGrades", err)
+    }
+    if computed, err := evaluations.HasCriteria(subject.SubjectID, term.TermID); err != nil || computed {
+        t.Fatalf("HasCriteria = %v, %v; no se debieron guardar los criterios", computed, err)
+    }
+    saved, err := grades.FindByID(manual.GradeID)
+    if err != nil || saved.Grade != 85 {
+        t.Fatalf("la calificación capturada cambió: %+v, %v", saved, err)
+    }
+
+    // Sin la calificación capturada los criterios se pueden definir
+    if err := grades.Delete(manual.GradeID, models.GradeChange{}); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    if _, err := evaluations.ReplaceCriteria(subject.SubjectID, term.TermID, criteria, models.GradeChange{}); err != nil {
+        t.Fatalf("ReplaceCriteria: %v", err)
+    }
+}
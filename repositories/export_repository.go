@@ -0,0 +1,95 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "ControlEscolar/models"
+)
+
+// ExportFilter acota los registros exportados; 0 o vacío no filtra
+type ExportFilter struct {
+    GroupID   int
+    SubjectID int
+    // TermIDs limita las calificaciones a los periodos indicados
+    TermIDs   []int
+}
+
+// ExportRepository recorre registros para exportarlos sin cargarlos todos en memoria.
+// Cada método llama a fn por cada fila, en orden de ID, y se detiene en el primer error de fn.
+// Los registros eliminados no se exportan, tampoco las calificaciones de estudiantes o materias eliminados.
+type ExportRepository interface {
+    EachStudent(filter ExportFilter, fn func(models.StudentExportRow) error) error
+    EachSubject(fn func(models.Subject) error) error
+    EachGrade(filter ExportFilter, fn func(models.GradeExportRow) error) error
+}
+
+// GormExportRepository implementa ExportRepository leyendo las filas con un cursor de la base de datos
+type GormExportRepository struct {
+    db *gorm.DB
+}
+
+// NewGormExportRepository crea un repositorio de exportación respaldado por la base de datos
+func NewGormExportRepository(db *gorm.DB) *GormExportRepository {
+    return &GormExportRepository{db: db}
+}
+
+func (r *GormExportRepository) EachStudent(filter ExportFilter, fn func(models.StudentExportRow) error) error {
+    query := r.db.Table("students").
+        Select(`students.student_id, students.name, students.email, students.group_id,
+            class_groups.name AS group_name, class_groups.school_year`).
+        Joins("LEFT JOIN ? AS class_groups ON class_groups.group_id = students.group_id", clause.Table{Name: models.Group{}.TableName()}).
+        Where("students.deleted_at IS NULL").
+        Order("students.student_id")
+    if filter.GroupID != 0 {
+        query = query.Where("students.group_id = ?", filter.GroupID)
+    }
+    return eachRow(r.db, query, fn)
+}
+
+func (r *GormExportRepository) EachSubject(fn func(models.Subject) error) error {
+    return eachRow(r.db, r.db.Model(&models.Subject{}).Order("subject_id"), fn)
+}
+
+func (r *GormExportRepository) EachGrade(filter ExportFilter, fn func(models.GradeExportRow) error) error {
+    query := r.db.Table("grades").
+        Select(`grades.grade_id, grades.student_id, students.name AS student_name, students.group_id,
+            class_groups.name AS group_name, grades.subject_id, subjects.name AS subject_name,
+            grades.term_id, terms.name AS term_name, terms.school_year, grades.grade`).
+        Joins("JOIN students ON students.student_id = grades.student_id").
+        Joins("JOIN subjects ON subjects.subject_id = grades.subject_id").
+        Joins("LEFT JOIN terms ON terms.term_id = grades.term_id").
+        Joins("LEFT JOIN ? AS class_groups ON class_groups.group_id = students.group_id", clause.Table{Name: models.Group{}.TableName()}).
+        Where("grades.deleted_at IS NULL AND students.deleted_at IS NULL AND subjects.deleted_at IS NULL").
+        Order("grades.grade_id")
+    if filter.GroupID != 0 {
+        query = query.Where("students.group_id = ?", filter.GroupID)
+    }
+    if filter.SubjectID != 0 {
+        query = query.Where("grades.subject_id = ?", filter.SubjectID)
+    }
+    if len(filter.TermIDs) > 0 {
+        query = query.Where("grades.term_id IN ?", filter.TermIDs)
+    }
+    return eachRow(r.db, query, fn)
+}
+
+// eachRow ejecuta la consulta y convierte cada fila del cursor en T antes de pasarla a fn
+func eachRow[T any](db *gorm.DB, query *gorm.DB, fn func(T) error) error {
+    rows, err := query.Rows()
+    if err != nil {
+        return translateError(err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var row T
+        if err := db.ScanRows(rows, &row); err != nil {
+            return translateError(err)
+        }
+        if err := fn(row); err != nil {
+            return err
+        }
+    }
+    return translateError(rows.Err())
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// GradeLockSortFields son las columnas por las que se puede ordenar el listado de cierres
+var GradeLockSortFields = []string{"lock_id", "subject_id", "group_id", "term_id", "closed_at"}
+
+// GradeLockEventSortFields son las columnas por las que se puede ordenar la bitácora de cierres
+var GradeLockEventSortFields = []string{"event_id", "created_at"}
+
+// GradeLockFilter contiene los filtros de los cierres y de su bitácora; 0 no filtra
+type GradeLockFilter struct {
+    SubjectID int
+    GroupID   int
+    TermID    int
+}
+
+// GradeLockRepository define el acceso a datos de los cierres de calificaciones. Cerrar y reabrir
+// quedan registrados en la bitácora, en la misma transacción, a nombre de change.
+type GradeLockRepository interface {
+    // Close cierra las calificaciones; devuelve ErrDuplicate si ya estaban cerradas
+    Close(lock *models.GradeLock, change models.GradeChange) error
+    List(filter GradeLockFilter, opts ListOptions) ([]models.GradeLock, int64, error)
+    FindByID(id int) (*models.GradeLock, error)
+    // Reopen elimina el cierre y devuelve el registro eliminado
+    Reopen(id int, change models.GradeChange) (*models.GradeLock, error)
+    // IsLocked indica si las calificaciones de la materia en el grupo están cerradas en alguno de los periodos
+    IsLocked(subjectID, groupID int, termIDs []int) (bool, error)
+    // Events devuelve la bitácora de cierres y reaperturas
+    Events(filter GradeLockFilter, opts ListOptions) ([]models.GradeLockEvent, int64, error)
+}
+
+// GormGradeLockRepository implementa GradeLockRepository sobre GORM
+type GormGradeLockRepository struct {
+    db *gorm.DB
+}
+
+// NewGormGradeLockRepository crea un repositorio de cierres respaldado por la base de datos
+func NewGormGradeLockRepository(db *gorm.DB) *GormGradeLockRepository {
+    return &GormGradeLockRepository{db: db}
+}
+
+func (r *GormGradeLockRepository) Close(lock *models.GradeLock, change models.GradeChange) error {
+    lock.ClosedBy = change.UserID
+    lock.ClosedByName = change.Username
+    lock.ClosedAt = time.Now().UTC()
+
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(lock).Error; err != nil {
+            return err
+        }
+        event := models.NewGradeLockEvent(*lock, models.GradeLockActionClose, change)
+        return tx.Create(&event).Error
+    }))
+}
+
+func (r *GormGradeLockRepository) List(filter GradeLockFilter, opts ListOptions) ([]models.GradeLock, int64, error) {
+    opts = opts.Normalize()
+    query := applyGradeLockFilter(r.db.Model(&models.GradeLock{}), filter)
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    locks := []models.GradeLock{}
+    if err := paginate(query, opts, "lock_id").Find(&locks).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return locks, total, nil
+}
+
+func (r *GormGradeLockRepository) FindByID(id int) (*models.GradeLock, error) {
+    var lock models.GradeLock
+    if err := r.db.First(&lock, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &lock, nil
+}
+
+func (r *GormGradeLockRepository) Reopen(id int, change models.GradeChange) (*models.GradeLock, error) {
+    var lock models.GradeLock
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.First(&lock, id).Error; err != nil {
+            return err
+        }
+        if err := tx.Delete(&lock).Error; err != nil {
+            return err
+        }
+        event := models.NewGradeLockEvent(lock, models.GradeLockActionReopen, change)
+        return tx.Create(&event).Error
+    })
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return &lock, nil
+}
+
+func (r *GormGradeLockRepository) IsLocked(subjectID, groupID int, termIDs []int) (bool, error) {
+    var count int64
+    if err := r.db.Model(&models.GradeLock{}).
+        Where("subject_id = ? AND group_id = ? AND term_id IN ?", subjectID, groupID, termIDs).
+        Count(&count).Error; err != nil {
+        return false, translateError(err)
+    }
+    return count > 0, nil
+}
+
+func (r *GormGradeLockRepository) Events(filter GradeLockFilter, opts ListOptions) ([]models.GradeLockEvent, int64, error) {
+    opts = opts.Normalize()
+    query := applyGradeLockFilter(r.db.Model(&models.GradeLockEvent{}), filter)
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    events := []models.GradeLockEvent{}
+    if err := paginate(query, opts, "event_id").Find(&events).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return events, total, nil
+}
+
+// applyGradeLockFilter agrega a la consulta los filtros por materia, grupo y periodo
+func applyGradeLockFilter(query *gorm.DB, filter GradeLockFilter) *gorm.DB {
+    if filter.SubjectID != 0 {
+        query = query.Where("subject_id = ?", filter.SubjectID)
+    }
+    if filter.GroupID != 0 {
+        query = query.Where("group_id = ?", filter.GroupID)
+    }
+    if filter.TermID != 0 {
+        query = query.Where("term_id = ?", filter.TermID)
+    }
+    return query
+}
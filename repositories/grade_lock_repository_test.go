@@ -0,0 +1,41 @@
+package repositories
+
+import (
+    "testing"
+
+    "ControlEscolar/models"
+)
+
+func TestGormGradeLockRepositoryLocksContainedTerms(t *testing.T) {
+    db := newTestDB(t)
+    locks := NewGormGradeLockRepository(db)
+    terms := NewGormTermRepository(db)
+
+    schoolYear := newTestTerm(t, db)
+    semester := &models.Term{Name: "Primer semestre", SchoolYear: schoolYear.SchoolYear, Kind: models.TermKindSemester, ParentID: &schoolYear.TermID, StartDate: schoolYear.StartDate, EndDate: schoolYear.StartDate.AddDate(0, 5, 0)}
+    mustCreate(t, db, semester)
+    subject := &models.Subject{Name: "Matemáticas"}
+    group := &models.Group{SchoolYear: schoolYear.SchoolYear, GradeLevel: 1, Section: "A", Name: "1A"}
+    mustCreate(t, db, subject, group)
+
+    termIDs, err := terms.WithAncestors(semester.TermID)
+    if err != nil {
+        t.Fatalf("WithAncestors: %v", err)
+    }
+
+    lock := &models.GradeLock{SubjectID: subject.SubjectID, GroupID: group.GroupID, TermID: schoolYear.TermID}
+    if err := locks.Close(lock, models.GradeChange{Username: "admin"}); err != nil {
+        t.Fatalf("Close: %v", err)
+    }
+    // Cerrar el ciclo escolar también cierra el semestre que contiene
+    if locked, err := locks.IsLocked(subject.SubjectID, group.GroupID, termIDs); err != nil || !locked {
+        t.Fatalf("IsLocked = %v, %v; se esperaba true", locked, err)
+    }
+
+    if _, err := locks.Reopen(lock.LockID, models.GradeChange{Username: "admin"}); err != nil {
+        t.Fatalf("Reopen: %v", err)
+    }
+    if locked, err := locks.IsLocked(subject.SubjectID, group.GroupID, termIDs); err != nil || locked {
+        t.Fatalf("IsLocked después de reabrir = %v, %v; se esperaba false", locked, err)
+    }
+}
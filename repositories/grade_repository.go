@@ -0,0 +1,264 @@
+package repositories
+
+import (
+    "errors"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// GradeRepository define el acceso a datos de calificaciones. Las operaciones que modifican
+// calificaciones registran el cambio en el historial, en la misma transacción, a nombre de change.
+type GradeRepository interface {
+    Create(grade *models.Grade, change models.GradeChange) error
+    FindByID(id int) (*models.Grade, error)
+    // FindWithDeleted busca la calificación aunque esté eliminada
+    FindWithDeleted(id int) (*models.Grade, error)
+    FindByIDAndStudent(gradeID, studentID int) (*models.Grade, error)
+    // FindByKey busca la calificación de un estudiante en una materia y periodo, aunque esté
+    // eliminada, porque sigue ocupando la llave
+    FindByKey(studentID, subjectID, termID int) (*models.Grade, error)
+    // FindByStudent devuelve las calificaciones del estudiante; si termIDs no está vacío, solo las de esos periodos.
+    // Sin includeDeleted omite las calificaciones eliminadas y las de materias eliminadas.
+    FindByStudent(studentID int, termIDs []int, includeDeleted bool) ([]models.Grade, error)
+    // SubjectAverages calcula el promedio por materia de un estudiante, opcionalmente solo en termIDs.
+    // No considera calificaciones eliminadas ni materias eliminadas.
+    SubjectAverages(studentID int, termIDs []int) ([]models.SubjectAverage, error)
+    // Update guarda el valor de la calificación; si no cambió no se registra en el historial
+    Update(grade *models.Grade, change models.GradeChange) error
+    // Upsert crea la calificación o actualiza la existente con la misma llave de estudiante,
+    // materia y periodo; indica si se creó un registro nuevo. Si la existente estaba eliminada
+    // se restaura con el nuevo valor y cuenta como creada.
+    Upsert(grade *models.Grade, change models.GradeChange) (bool, error)
+    // UpsertMany aplica Upsert a todas las calificaciones en una sola transacción; si alguna
+    // falla no se guarda ninguna. Indica por cada una si se creó un registro nuevo.
+    UpsertMany(grades []models.Grade, change models.GradeChange) ([]bool, error)
+    // Delete marca la calificación como eliminada
+    Delete(id int, change models.GradeChange) error
+    // Restore quita la marca de eliminada; si la calificación no estaba eliminada la devuelve sin cambios
+    Restore(id int, change models.GradeChange) (*models.Grade, error)
+    // History devuelve los cambios de una calificación del más antiguo al más reciente,
+    // incluso si la calificación ya fue eliminada
+    History(gradeID int) ([]models.GradeHistory, error)
+}
+
+// GormGradeRepository implementa GradeRepository sobre GORM
+type GormGradeRepository struct {
+    db *gorm.DB
+}
+
+// NewGormGradeRepository crea un repositorio de calificaciones respaldado por la base de datos
+func NewGormGradeRepository(db *gorm.DB) *GormGradeRepository {
+    return &GormGradeRepository{db: db}
+}
+
+func (r *GormGradeRepository) Create(grade *models.Grade, change models.GradeChange) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(grade).Error; err != nil {
+            return err
+        }
+        return recordGradeChange(tx, grade.GradeID, models.GradeActionCreate, nil, &grade.Grade, change)
+    }))
+}
+
+func (r *GormGradeRepository) FindByID(id int) (*models.Grade, error) {
+    var grade models.Grade
+    if err := r.db.First(&grade, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &grade, nil
+}
+
+func (r *GormGradeRepository) FindWithDeleted(id int) (*models.Grade, error) {
+    var grade models.Grade
+    if err := r.db.Unscoped().First(&grade, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &grade, nil
+}
+
+func (r *GormGradeRepository) FindByIDAndStudent(gradeID, studentID int) (*models.Grade, error) {
+    var grade models.Grade
+    if err := r.db.
+        Where("grade_id = ? AND student_id = ?", gradeID, studentID).
+        First(&grade).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &grade, nil
+}
+
+func (r *GormGradeRepository) FindByKey(studentID, subjectID, termID int) (*models.Grade, error) {
+    var grade models.Grade
+    if err := r.db.Unscoped().
+        Where("student_id = ? AND subject_id = ? AND term_id = ?", studentID, subjectID, termID).
+        First(&grade).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &grade, nil
+}
+
+func (r *GormGradeRepository) FindByStudent(studentID int, termIDs []int, includeDeleted bool) ([]models.Grade, error) {
+    query := r.db.Where("student_id = ?", studentID)
+    if includeDeleted {
+        query = query.Unscoped()
+    } else {
+        query = query.Where("subject_id IN (?)", activeSubjectIDs(r.db))
+    }
+    if len(termIDs) > 0 {
+        query = query.Where("term_id IN ?", termIDs)
+    }
+
+    var grades []models.Grade
+    if err := query.Order("grade_id").Find(&grades).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return grades, nil
+}
+
+func (r *GormGradeRepository) SubjectAverages(studentID int, termIDs []int) ([]models.SubjectAverage, error) {
+    query := r.db.Model(&models.Grade{}).
+        Select("subject_id, AVG(grade) AS average, COUNT(*) AS grade_count").
+        Where("student_id = ?", studentID).
+        Where("subject_id IN (?)", activeSubjectIDs(r.db))
+    if len(termIDs) > 0 {
+        query = query.Where("term_id IN ?", termIDs)
+    }
+
+    averages := []models.SubjectAverage{}
+    if err := query.Group("subject_id").Order("subject_id").Scan(&averages).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return averages, nil
+}
+
+func (r *GormGradeRepository) Update(grade *models.Grade, change models.GradeChange) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        // El valor anterior se lee dentro de la transacción para no perder cambios concurrentes
+        var existing models.Grade
+        if err := tx.First(&existing, grade.GradeID).Error; err != nil {
+            return err
+        }
+        if err := tx.Save(grade).Error; err != nil {
+            return err
+        }
+        if existing.Grade == grade.Grade {
+            return nil
+        }
+        return recordGradeChange(tx, grade.GradeID, models.GradeActionUpdate, &existing.Grade, &grade.Grade, change)
+    }))
+}
+
+func (r *GormGradeRepository) Upsert(grade *models.Grade, change models.GradeChange) (bool, error) {
+    created := false
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        var err error
+        created, err = upsertGrade(tx, grade, change)
+        return err
+    })
+    return created, translateError(err)
+}
+
+func (r *GormGradeRepository) UpsertMany(grades []models.Grade, change models.GradeChange) ([]bool, error) {
+    created := make([]bool, len(grades))
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        for i := range grades {
+            var err error
+            if created[i], err = upsertGrade(tx, &grades[i], change); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return created, nil
+}
+
+// upsertGrade crea, actualiza o restaura la calificación dentro de la transacción tx y registra el cambio
+func upsertGrade(tx *gorm.DB, grade *models.Grade, change models.GradeChange) (bool, error) {
+    var existing models.Grade
+    err := tx.Unscoped().
+        Where("student_id = ? AND subject_id = ? AND term_id = ?", grade.StudentID, grade.SubjectID, grade.TermID).
+        First(&existing).Error
+    switch {
+    case err == nil && existing.DeletedAt.Valid:
+        existing.Grade = grade.Grade
+        existing.DeletedAt = gorm.DeletedAt{}
+        if err := tx.Unscoped().Save(&existing).Error; err != nil {
+            return false, err
+        }
+        *grade = existing
+        return true, recordGradeChange(tx, grade.GradeID, models.GradeActionRestore, nil, &grade.Grade, change)
+    case err == nil:
+        oldGrade := existing.Grade
+        existing.Grade = grade.Grade
+        if err := tx.Save(&existing).Error; err != nil {
+            return false, err
+        }
+        *grade = existing
+        if oldGrade == grade.Grade {
+            return false, nil
+        }
+        return false, recordGradeChange(tx, grade.GradeID, models.GradeActionUpdate, &oldGrade, &grade.Grade, change)
+    case errors.Is(err, gorm.ErrRecordNotFound):
+        if err := tx.Create(grade).Error; err != nil {
+            return false, err
+        }
+        return true, recordGradeChange(tx, grade.GradeID, models.GradeActionCreate, nil, &grade.Grade, change)
+    default:
+        return false, err
+    }
+}
+
+// recordGradeChange agrega un registro al historial dentro de la transacción tx
+func recordGradeChange(tx *gorm.DB, gradeID int, action string, oldGrade, newGrade *float64, change models.GradeChange) error {
+    entry := models.NewGradeHistory(gradeID, action, oldGrade, newGrade, change)
+    return tx.Create(&entry).Error
+}
+
+func (r *GormGradeRepository) Delete(id int, change models.GradeChange) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        var existing models.Grade
+        if err := tx.First(&existing, id).Error; err != nil {
+            return err
+        }
+        if err := tx.Delete(&existing).Error; err != nil {
+            return err
+        }
+        return recordGradeChange(tx, id, models.GradeActionDelete, &existing.Grade, nil, change)
+    }))
+}
+
+func (r *GormGradeRepository) Restore(id int, change models.GradeChange) (*models.Grade, error) {
+    var grade models.Grade
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Unscoped().First(&grade, id).Error; err != nil {
+            return err
+        }
+        if !grade.DeletedAt.Valid {
+            return nil
+        }
+        if err := tx.Unscoped().Model(&grade).Update("deleted_at", nil).Error; err != nil {
+            return err
+        }
+        grade.DeletedAt = gorm.DeletedAt{}
+        return recordGradeChange(tx, id, models.GradeActionRestore, nil, &grade.Grade, change)
+    })
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return &grade, nil
+}
+
+func (r *GormGradeRepository) History(gradeID int) ([]models.GradeHistory, error) {
+    history := []models.GradeHistory{}
+    if err := r.db.
+        Where("grade_id = ?", gradeID).
+        Order("changed_at, history_id").
+        Find(&history).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return history, nil
+}
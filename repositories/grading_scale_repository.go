@@ -0,0 +1,238 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// GradingScaleSortFields son las columnas por las que se puede ordenar el listado de escalas
+var GradingScaleSortFields = []string{"scale_id", "name"}
+
+// GradingScaleAssignmentSortFields son las columnas por las que se puede ordenar el listado de asignaciones de escalas
+var GradingScaleAssignmentSortFields = []string{"assignment_id", "scale_id", "grade_level", "subject_id"}
+
+// GradingScaleAssignmentFilter contiene los filtros de las asignaciones de escalas; 0 no filtra
+type GradingScaleAssignmentFilter struct {
+    ScaleID    int
+    GradeLevel int
+    SubjectID  int
+}
+
+// GradingScaleRepository define el acceso a datos de las escalas de calificación y sus asignaciones
+type GradingScaleRepository interface {
+    Create(scale *models.GradingScale) error
+    List(opts ListOptions) ([]models.GradingScale, int64, error)
+    FindByID(id int) (*models.GradingScale, error)
+    // Update guarda la escala y reemplaza sus letras
+    Update(scale *models.GradingScale) error
+    // Delete elimina la escala con sus letras; devuelve ErrInUse si está asignada
+    Delete(id int) error
+    // Assign guarda la asignación; devuelve ErrDuplicate si ya hay una escala asignada al mismo
+    // grado escolar y materia
+    Assign(assignment *models.GradingScaleAssignment) error
+    Assignments(filter GradingScaleAssignmentFilter, opts ListOptions) ([]models.GradingScaleAssignment, int64, error)
+    FindAssignment(id int) (*models.GradingScaleAssignment, error)
+    Unassign(id int) error
+    // Resolve devuelve la escala asignada a la materia en el grado escolar, a la materia o al grado,
+    // en ese orden; gradeLevel nulo solo considera la materia. Devuelve ErrNotFound si no hay asignación.
+    Resolve(subjectID int, gradeLevel *int) (*models.GradingScale, error)
+}
+
+// GormGradingScaleRepository implementa GradingScaleRepository sobre GORM
+type GormGradingScaleRepository struct {
+    db *gorm.DB
+}
+
+// NewGormGradingScaleRepository crea un repositorio de escalas de calificación respaldado por la base de datos
+func NewGormGradingScaleRepository(db *gorm.DB) *GormGradingScaleRepository {
+    return &GormGradingScaleRepository{db: db}
+}
+
+func (r *GormGradingScaleRepository) Create(scale *models.GradingScale) error {
+    return translateError(r.db.Create(scale).Error)
+}
+
+func (r *GormGradingScaleRepository) List(opts ListOptions) ([]models.GradingScale, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.GradingScale{})
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    scales := []models.GradingScale{}
+    if err := paginate(query, opts, "scale_id").Preload("Letters", orderLetters).Find(&scales).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return scales, total, nil
+}
+
+func (r *GormGradingScaleRepository) FindByID(id int) (*models.GradingScale, error) {
+    var scale models.GradingScale
+    if err := r.db.Preload("Letters", orderLetters).First(&scale, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &scale, nil
+}
+
+func (r *GormGradingScaleRepository) Update(scale *models.GradingScale) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Where("scale_id = ?", scale.ScaleID).Delete(&models.ScaleLetter{}).Error; err != nil {
+            return err
+        }
+        for i := range scale.Letters {
+            scale.Letters[i].LetterID = 0
+            scale.Letters[i].ScaleID = scale.ScaleID
+        }
+        // Las letras se crean por separado para no actualizar las que se acaban de eliminar
+        if err := tx.Omit("Letters").Save(scale).Error; err != nil {
+            return err
+        }
+        if len(scale.Letters) == 0 {
+            return nil
+        }
+        return tx.Create(&scale.Letters).Error
+    }))
+}
+
+func (r *GormGradingScaleRepository) Delete(id int) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        var assigned int64
+        if err := tx.Model(&models.GradingScaleAssignment{}).Where("scale_id = ?", id).Count(&assigned).Error; err != nil {
+            return err
+        }
+        if assigned > 0 {
+            return ErrInUse
+        }
+        if err := tx.Where("scale_id = ?", id).Delete(&models.ScaleLetter{}).Error; err != nil {
+            return err
+        }
+        result := tx.Delete(&models.GradingScale{}, id)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return ErrNotFound
+        }
+        return nil
+    }))
+}
+
+func (r *GormGradingScaleRepository) Assign(assignment *models.GradingScaleAssignment) error {
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        // grade_level y subject_id pueden ser nulos, así que la unicidad no puede delegarse a un índice
+        var count int64
+        if err := assignmentTarget(tx.Model(&models.GradingScaleAssignment{}), assignment.GradeLevel, assignment.SubjectID).
+            Count(&count).Error; err != nil {
+            return err
+        }
+        if count > 0 {
+            return ErrDuplicate
+        }
+        return tx.Create(assignment).Error
+    }))
+}
+
+func (r *GormGradingScaleRepository) Assignments(filter GradingScaleAssignmentFilter, opts ListOptions) ([]models.GradingScaleAssignment, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.GradingScaleAssignment{})
+
+    if filter.ScaleID != 0 {
+        query = query.Where("scale_id = ?", filter.ScaleID)
+    }
+    if filter.GradeLevel != 0 {
+        query = query.Where("grade_level = ?", filter.GradeLevel)
+    }
+    if filter.SubjectID != 0 {
+        query = query.Where("subject_id = ?", filter.SubjectID)
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    assignments := []models.GradingScaleAssignment{}
+    if err := paginate(query, opts, "assignment_id").Find(&assignments).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return assignments, total, nil
+}
+
+func (r *GormGradingScaleRepository) FindAssignment(id int) (*models.GradingScaleAssignment, error) {
+    var assignment models.GradingScaleAssignment
+    if err := r.db.First(&assignment, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &assignment, nil
+}
+
+func (r *GormGradingScaleRepository) Unassign(id int) error {
+    result := r.db.Delete(&models.GradingScaleAssignment{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormGradingScaleRepository) Resolve(subjectID int, gradeLevel *int) (*models.GradingScale, error) {
+    var candidates []models.GradingScaleAssignment
+    query := r.db.Where("subject_id = ? AND grade_level IS NULL", subjectID)
+    if gradeLevel != nil {
+        query = query.
+            Or("subject_id = ? AND grade_level = ?", subjectID, *gradeLevel).
+            Or("subject_id IS NULL AND grade_level = ?", *gradeLevel)
+    }
+    if err := query.Find(&candidates).Error; err != nil {
+        return nil, translateError(err)
+    }
+    if len(candidates) == 0 {
+        return nil, ErrNotFound
+    }
+
+    // La asignación más específica es la que indica materia y grado, luego la de materia
+    best := candidates[0]
+    for _, candidate := range candidates[1:] {
+        if assignmentRank(candidate) > assignmentRank(best) {
+            best = candidate
+        }
+    }
+    return r.FindByID(best.ScaleID)
+}
+
+// assignmentRank ordena las asignaciones de la menos a la más específica
+func assignmentRank(assignment models.GradingScaleAssignment) int {
+    rank := 0
+    if assignment.SubjectID != nil {
+        rank += 2
+    }
+    if assignment.GradeLevel != nil {
+        rank++
+    }
+    return rank
+}
+
+// assignmentTarget agrega a la consulta el grado escolar y la materia exactos de una asignación
+func assignmentTarget(query *gorm.DB, gradeLevel, subjectID *int) *gorm.DB {
+    if gradeLevel != nil {
+        query = query.Where("grade_level = ?", *gradeLevel)
+    } else {
+        query = query.Where("grade_level IS NULL")
+    }
+    if subjectID != nil {
+        query = query.Where("subject_id = ?", *subjectID)
+    } else {
+        query = query.Where("subject_id IS NULL")
+    }
+    return query
+}
+
+// orderLetters ordena las letras de una escala de la de mayor a la de menor valor mínimo
+func orderLetters(db *gorm.DB) *gorm.DB {
+    return db.Order("min_value DESC")
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// GroupSortFields son las columnas por las que se puede ordenar el listado de grupos
+var GroupSortFields = []string{"group_id", "name", "grade_level", "school_year"}
+
+// GroupFilter contiene los filtros del listado de grupos
+type GroupFilter struct {
+    SchoolYear string
+    GradeLevel int
+    Shift      string
+}
+
+// GroupRepository define el acceso a datos de grupos
+type GroupRepository interface {
+    Create(group *models.Group) error
+    List(filter GroupFilter, opts ListOptions) ([]models.Group, int64, error)
+    FindByID(id int) (*models.Group, error)
+    Update(group *models.Group) error
+    // Delete devuelve ErrInUse si el grupo todavía tiene estudiantes
+    Delete(id int) error
+}
+
+// GormGroupRepository implementa GroupRepository sobre GORM
+type GormGroupRepository struct {
+    db *gorm.DB
+}
+
+// NewGormGroupRepository crea un repositorio de grupos respaldado por la base de datos
+func NewGormGroupRepository(db *gorm.DB) *GormGroupRepository {
+    return &GormGroupRepository{db: db}
+}
+
+func (r *GormGroupRepository) Create(group *models.Group) error {
+    return translateError(r.db.Create(group).Error)
+}
+
+func (r *GormGroupRepository) List(filter GroupFilter, opts ListOptions) ([]models.Group, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Group{})
+
+    if filter.SchoolYear != "" {
+        query = query.Where("school_year = ?", filter.SchoolYear)
+    }
+    if filter.GradeLevel != 0 {
+        query = query.Where("grade_level = ?", filter.GradeLevel)
+    }
+    if filter.Shift != "" {
+        query = query.Where("shift = ?", filter.Shift)
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    groups := []models.Group{}
+    if err := paginate(query, opts, "group_id").Find(&groups).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return groups, total, nil
+}
+
+func (r *GormGroupRepository) FindByID(id int) (*models.Group, error) {
+    var group models.Group
+    if err := r.db.First(&group, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &group, nil
+}
+
+func (r *GormGroupRepository) Update(group *models.Group) error {
+    return translateError(r.db.Save(group).Error)
+}
+
+func (r *GormGroupRepository) Delete(id int) error {
+    // Se valida antes de borrar para devolver ErrInUse igual en todos los dialectos.
+    // Los estudiantes eliminados cuentan porque conservan la llave foránea al grupo.
+    var students int64
+    if err := r.db.Unscoped().Model(&models.Student{}).Where("group_id = ?", id).Count(&students).Error; err != nil {
+        return translateError(err)
+    }
+    if students > 0 {
+        return ErrInUse
+    }
+
+    result := r.db.Delete(&models.Group{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// GuardianSortFields son las columnas por las que se puede ordenar el listado de tutores
+var GuardianSortFields = []string{"guardian_id", "name", "email"}
+
+// GuardianFilter contiene los filtros del listado de tutores
+type GuardianFilter struct {
+    // Query busca el texto dentro del nombre o el email
+    Query     string
+    // StudentID limita el listado a los tutores del estudiante; 0 no filtra
+    StudentID int
+}
+
+// GuardianRepository define el acceso a datos de tutores y sus vínculos con estudiantes
+type GuardianRepository interface {
+    Create(guardian *models.Guardian) error
+    List(filter GuardianFilter, opts ListOptions) ([]models.Guardian, int64, error)
+    FindByID(id int) (*models.Guardian, error)
+    // FindByUserID devuelve el tutor vinculado a una cuenta de usuario
+    FindByUserID(userID int) (*models.Guardian, error)
+    Update(guardian *models.Guardian) error
+    // Delete elimina al tutor junto con sus vínculos con estudiantes
+    Delete(id int) error
+    // Link vincula al tutor con un estudiante; devuelve ErrDuplicate si ya están vinculados
+    Link(link *models.GuardianStudent) error
+    Unlink(guardianID, studentID int) error
+    // Children devuelve los vínculos del tutor con su estudiante y grupo, sin los estudiantes eliminados
+    Children(guardianID int) ([]models.GuardianStudent, error)
+    // ForStudent devuelve los vínculos de un estudiante con sus tutores
+    ForStudent(studentID int) ([]models.GuardianStudent, error)
+    // IsLinked indica si el estudiante está vinculado al tutor
+    IsLinked(guardianID, studentID int) (bool, error)
+}
+
+// GormGuardianRepository implementa GuardianRepository sobre GORM
+type GormGuardianRepository struct {
+    db *gorm.DB
+}
+
+// NewGormGuardianRepository crea un repositorio de tutores respaldado por la base de datos
+func NewGormGuardianRepository(db *gorm.DB) *GormGuardianRepository {
+    return &GormGuardianRepository{db: db}
+}
+
+func (r *GormGuardianRepository) Create(guardian *models.Guardian) error {
+    return translateError(r.db.Create(guardian).Error)
+}
+
+func (r *GormGuardianRepository) List(filter GuardianFilter, opts ListOptions) ([]models.Guardian, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Guardian{})
+
+    if filter.Query != "" {
+        pattern := likePattern(filter.Query)
+        query = query.Where("LOWER(name) LIKE ? ESCAPE '"+likeEscape+"' OR LOWER(email) LIKE ? ESCAPE '"+likeEscape+"'", pattern, pattern)
+    }
+    if filter.StudentID != 0 {
+        query = query.Where("guardian_id IN (?)",
+            r.db.Model(&models.GuardianStudent{}).Select("guardian_id").Where("student_id = ?", filter.StudentID))
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    guardians := []models.Guardian{}
+    if err := paginate(query, opts, "guardian_id").Find(&guardians).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return guardians, total, nil
+}
+
+func (r *GormGuardianRepository) FindByID(id int) (*models.Guardian, error) {
+    var guardian models.Guardian
+    if err := r.db.First(&guardian, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &guardian, nil
+}
+
+func (r *GormGuardianRepository) FindByUserID(userID int) (*models.Guardian, error) {
+    var guardian models.Guardian
+    if err := r.db.Where("user_id = ?", userID).First(&guardian).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &guardian, nil
+}
+
+func (r *GormGuardianRepository) Update(guardian *models.Guardian) error {
+    return translateError(r.db.Save(guardian).Error)
+}
+
+func (r *GormGuardianRepository) Delete(id int) error {
+    // Los vínculos del tutor con sus estudiantes se eliminan por CASCADE
+    result := r.db.Delete(&models.Guardian{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormGuardianRepository) Link(link *models.GuardianStudent) error {
+    return translateError(r.db.Create(link).Error)
+}
+
+func (r *GormGuardianRepository) Unlink(guardianID, studentID int) error {
+    result := r.db.Where("guardian_id = ? AND student_id = ?", guardianID, studentID).Delete(&models.GuardianStudent{})
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormGuardianRepository) Children(guardianID int) ([]models.GuardianStudent, error) {
+    links := []models.GuardianStudent{}
+    // La subconsulta sobre Student excluye a los estudiantes eliminados
+    err := r.db.Preload("Student.Group").
+        Where("guardian_id = ? AND student_id IN (?)", guardianID, r.db.Model(&models.Student{}).Select("student_id")).
+        Order("student_id").
+        Find(&links).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return links, nil
+}
+
+func (r *GormGuardianRepository) ForStudent(studentID int) ([]models.GuardianStudent, error) {
+    links := []models.GuardianStudent{}
+    if err := r.db.Preload("Guardian").Where("student_id = ?", studentID).Order("guardian_id").Find(&links).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return links, nil
+}
+
+func (r *GormGuardianRepository) IsLinked(guardianID, studentID int) (bool, error) {
+    var count int64
+    err := r.db.Model(&models.GuardianStudent{}).
+        Where("guardian_id = ? AND student_id = ?", guardianID, studentID).
+        Count(&count).Error
+    if err != nil {
+        return false, translateError(err)
+    }
+    return count > 0, nil
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+    "sort"
+    "strings"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// Valores por defecto de la paginación
+const (
+    DefaultPageSize = 20
+    MaxPageSize     = 100
+)
+
+// ListOptions describe la página y el orden solicitados en un listado
+type ListOptions struct {
+    Page     int
+    Limit    int
+    SortBy   string
+    SortDesc bool
+}
+
+// Normalize aplica los valores por defecto y los límites de la paginación
+func (o ListOptions) Normalize() ListOptions {
+    if o.Page < 1 {
+        o.Page = 1
+    }
+    if o.Limit < 1 {
+        o.Limit = DefaultPageSize
+    }
+    if o.Limit > MaxPageSize {
+        o.Limit = MaxPageSize
+    }
+    return o
+}
+
+// Offset devuelve el número de registros que se omiten antes de la página actual
+func (o ListOptions) Offset() int {
+    return (o.Page - 1) * o.Limit
+}
+
+// paginate aplica orden, límite y desplazamiento a una consulta.
+// defaultSort se usa si no se pidió orden y como desempate para que las páginas sean estables.
+func paginate(query *gorm.DB, opts ListOptions, defaultSort string) *gorm.DB {
+    sortBy := opts.SortBy
+    if sortBy == "" {
+        sortBy = defaultSort
+    }
+
+    query = query.Order(clause.OrderByColumn{
+        Column: clause.Column{Name: sortBy},
+        Desc:   opts.SortDesc,
+    })
+    if sortBy != defaultSort {
+        query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: defaultSort}})
+    }
+
+    return query.Offset(opts.Offset()).Limit(opts.Limit)
+}
+
+// likeEscape es el carácter de escape usado en los patrones LIKE; se declara
+// explícitamente porque SQLite no tiene uno por defecto y MySQL interpreta "\"
+const likeEscape = "!"
+
+// likePattern arma un patrón LIKE que busca el texto en cualquier posición, sin distinguir mayúsculas
+func likePattern(text string) string {
+    replacer := strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+    return "%" + strings.ToLower(replacer.Replace(text)) + "%"
+}
+
+// pageOf recorta en memoria la página solicitada de un listado ya filtrado y ordenado
+func pageOf[T any](items []T, opts ListOptions) []T {
+    start := opts.Offset()
+    if start >= len(items) {
+        return []T{}
+    }
+    end := start + opts.Limit
+    if end > len(items) {
+        end = len(items)
+    }
+    return items[start:end]
+}
+
+// sortItems ordena en memoria por la clave indicada, desempatando por ID ascendente
+// igual que paginate en las consultas SQL
+func sortItems[T any](items []T, desc bool, key func(T) string, id func(T) int) {
+    sort.SliceStable(items, func(i, j int) bool {
+        a, b := key(items[i]), key(items[j])
+        if a == b {
+            return id(items[i]) < id(items[j])
+        }
+        if desc {
+            return a > b
+        }
+        return a < b
+    })
+}
@@ -0,0 +1,289 @@
+package repositories
+
+import (
+    "sort"
+    "sync"
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// MemoryGradeRepository implementa GradeRepository en memoria, pensado para pruebas.
+// No conoce las materias, así que no omite las calificaciones de materias eliminadas.
+type MemoryGradeRepository struct {
+    mu      sync.RWMutex
+    nextID  int
+    grades  map[int]models.Grade
+    history []models.GradeHistory
+}
+
+// NewMemoryGradeRepository crea un repositorio de calificaciones vacío en memoria
+func NewMemoryGradeRepository() *MemoryGradeRepository {
+    return &MemoryGradeRepository{
+        nextID: 1,
+        grades: make(map[int]models.Grade),
+    }
+}
+
+func (r *MemoryGradeRepository) Create(grade *models.Grade, change models.GradeChange) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if grade.TermID != nil {
+        if _, ok := r.findByKey(grade.StudentID, grade.SubjectID, *grade.TermID); ok {
+            return ErrDuplicate
+        }
+    }
+
+    grade.GradeID = r.nextID
+    grade.DeletedAt = gorm.DeletedAt{}
+    r.nextID++
+    r.grades[grade.GradeID] = *grade
+    r.record(grade.GradeID, models.GradeActionCreate, nil, &grade.Grade, change)
+    return nil
+}
+
+func (r *MemoryGradeRepository) FindByID(id int) (*models.Grade, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    grade, ok := r.grades[id]
+    if !ok || grade.DeletedAt.Valid {
+        return nil, ErrNotFound
+    }
+    return &grade, nil
+}
+
+func (r *MemoryGradeRepository) FindWithDeleted(id int) (*models.Grade, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    grade, ok := r.grades[id]
+    if !ok {
+        return nil, ErrNotFound
+    }
+    return &grade, nil
+}
+
+func (r *MemoryGradeRepository) FindByIDAndStudent(gradeID, studentID int) (*models.Grade, error) {
+    grade, err := r.FindByID(gradeID)
+    if err != nil {
+        return nil, err
+    }
+    if grade.StudentID != studentID {
+        return nil, ErrNotFound
+    }
+    return grade, nil
+}
+
+func (r *MemoryGradeRepository) FindByKey(studentID, subjectID, termID int) (*models.Grade, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    grade, ok := r.findByKey(studentID, subjectID, termID)
+    if !ok {
+        return nil, ErrNotFound
+    }
+    return &grade, nil
+}
+
+func (r *MemoryGradeRepository) FindByStudent(studentID int, termIDs []int, includeDeleted bool) ([]models.Grade, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    grades := []models.Grade{}
+    for _, grade := range r.grades {
+        if grade.StudentID != studentID || (grade.DeletedAt.Valid && !includeDeleted) {
+            continue
+        }
+        if len(termIDs) > 0 && (grade.TermID == nil || !containsInt(termIDs, *grade.TermID)) {
+            continue
+        }
+        grades = append(grades, grade)
+    }
+    sortGrades(grades)
+    return grades, nil
+}
+
+func (r *MemoryGradeRepository) SubjectAverages(studentID int, termIDs []int) ([]models.SubjectAverage, error) {
+    grades, err := r.FindByStudent(studentID, termIDs, false)
+    if err != nil {
+        return nil, err
+    }
+
+    sums := make(map[int]float64)
+    averages := []models.SubjectAverage{}
+    index := make(map[int]int)
+    for _, grade := range grades {
+        i, ok := index[grade.SubjectID]
+        if !ok {
+            i = len(averages)
+            index[grade.SubjectID] = i
+            averages = append(averages, models.SubjectAverage{SubjectID: grade.SubjectID})
+        }
+        sums[grade.SubjectID] += grade.Grade
+        averages[i].GradeCount++
+    }
+
+    for i := range averages {
+        averages[i].Average = sums[averages[i].SubjectID] / float64(averages[i].GradeCount)
+    }
+    sort.Slice(averages, func(i, j int) bool {
+        return averages[i].SubjectID < averages[j].SubjectID
+    })
+    return averages, nil
+}
+
+func (r *MemoryGradeRepository) Update(grade *models.Grade, change models.GradeChange) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    existing, ok := r.grades[grade.GradeID]
+    if !ok || existing.DeletedAt.Valid {
+        return ErrNotFound
+    }
+    grade.DeletedAt = existing.DeletedAt
+    r.grades[grade.GradeID] = *grade
+    if existing.Grade != grade.Grade {
+        r.record(grade.GradeID, models.GradeActionUpdate, &existing.Grade, &grade.Grade, change)
+    }
+    return nil
+}
+
+func (r *MemoryGradeRepository) Upsert(grade *models.Grade, change models.GradeChange) (bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    return r.upsert(grade, change), nil
+}
+
+func (r *MemoryGradeRepository) UpsertMany(grades []models.Grade, change models.GradeChange) ([]bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    created := make([]bool, len(grades))
+    for i := range grades {
+        created[i] = r.upsert(&grades[i], change)
+    }
+    return created, nil
+}
+
+// upsert crea, actualiza o restaura la calificación; debe llamarse con el candado tomado
+func (r *MemoryGradeRepository) upsert(grade *models.Grade, change models.GradeChange) bool {
+    if grade.TermID != nil {
+        if existing, ok := r.findByKey(grade.StudentID, grade.SubjectID, *grade.TermID); ok && existing.DeletedAt.Valid {
+            existing.Grade = grade.Grade
+            existing.DeletedAt = gorm.DeletedAt{}
+            r.grades[existing.GradeID] = existing
+            *grade = existing
+            r.record(grade.GradeID, models.GradeActionRestore, nil, &grade.Grade, change)
+            return true
+        } else if ok {
+            oldGrade := existing.Grade
+            existing.Grade = grade.Grade
+            r.grades[existing.GradeID] = existing
+            *grade = existing
+            if oldGrade != grade.Grade {
+                r.record(grade.GradeID, models.GradeActionUpdate, &oldGrade, &grade.Grade, change)
+            }
+            return false
+        }
+    }
+
+    grade.GradeID = r.nextID
+    grade.DeletedAt = gorm.DeletedAt{}
+    r.nextID++
+    r.grades[grade.GradeID] = *grade
+    r.record(grade.GradeID, models.GradeActionCreate, nil, &grade.Grade, change)
+    return true
+}
+
+func (r *MemoryGradeRepository) Delete(id int, change models.GradeChange) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    existing, ok := r.grades[id]
+    if !ok || existing.DeletedAt.Valid {
+        return ErrNotFound
+    }
+    existing.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+    r.grades[id] = existing
+    r.record(id, models.GradeActionDelete, &existing.Grade, nil, change)
+    return nil
+}
+
+func (r *MemoryGradeRepository) Restore(id int, change models.GradeChange) (*models.Grade, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    grade, ok := r.grades[id]
+    if !ok {
+        return nil, ErrNotFound
+    }
+    if grade.DeletedAt.Valid {
+        grade.DeletedAt = gorm.DeletedAt{}
+        r.grades[id] = grade
+        r.record(id, models.GradeActionRestore, nil, &grade.Grade, change)
+    }
+    return &grade, nil
+}
+
+func (r *MemoryGradeRepository) History(gradeID int) ([]models.GradeHistory, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    history := []models.GradeHistory{}
+    for _, entry := range r.history {
+        if entry.GradeID == gradeID {
+            history = append(history, entry)
+        }
+    }
+    return history, nil
+}
+
+// record agrega un cambio al historial; debe llamarse con el candado tomado.
+// Los valores se copian para que el registro no cambie con la calificación.
+func (r *MemoryGradeRepository) record(gradeID int, action string, oldGrade, newGrade *float64, change models.GradeChange) {
+    entry := models.NewGradeHistory(gradeID, action, copyFloat(oldGrade), copyFloat(newGrade), change)
+    entry.HistoryID = len(r.history) + 1
+    r.history = append(r.history, entry)
+}
+
+// copyFloat devuelve un puntero a una copia de value, o nil si es nulo
+func copyFloat(value *float64) *float64 {
+    if value == nil {
+        return nil
+    }
+    v := *value
+    return &v
+}
+
+// findByKey busca por estudiante, materia y periodo, incluidas las eliminadas; debe llamarse con el candado tomado
+func (r *MemoryGradeRepository) findByKey(studentID, subjectID, termID int) (models.Grade, bool) {
+    for _, grade := range r.grades {
+        if grade.StudentID == studentID && grade.SubjectID == subjectID &&
+            grade.TermID != nil && *grade.TermID == termID {
+            return grade, true
+        }
+    }
+    return models.Grade{}, false
+}
+
+// sortGrades ordena las calificaciones por ID para que los resultados sean deterministas
+func sortGrades(grades []models.Grade) {
+    sort.Slice(grades, func(i, j int) bool {
+        return grades[i].GradeID < grades[j].GradeID
+    })
+}
+
+// containsInt indica si value está en values
+func containsInt(values []int, value int) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,222 @@
+package repositories
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// MemoryStudentRepository implementa StudentRepository en memoria, pensado para pruebas
+type MemoryStudentRepository struct {
+    mu       sync.RWMutex
+    nextID   int
+    students map[int]models.Student
+}
+
+// NewMemoryStudentRepository crea un repositorio de estudiantes vacío en memoria
+func NewMemoryStudentRepository() *MemoryStudentRepository {
+    return &MemoryStudentRepository{
+        nextID:   1,
+        students: make(map[int]models.Student),
+    }
+}
+
+func (r *MemoryStudentRepository) Create(student *models.Student) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if r.emailTaken(student.Email, 0) {
+        return ErrDuplicate
+    }
+
+    student.StudentID = r.nextID
+    student.DeletedAt = gorm.DeletedAt{}
+    r.nextID++
+    r.students[student.StudentID] = *student
+    return nil
+}
+
+func (r *MemoryStudentRepository) List(filter StudentFilter, opts ListOptions) ([]models.Student, int64, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    opts = opts.Normalize()
+    query := strings.ToLower(filter.Query)
+
+    students := []models.Student{}
+    for _, student := range r.students {
+        if student.DeletedAt.Valid && !filter.IncludeDeleted {
+            continue
+        }
+        if filter.GroupID != 0 && (student.GroupID == nil || *student.GroupID != filter.GroupID) {
+            continue
+        }
+        if filter.Group != "" && (student.Group == nil || student.Group.Name != filter.Group) {
+            continue
+        }
+        if query != "" &&
+            !strings.Contains(strings.ToLower(student.Name), query) &&
+            !strings.Contains(strings.ToLower(student.Email), query) {
+            continue
+        }
+        students = append(students, student)
+    }
+
+    sortItems(students, opts.SortDesc,
+        func(student models.Student) string { return studentSortKey(student, opts.SortBy) },
+        func(student models.Student) int { return student.StudentID })
+
+    return pageOf(students, opts), int64(len(students)), nil
+}
+
+func (r *MemoryStudentRepository) FindByID(id int) (*models.Student, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    student, ok := r.students[id]
+    if !ok || student.DeletedAt.Valid {
+        return nil, ErrNotFound
+    }
+    return &student, nil
+}
+
+func (r *MemoryStudentRepository) FindByIDs(ids []int) (map[int]models.Student, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    found := make(map[int]models.Student, len(ids))
+    for _, id := range ids {
+        if student, ok := r.students[id]; ok && !student.DeletedAt.Valid {
+            found[id] = student
+        }
+    }
+    return found, nil
+}
+
+func (r *MemoryStudentRepository) Update(student *models.Student) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    existing, ok := r.students[student.StudentID]
+    if !ok || existing.DeletedAt.Valid {
+        return ErrNotFound
+    }
+    if r.emailTaken(student.Email, student.StudentID) {
+        return ErrDuplicate
+    }
+
+    student.DeletedAt = existing.DeletedAt
+    r.students[student.StudentID] = *student
+    return nil
+}
+
+func (r *MemoryStudentRepository) Delete(id int) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    student, ok := r.students[id]
+    if !ok || student.DeletedAt.Valid {
+        return ErrNotFound
+    }
+    student.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+    r.students[id] = student
+    return nil
+}
+
+func (r *MemoryStudentRepository) Restore(id int) (*models.Student, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    student, ok := r.students[id]
+    if !ok {
+        return nil, ErrNotFound
+    }
+    student.DeletedAt = gorm.DeletedAt{}
+    r.students[id] = student
+    return &student, nil
+}
+
+func (r *MemoryStudentRepository) FindDeletedByEmail(email string) (*models.Student, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    for _, student := range r.students {
+        if student.DeletedAt.Valid && strings.EqualFold(student.Email, email) {
+            return &student, nil
+        }
+    }
+    return nil, ErrNotFound
+}
+
+func (r *MemoryStudentRepository) CreateMany(students []models.Student) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    // Se valida todo antes de guardar para que la operación sea atómica
+    seen := make(map[string]bool)
+    for _, student := range students {
+        email := strings.ToLower(student.Email)
+        if seen[email] || r.emailTaken(student.Email, 0) {
+            return ErrDuplicate
+        }
+        seen[email] = true
+    }
+
+    for i := range students {
+        students[i].StudentID = r.nextID
+        students[i].DeletedAt = gorm.DeletedAt{}
+        r.nextID++
+        r.students[students[i].StudentID] = students[i]
+    }
+    return nil
+}
+
+func (r *MemoryStudentRepository) ExistingEmails(emails []string) ([]string, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    existing := []string{}
+    for _, email := range emails {
+        if r.emailTaken(email, 0) {
+            existing = append(existing, strings.ToLower(email))
+        }
+    }
+    return existing, nil
+}
+
+// studentSortKey devuelve el valor por el que se ordena un estudiante
+func studentSortKey(student models.Student, sortBy string) string {
+    switch sortBy {
+    case "name":
+        return strings.ToLower(student.Name)
+    case "group":
+        if student.Group == nil {
+            return ""
+        }
+        return student.Group.Name
+    case "group_id":
+        if student.GroupID == nil {
+            return ""
+        }
+        return fmt.Sprintf("%010d", *student.GroupID)
+    case "email":
+        return strings.ToLower(student.Email)
+    default:
+        return fmt.Sprintf("%010d", student.StudentID)
+    }
+}
+
+// emailTaken indica si otro estudiante, aunque esté eliminado, ya usa el email; debe llamarse con el candado tomado
+func (r *MemoryStudentRepository) emailTaken(email string, exceptID int) bool {
+    for id, student := range r.students {
+        if id != exceptID && strings.EqualFold(student.Email, email) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// MemorySubjectRepository implementa SubjectRepository en memoria, pensado para pruebas.
+// Counts cuenta los estudiantes con las inscripciones registradas con Enroll y, si se le asigna
+// un MemoryGradeRepository, las calificaciones a partir de él.
+type MemorySubjectRepository struct {
+    mu          sync.RWMutex
+    nextID      int
+    subjects    map[int]models.Subject
+    enrollments []models.Enrollment
+    grades      *MemoryGradeRepository
+}
+
+// NewMemorySubjectRepository crea un repositorio de materias vacío en memoria
+func NewMemorySubjectRepository(grades *MemoryGradeRepository) *MemorySubjectRepository {
+    return &MemorySubjectRepository{
+        nextID:   1,
+        subjects: make(map[int]models.Subject),
+        grades:   grades,
+    }
+}
+
+func (r *MemorySubjectRepository) Create(subject *models.Subject) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if r.nameTaken(subject.Name, 0) {
+        return ErrDuplicate
+    }
+
+    subject.SubjectID = r.nextID
+    subject.DeletedAt = gorm.DeletedAt{}
+    r.nextID++
+    r.subjects[subject.SubjectID] = *subject
+    return nil
+}
+
+func (r *MemorySubjectRepository) List(filter SubjectFilter, opts ListOptions) ([]models.Subject, int64, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    opts = opts.Normalize()
+    query := strings.ToLower(filter.Query)
+
+    subjects := []models.Subject{}
+    for _, subject := range r.subjects {
+        if subject.DeletedAt.Valid && !filter.IncludeDeleted {
+            continue
+        }
+        if query != "" && !strings.Contains(strings.ToLower(subject.Name), query) {
+            continue
+        }
+        subjects = append(subjects, subject)
+    }
+
+    sortItems(subjects, opts.SortDesc,
+        func(subject models.Subject) string { return subjectSortKey(subject, opts.SortBy) },
+        func(subject models.Subject) int { return subject.SubjectID })
+
+    return pageOf(subjects, opts), int64(len(subjects)), nil
+}
+
+// Enroll registra una inscripción para que Counts la incluya
+func (r *MemorySubjectRepository) Enroll(enrollment models.Enrollment) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    r.enrollments = append(r.enrollments, enrollment)
+}
+
+func (r *MemorySubjectRepository) Counts(subjectIDs []int) (map[int]models.SubjectCounts, error) {
+    counts := make(map[int]models.SubjectCounts, len(subjectIDs))
+    students := make(map[int]map[int]bool, len(subjectIDs))
+    for _, id := range subjectIDs {
+        counts[id] = models.SubjectCounts{}
+        students[id] = make(map[int]bool)
+    }
+
+    r.mu.RLock()
+    for _, enrollment := range r.enrollments {
+        count, ok := counts[enrollment.SubjectID]
+        if !ok || students[enrollment.SubjectID][enrollment.StudentID] {
+            continue
+        }
+        students[enrollment.SubjectID][enrollment.StudentID] = true
+        count.StudentCount++
+        counts[enrollment.SubjectID] = count
+    }
+    r.mu.RUnlock()
+
+    if r.grades == nil {
+        return counts, nil
+    }
+
+    r.grades.mu.RLock()
+    defer r.grades.mu.RUnlock()
+
+    for _, grade := range r.grades.grades {
+        count, ok := counts[grade.SubjectID]
+        if !ok || grade.DeletedAt.Valid {
+            continue
+        }
+        count.GradeCount++
+        counts[grade.SubjectID] = count
+    }
+    return counts, nil
+}
+
+func (r *MemorySubjectRepository) FindByID(id int) (*models.Subject, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    subject, ok := r.subjects[id]
+    if !ok || subject.DeletedAt.Valid {
+        return nil, ErrNotFound
+    }
+    return &subject, nil
+}
+
+func (r *MemorySubjectRepository) FindByIDs(ids []int) (map[int]models.Subject, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    found := make(map[int]models.Subject, len(ids))
+    for _, id := range ids {
+        if subject, ok := r.subjects[id]; ok && !subject.DeletedAt.Valid {
+            found[id] = subject
+        }
+    }
+    return found, nil
+}
+
+func (r *MemorySubjectRepository) Update(subject *models.Subject) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    existing, ok := r.subjects[subject.SubjectID]
+    if !ok || existing.DeletedAt.Valid {
+        return ErrNotFound
+    }
+    if r.nameTaken(subject.Name, subject.SubjectID) {
+        return ErrDuplicate
+    }
+
+    subject.DeletedAt = existing.DeletedAt
+    r.subjects[subject.SubjectID] = *subject
+    return nil
+}
+
+func (r *MemorySubjectRepository) Delete(id int) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    subject, ok := r.subjects[id]
+    if !ok || subject.DeletedAt.Valid {
+        return ErrNotFound
+    }
+    subject.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+    r.subjects[id] = subject
+    return nil
+}
+
+func (r *MemorySubjectRepository) Restore(id int) (*models.Subject, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    subject, ok := r.subjects[id]
+    if !ok {
+        return nil, ErrNotFound
+    }
+    subject.DeletedAt = gorm.DeletedAt{}
+    r.subjects[id] = subject
+    return &subject, nil
+}
+
+func (r *MemorySubjectRepository) FindDeletedByName(name string) (*models.Subject, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    for _, subject := range r.subjects {
+        if subject.DeletedAt.Valid && strings.EqualFold(subject.Name, name) {
+            return &subject, nil
+        }
+    }
+    return nil, ErrNotFound
+}
+
+// subjectSortKey devuelve el valor por el que se ordena una materia
+func subjectSortKey(subject models.Subject, sortBy string) string {
+    if sortBy == "name" {
+        return strings.ToLower(subject.Name)
+    }
+    return fmt.Sprintf("%010d", subject.SubjectID)
+}
+
+// nameTaken indica si otra materia, aunque esté eliminada, ya usa el nombre; debe llamarse con el candado tomado
+func (r *MemorySubjectRepository) nameTaken(name string, exceptID int) bool {
+    for id, subject := range r.subjects {
+        if id != exceptID && strings.EqualFold(subject.Name, name) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,24 @@
+package repositories
+
+// Verificación en tiempo de compilación de que las implementaciones cumplen las interfaces
+var (
+    _ StudentRepository      = (*GormStudentRepository)(nil)
+    _ StudentRepository      = (*MemoryStudentRepository)(nil)
+    _ SubjectRepository      = (*GormSubjectRepository)(nil)
+    _ SubjectRepository      = (*MemorySubjectRepository)(nil)
+    _ GradeRepository        = (*GormGradeRepository)(nil)
+    _ GradeRepository        = (*MemoryGradeRepository)(nil)
+    _ GradeLockRepository    = (*GormGradeLockRepository)(nil)
+    _ AttendanceRepository   = (*GormAttendanceRepository)(nil)
+    _ EvaluationRepository   = (*GormEvaluationRepository)(nil)
+    _ GradingScaleRepository = (*GormGradingScaleRepository)(nil)
+    _ UserRepository         = (*GormUserRepository)(nil)
+    _ TermRepository         = (*GormTermRepository)(nil)
+    _ GroupRepository        = (*GormGroupRepository)(nil)
+    _ TeacherRepository      = (*GormTeacherRepository)(nil)
+    _ GuardianRepository     = (*GormGuardianRepository)(nil)
+    _ AssignmentRepository   = (*GormAssignmentRepository)(nil)
+    _ EnrollmentRepository   = (*GormEnrollmentRepository)(nil)
+    _ AnalyticsRepository    = (*GormAnalyticsRepository)(nil)
+    _ ExportRepository       = (*GormExportRepository)(nil)
+)
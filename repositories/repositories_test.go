@@ -0,0 +1,70 @@
+package repositories
+
+import (
+    "testing"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/logger"
+
+    "ControlEscolar/config"
+    "ControlEscolar/models"
+)
+
+// openTestDB abre una base de datos SQLite en memoria sin tablas
+func openTestDB(t *testing.T) *gorm.DB {
+    t.Helper()
+
+    db, err := config.OpenDatabase(config.DatabaseConfig{
+        Driver:   config.DriverSQLite,
+        Path:     ":memory:",
+        LogLevel: logger.Silent,
+    })
+    if err != nil {
+        t.Fatalf("abrir base de datos: %v", err)
+    }
+    t.Cleanup(func() {
+        if sqlDB, err := db.DB(); err == nil {
+            sqlDB.Close()
+        }
+    })
+    return db
+}
+
+// newTestDB abre una base de datos SQLite en memoria con las mismas migraciones que main
+func newTestDB(t *testing.T) *gorm.DB {
+    t.Helper()
+    db := openTestDB(t)
+
+    if err := models.MigrateAll(db); err != nil {
+        t.Fatalf("migrar base de datos: %v", err)
+    }
+    if err := models.AddForeignKeys(db); err != nil {
+        t.Fatalf("agregar llaves foráneas: %v", err)
+    }
+    return db
+}
+
+// mustCreate inserta los registros indicados o termina la prueba
+func mustCreate(t *testing.T, db *gorm.DB, values ...interface{}) {
+    t.Helper()
+    for _, value := range values {
+        if err := db.Create(value).Error; err != nil {
+            t.Fatalf("crear %T: %v", value, err)
+        }
+    }
+}
+
+// newTestTerm crea un ciclo escolar de prueba
+func newTestTerm(t *testing.T, db *gorm.DB) *models.Term {
+    t.Helper()
+    term := &models.Term{
+        Name:       "Ciclo 2025-2026",
+        SchoolYear: "2025-2026",
+        Kind:       models.TermKindSchoolYear,
+        StartDate:  time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC),
+        EndDate:    time.Date(2026, time.July, 31, 0, 0, 0, 0, time.UTC),
+    }
+    mustCreate(t, db, term)
+    return term
+}
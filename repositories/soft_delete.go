@@ -0,0 +1,26 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// restoreRow quita la marca de eliminado del registro de model con la llave primaria id.
+// Devuelve ErrNotFound si el registro no existe, esté eliminado o no.
+func restoreRow(db *gorm.DB, model interface{}, id int) error {
+    if err := db.Unscoped().First(model, id).Error; err != nil {
+        return translateError(err)
+    }
+    return translateError(db.Unscoped().Model(model).Update("deleted_at", nil).Error)
+}
+
+// activeStudentIDs es la subconsulta de los estudiantes que no están eliminados
+func activeStudentIDs(db *gorm.DB) *gorm.DB {
+    return db.Model(&models.Student{}).Select("student_id")
+}
+
+// activeSubjectIDs es la subconsulta de las materias que no están eliminadas
+func activeSubjectIDs(db *gorm.DB) *gorm.DB {
+    return db.Model(&models.Subject{}).Select("subject_id")
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+    "strings"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "ControlEscolar/models"
+)
+
+// StudentSortFields son las columnas por las que se puede ordenar el listado de estudiantes;
+// "group" ordena por el nombre del grupo
+var StudentSortFields = []string{"student_id", "name", "group", "group_id", "email"}
+
+// studentSortColumns traduce los campos de orden a columnas de la consulta con el grupo unido
+var studentSortColumns = map[string]string{
+    "student_id": "students.student_id",
+    "name":       "students.name",
+    "group":      "class_groups.name",
+    "group_id":   "students.group_id",
+    "email":      "students.email",
+}
+
+// StudentFilter contiene los filtros del listado de estudiantes
+type StudentFilter struct {
+    // GroupID filtra por grupo; 0 incluye todos
+    GroupID int
+    // Group filtra por el nombre exacto del grupo (p. ej. "5A"), en cualquier ciclo escolar
+    Group string
+    // Query busca el texto dentro del nombre o el email
+    Query string
+    // IncludeDeleted incluye a los estudiantes eliminados
+    IncludeDeleted bool
+}
+
+// StudentRepository define el acceso a datos de estudiantes
+type StudentRepository interface {
+    Create(student *models.Student) error
+    // List devuelve una página de estudiantes y el total de registros que cumplen el filtro
+    List(filter StudentFilter, opts ListOptions) ([]models.Student, int64, error)
+    FindByID(id int) (*models.Student, error)
+    // FindByIDs devuelve los estudiantes existentes de la lista con su grupo, indexados por ID
+    FindByIDs(ids []int) (map[int]models.Student, error)
+    Update(student *models.Student) error
+    // Delete marca al estudiante como eliminado; sus calificaciones se conservan, pero dejan de
+    // aparecer en listados y estadísticas mientras no se restaure
+    Delete(id int) error
+    // Restore quita la marca de eliminado; si el estudiante no estaba eliminado lo devuelve sin cambios
+    Restore(id int) (*models.Student, error)
+    // FindDeletedByEmail devuelve al estudiante eliminado que usa el email, sin distinguir mayúsculas
+    FindDeletedByEmail(email string) (*models.Student, error)
+    // CreateMany crea todos los estudiantes en una sola transacción; si alguno falla no se crea ninguno
+    CreateMany(students []models.Student) error
+    // ExistingEmails devuelve, en minúsculas, los emails indicados que ya usa algún estudiante,
+    // incluidos los eliminados
+    ExistingEmails(emails []string) ([]string, error)
+}
+
+// GormStudentRepository implementa StudentRepository sobre GORM
+type GormStudentRepository struct {
+    db *gorm.DB
+}
+
+// NewGormStudentRepository crea un repositorio de estudiantes respaldado por la base de datos
+func NewGormStudentRepository(db *gorm.DB) *GormStudentRepository {
+    return &GormStudentRepository{db: db}
+}
+
+func (r *GormStudentRepository) Create(student *models.Student) error {
+    // El grupo se asigna solo con group_id; la relación cargada nunca se guarda.
+    // La eliminación solo cambia con Delete y Restore.
+    student.DeletedAt = gorm.DeletedAt{}
+    return translateError(r.db.Omit(clause.Associations).Create(student).Error)
+}
+
+func (r *GormStudentRepository) List(filter StudentFilter, opts ListOptions) ([]models.Student, int64, error) {
+    opts = opts.Normalize()
+    // El grupo se une para filtrar y ordenar por su nombre, así que las columnas van calificadas
+    query := r.db.Model(&models.Student{}).
+        Joins("LEFT JOIN ? AS class_groups ON class_groups.group_id = students.group_id", clause.Table{Name: models.Group{}.TableName()})
+    if filter.IncludeDeleted {
+        query = query.Unscoped()
+    }
+
+    if filter.GroupID != 0 {
+        query = query.Where("students.group_id = ?", filter.GroupID)
+    }
+    if filter.Group != "" {
+        query = query.Where("class_groups.name = ?", filter.Group)
+    }
+    if filter.Query != "" {
+        pattern := likePattern(filter.Query)
+        query = query.Where("LOWER(students.name) LIKE ? ESCAPE '"+likeEscape+"' OR LOWER(students.email) LIKE ? ESCAPE '"+likeEscape+"'", pattern, pattern)
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    if opts.SortBy != "" {
+        opts.SortBy = studentSortColumns[opts.SortBy]
+    }
+    students := []models.Student{}
+    err := paginate(query.Select("students.*"), opts, "students.student_id").Preload("Group").Find(&students).Error
+    if err != nil {
+        return nil, 0, translateError(err)
+    }
+    return students, total, nil
+}
+
+func (r *GormStudentRepository) FindByID(id int) (*models.Student, error) {
+    var student models.Student
+    if err := r.db.Preload("Group").First(&student, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &student, nil
+}
+
+func (r *GormStudentRepository) FindByIDs(ids []int) (map[int]models.Student, error) {
+    found := make(map[int]models.Student, len(ids))
+    if len(ids) == 0 {
+        return found, nil
+    }
+
+    var students []models.Student
+    if err := r.db.Preload("Group").Where("student_id IN ?", ids).Find(&students).Error; err != nil {
+        return nil, translateError(err)
+    }
+    for _, student := range students {
+        found[student.StudentID] = student
+    }
+    return found, nil
+}
+
+func (r *GormStudentRepository) Update(student *models.Student) error {
+    student.DeletedAt = gorm.DeletedAt{}
+    return translateError(r.db.Omit(clause.Associations, "DeletedAt").Save(student).Error)
+}
+
+func (r *GormStudentRepository) Delete(id int) error {
+    result := r.db.Delete(&models.Student{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormStudentRepository) Restore(id int) (*models.Student, error) {
+    if err := restoreRow(r.db, &models.Student{}, id); err != nil {
+        return nil, err
+    }
+    return r.FindByID(id)
+}
+
+func (r *GormStudentRepository) FindDeletedByEmail(email string) (*models.Student, error) {
+    var student models.Student
+    err := r.db.Unscoped().
+        Where("LOWER(email) = ? AND deleted_at IS NOT NULL", strings.ToLower(email)).
+        First(&student).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return &student, nil
+}
+
+func (r *GormStudentRepository) CreateMany(students []models.Student) error {
+    if len(students) == 0 {
+        return nil
+    }
+    return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+        return tx.Omit(clause.Associations).CreateInBatches(students, 100).Error
+    }))
+}
+
+func (r *GormStudentRepository) ExistingEmails(emails []string) ([]string, error) {
+    lowered := make([]string, len(emails))
+    for i, email := range emails {
+        lowered[i] = strings.ToLower(email)
+    }
+
+    existing := []string{}
+    // El índice único del email también abarca a los estudiantes eliminados
+    if err := r.db.Unscoped().Model(&models.Student{}).
+        Where("LOWER(email) IN ?", lowered).
+        Pluck("LOWER(email)", &existing).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return existing, nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+    "errors"
+    "testing"
+
+    "ControlEscolar/models"
+)
+
+func TestGormStudentRepositoryListByGroupName(t *testing.T) {
+    db := newTestDB(t)
+    repo := NewGormStudentRepository(db)
+
+    groupB := &models.Group{SchoolYear: "2025-2026", GradeLevel: 3, Section: "B", Name: "3B"}
+    groupA := &models.Group{SchoolYear: "2025-2026", GradeLevel: 3, Section: "A", Name: "3A"}
+    mustCreate(t, db, groupB, groupA)
+    for _, student := range []*models.Student{
+        {Name: "Ana", Email: "ana@example.com", GroupID: &groupB.GroupID},
+        {Name: "Beto", Email: "beto@example.com", GroupID: &groupA.GroupID},
+        {Name: "Carla", Email: "carla@example.com", GroupID: &groupB.GroupID},
+    } {
+        if err := repo.Create(student); err != nil {
+            t.Fatalf("Create: %v", err)
+        }
+    }
+
+    students, total, err := repo.List(StudentFilter{Group: "3B"}, ListOptions{})
+    if err != nil {
+        t.Fatalf("List: %v", err)
+    }
+    if total != 2 || len(students) != 2 {
+        t.Fatalf("grupo 3B: total %d, %d estudiantes; se esperaban 2", total, len(students))
+    }
+    for _, student := range students {
+        if student.Group == nil || student.Group.Name != "3B" {
+            t.Errorf("estudiante %q fuera del grupo 3B", student.Name)
+        }
+    }
+
+    students, _, err = repo.List(StudentFilter{}, ListOptions{SortBy: "group"})
+    if err != nil {
+        t.Fatalf("List ordenado por grupo: %v", err)
+    }
+    if len(students) != 3 || students[0].Name != "Beto" {
+        t.Fatalf("el primer estudiante por grupo debe ser Beto (3A), se obtuvo %+v", students)
+    }
+}
+
+func TestGormStudentRepositoryFindDeletedByEmail(t *testing.T) {
+    db := newTestDB(t)
+    repo := NewGormStudentRepository(db)
+
+    student := &models.Student{Name: "Ana", Email: "ana@example.com"}
+    if err := repo.Create(student); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+
+    if _, err := repo.FindDeletedByEmail("ana@example.com"); !errors.Is(err, ErrNotFound) {
+        t.Fatalf("un estudiante activo no debe encontrarse como eliminado, err = %v", err)
+    }
+
+    if err := repo.Delete(student.StudentID); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+    if err := repo.Create(&models.Student{Name: "Otra Ana", Email: "ana@example.com"}); !errors.Is(err, ErrDuplicate) {
+        t.Fatalf("el email de un estudiante eliminado debe seguir ocupado, err = %v", err)
+    }
+
+    deleted, err := repo.FindDeletedByEmail("ANA@example.com")
+    if err != nil {
+        t.Fatalf("FindDeletedByEmail: %v", err)
+    }
+    if deleted.StudentID != student.StudentID {
+        t.Errorf("student_id = %d, se esperaba %d", deleted.StudentID, student.StudentID)
+    }
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+    "strings"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// SubjectSortFields son las columnas por las que se puede ordenar el listado de materias
+var SubjectSortFields = []string{"subject_id", "name"}
+
+// SubjectFilter contiene los filtros del listado de materias
+type SubjectFilter struct {
+    // Query busca el texto dentro del nombre
+    Query string
+    // IncludeDeleted incluye las materias eliminadas
+    IncludeDeleted bool
+}
+
+// SubjectRepository define el acceso a datos de materias
+type SubjectRepository interface {
+    Create(subject *models.Subject) error
+    // List devuelve una página de materias y el total de registros que cumplen el filtro
+    List(filter SubjectFilter, opts ListOptions) ([]models.Subject, int64, error)
+    // Counts devuelve, por materia, cuántos estudiantes están inscritos en algún periodo y cuántas
+    // calificaciones hay, sin contar las calificaciones ni los estudiantes eliminados
+    Counts(subjectIDs []int) (map[int]models.SubjectCounts, error)
+    FindByID(id int) (*models.Subject, error)
+    // FindByIDs devuelve las materias existentes de la lista, indexadas por ID
+    FindByIDs(ids []int) (map[int]models.Subject, error)
+    Update(subject *models.Subject) error
+    // Delete marca la materia como eliminada; sus calificaciones se conservan, pero dejan de
+    // aparecer en listados y estadísticas mientras no se restaure
+    Delete(id int) error
+    // Restore quita la marca de eliminada; si la materia no estaba eliminada la devuelve sin cambios
+    Restore(id int) (*models.Subject, error)
+    // FindDeletedByName devuelve la materia eliminada que usa el nombre, sin distinguir mayúsculas
+    FindDeletedByName(name string) (*models.Subject, error)
+}
+
+// GormSubjectRepository implementa SubjectRepository sobre GORM
+type GormSubjectRepository struct {
+    db *gorm.DB
+}
+
+// NewGormSubjectRepository crea un repositorio de materias respaldado por la base de datos
+func NewGormSubjectRepository(db *gorm.DB) *GormSubjectRepository {
+    return &GormSubjectRepository{db: db}
+}
+
+func (r *GormSubjectRepository) Create(subject *models.Subject) error {
+    // La eliminación solo cambia con Delete y Restore
+    subject.DeletedAt = gorm.DeletedAt{}
+    return translateError(r.db.Create(subject).Error)
+}
+
+func (r *GormSubjectRepository) List(filter SubjectFilter, opts ListOptions) ([]models.Subject, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Subject{})
+    if filter.IncludeDeleted {
+        query = query.Unscoped()
+    }
+
+    if filter.Query != "" {
+        query = query.Where("LOWER(name) LIKE ? ESCAPE '"+likeEscape+"'", likePattern(filter.Query))
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    subjects := []models.Subject{}
+    if err := paginate(query, opts, "subject_id").Find(&subjects).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return subjects, total, nil
+}
+
+func (r *GormSubjectRepository) Counts(subjectIDs []int) (map[int]models.SubjectCounts, error) {
+    counts := make(map[int]models.SubjectCounts, len(subjectIDs))
+    if len(subjectIDs) == 0 {
+        return counts, nil
+    }
+
+    // Los estudiantes se cuentan por inscripción, así que incluyen a los que aún no tienen calificaciones
+    var students []struct {
+        SubjectID    int
+        StudentCount int64
+    }
+    if err := r.db.Model(&models.Enrollment{}).
+        Select("subject_id, COUNT(DISTINCT student_id) AS student_count").
+        Where("subject_id IN ?", subjectIDs).
+        Where("student_id IN (?)", activeStudentIDs(r.db)).
+        Group("subject_id").
+        Scan(&students).Error; err != nil {
+        return nil, translateError(err)
+    }
+
+    var grades []struct {
+        SubjectID  int
+        GradeCount int64
+    }
+    if err := r.db.Model(&models.Grade{}).
+        Select("subject_id, COUNT(*) AS grade_count").
+        Where("subject_id IN ?", subjectIDs).
+        Where("student_id IN (?)", activeStudentIDs(r.db)).
+        Group("subject_id").
+        Scan(&grades).Error; err != nil {
+        return nil, translateError(err)
+    }
+
+    for _, id := range subjectIDs {
+        counts[id] = models.SubjectCounts{}
+    }
+    for _, row := range students {
+        count := counts[row.SubjectID]
+        count.StudentCount = row.StudentCount
+        counts[row.SubjectID] = count
+    }
+    for _, row := range grades {
+        count := counts[row.SubjectID]
+        count.GradeCount = row.GradeCount
+        counts[row.SubjectID] = count
+    }
+    return counts, nil
+}
+
+func (r *GormSubjectRepository) FindByID(id int) (*models.Subject, error) {
+    var subject models.Subject
+    if err := r.db.First(&subject, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &subject, nil
+}
+
+func (r *GormSubjectRepository) FindByIDs(ids []int) (map[int]models.Subject, error) {
+    found := make(map[int]models.Subject, len(ids))
+    if len(ids) == 0 {
+        return found, nil
+    }
+
+    var subjects []models.Subject
+    if err := r.db.Where("subject_id IN ?", ids).Find(&subjects).Error; err != nil {
+        return nil, translateError(err)
+    }
+    for _, subject := range subjects {
+        found[subject.SubjectID] = subject
+    }
+    return found, nil
+}
+
+func (r *GormSubjectRepository) Update(subject *models.Subject) error {
+    subject.DeletedAt = gorm.DeletedAt{}
+    return translateError(r.db.Omit("DeletedAt").Save(subject).Error)
+}
+
+func (r *GormSubjectRepository) Delete(id int) error {
+    result := r.db.Delete(&models.Subject{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormSubjectRepository) Restore(id int) (*models.Subject, error) {
+    if err := restoreRow(r.db, &models.Subject{}, id); err != nil {
+        return nil, err
+    }
+    return r.FindByID(id)
+}
+
+func (r *GormSubjectRepository) FindDeletedByName(name string) (*models.Subject, error) {
+    var subject models.Subject
+    err := r.db.Unscoped().
+        Where("LOWER(name) = ? AND deleted_at IS NOT NULL", strings.ToLower(name)).
+        First(&subject).Error
+    if err != nil {
+        return nil, translateError(err)
+    }
+    return &subject, nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+    "testing"
+
+    "ControlEscolar/models"
+)
+
+func TestGormSubjectRepositoryCountsEnrolledStudents(t *testing.T) {
+    db := newTestDB(t)
+    repo := NewGormSubjectRepository(db)
+    grades := NewGormGradeRepository(db)
+    students := NewGormStudentRepository(db)
+
+    term := newTestTerm(t, db)
+    subject := &models.Subject{Name: "Matemáticas"}
+    graded := &models.Student{Name: "Ana", Email: "ana@example.com"}
+    enrolled := &models.Student{Name: "Beto", Email: "beto@example.com"}
+    deleted := &models.Student{Name: "Carla", Email: "carla@example.com"}
+    mustCreate(t, db, subject, graded, enrolled, deleted)
+    for _, student := range []*models.Student{graded, enrolled, deleted} {
+        mustCreate(t, db, &models.Enrollment{StudentID: student.StudentID, SubjectID: subject.SubjectID, TermID: term.TermID})
+    }
+    if err := grades.Create(&models.Grade{StudentID: graded.StudentID, SubjectID: subject.SubjectID, TermID: &term.TermID, Grade: 90}, models.GradeChange{}); err != nil {
+        t.Fatalf("crear calificación: %v", err)
+    }
+    if err := students.Delete(deleted.StudentID); err != nil {
+        t.Fatalf("eliminar estudiante: %v", err)
+    }
+
+    counts, err := repo.Counts([]int{subject.SubjectID})
+    if err != nil {
+        t.Fatalf("Counts: %v", err)
+    }
+    // Los inscritos sin calificaciones cuentan; los estudiantes eliminados no
+    want := models.SubjectCounts{StudentCount: 2, GradeCount: 1}
+    if got := counts[subject.SubjectID]; got != want {
+        t.Errorf("Counts = %+v, se esperaba %+v", got, want)
+    }
+}
+
+func TestGormSubjectRepositoryFindDeletedByName(t *testing.T) {
+    db := newTestDB(t)
+    repo := NewGormSubjectRepository(db)
+
+    subject := &models.Subject{Name: "Historia"}
+    if err := repo.Create(subject); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    if err := repo.Delete(subject.SubjectID); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+
+    deleted, err := repo.FindDeletedByName("historia")
+    if err != nil {
+        t.Fatalf("FindDeletedByName: %v", err)
+    }
+    if deleted.SubjectID != subject.SubjectID {
+        t.Errorf("subject_id = %d, se esperaba %d", deleted.SubjectID, subject.SubjectID)
+    }
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// TeacherSortFields son las columnas por las que se puede ordenar el listado de maestros
+var TeacherSortFields = []string{"teacher_id", "name", "email"}
+
+// TeacherFilter contiene los filtros del listado de maestros
+type TeacherFilter struct {
+    // Query busca el texto dentro del nombre o el email
+    Query string
+}
+
+// TeacherRepository define el acceso a datos de maestros
+type TeacherRepository interface {
+    Create(teacher *models.Teacher) error
+    List(filter TeacherFilter, opts ListOptions) ([]models.Teacher, int64, error)
+    FindByID(id int) (*models.Teacher, error)
+    // FindByUserID devuelve el maestro vinculado a una cuenta de usuario
+    FindByUserID(userID int) (*models.Teacher, error)
+    Update(teacher *models.Teacher) error
+    Delete(id int) error
+}
+
+// GormTeacherRepository implementa TeacherRepository sobre GORM
+type GormTeacherRepository struct {
+    db *gorm.DB
+}
+
+// NewGormTeacherRepository crea un repositorio de maestros respaldado por la base de datos
+func NewGormTeacherRepository(db *gorm.DB) *GormTeacherRepository {
+    return &GormTeacherRepository{db: db}
+}
+
+func (r *GormTeacherRepository) Create(teacher *models.Teacher) error {
+    return translateError(r.db.Create(teacher).Error)
+}
+
+func (r *GormTeacherRepository) List(filter TeacherFilter, opts ListOptions) ([]models.Teacher, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Teacher{})
+
+    if filter.Query != "" {
+        pattern := likePattern(filter.Query)
+        query = query.Where("LOWER(name) LIKE ? ESCAPE '"+likeEscape+"' OR LOWER(email) LIKE ? ESCAPE '"+likeEscape+"'", pattern, pattern)
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    teachers := []models.Teacher{}
+    if err := paginate(query, opts, "teacher_id").Find(&teachers).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return teachers, total, nil
+}
+
+func (r *GormTeacherRepository) FindByID(id int) (*models.Teacher, error) {
+    var teacher models.Teacher
+    if err := r.db.First(&teacher, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &teacher, nil
+}
+
+func (r *GormTeacherRepository) FindByUserID(userID int) (*models.Teacher, error) {
+    var teacher models.Teacher
+    if err := r.db.Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &teacher, nil
+}
+
+func (r *GormTeacherRepository) Update(teacher *models.Teacher) error {
+    return translateError(r.db.Save(teacher).Error)
+}
+
+func (r *GormTeacherRepository) Delete(id int) error {
+    // Las asignaciones del maestro se eliminan por CASCADE y los grupos que tenía como
+    // titular quedan sin titular
+    result := r.db.Delete(&models.Teacher{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// TermSortFields son las columnas por las que se puede ordenar el listado de periodos
+var TermSortFields = []string{"term_id", "name", "school_year", "start_date"}
+
+// TermFilter contiene los filtros del listado de periodos
+type TermFilter struct {
+    SchoolYear string
+    Kind       string
+    ParentID   *int
+}
+
+// TermRepository define el acceso a datos de periodos académicos
+type TermRepository interface {
+    Create(term *models.Term) error
+    List(filter TermFilter, opts ListOptions) ([]models.Term, int64, error)
+    FindByID(id int) (*models.Term, error)
+    Update(term *models.Term) error
+    Delete(id int) error
+    // WithDescendants devuelve el ID del periodo junto con los de todos los periodos que contiene
+    WithDescendants(id int) ([]int, error)
+    // WithAncestors devuelve el ID del periodo junto con los de los periodos que lo contienen
+    WithAncestors(id int) ([]int, error)
+    // ContainingDate devuelve los IDs de los periodos del ciclo escolar cuyas fechas incluyen date
+    ContainingDate(schoolYear string, date time.Time) ([]int, error)
+}
+
+// GormTermRepository implementa TermRepository sobre GORM
+type GormTermRepository struct {
+    db *gorm.DB
+}
+
+// NewGormTermRepository crea un repositorio de periodos respaldado por la base de datos
+func NewGormTermRepository(db *gorm.DB) *GormTermRepository {
+    return &GormTermRepository{db: db}
+}
+
+func (r *GormTermRepository) Create(term *models.Term) error {
+    return translateError(r.db.Create(term).Error)
+}
+
+func (r *GormTermRepository) List(filter TermFilter, opts ListOptions) ([]models.Term, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.Term{})
+
+    if filter.SchoolYear != "" {
+        query = query.Where("school_year = ?", filter.SchoolYear)
+    }
+    if filter.Kind != "" {
+        query = query.Where("kind = ?", filter.Kind)
+    }
+    if filter.ParentID != nil {
+        query = query.Where("parent_id = ?", *filter.ParentID)
+    }
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    terms := []models.Term{}
+    if err := paginate(query, opts, "term_id").Find(&terms).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return terms, total, nil
+}
+
+func (r *GormTermRepository) FindByID(id int) (*models.Term, error) {
+    var term models.Term
+    if err := r.db.First(&term, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &term, nil
+}
+
+func (r *GormTermRepository) Update(term *models.Term) error {
+    return translateError(r.db.Save(term).Error)
+}
+
+func (r *GormTermRepository) Delete(id int) error {
+    // Se valida antes de borrar para devolver ErrInUse igual en todos los dialectos
+    var children int64
+    if err := r.db.Model(&models.Term{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+        return translateError(err)
+    }
+    // Las calificaciones eliminadas cuentan porque conservan la llave foránea al periodo
+    var grades int64
+    if err := r.db.Unscoped().Model(&models.Grade{}).Where("term_id = ?", id).Count(&grades).Error; err != nil {
+        return translateError(err)
+    }
+    if children > 0 || grades > 0 {
+        return ErrInUse
+    }
+
+    result := r.db.Delete(&models.Term{}, id)
+    if result.Error != nil {
+        return translateError(result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+func (r *GormTermRepository) WithDescendants(id int) ([]int, error) {
+    ids := []int{id}
+    level := []int{id}
+
+    // Los periodos tienen a lo sumo tres niveles, así que basta una consulta por nivel
+    for len(level) > 0 {
+        var children []int
+        if err := r.db.Model(&models.Term{}).
+            Where("parent_id IN ?", level).
+            Pluck("term_id", &children).Error; err != nil {
+            return nil, translateError(err)
+        }
+        ids = append(ids, children...)
+        level = children
+    }
+    return ids, nil
+}
+
+func (r *GormTermRepository) WithAncestors(id int) ([]int, error) {
+    ids := []int{}
+    current := &id
+
+    // Se detiene al llegar al ciclo escolar o si encuentra un ciclo en los datos
+    for current != nil && !containsInt(ids, *current) {
+        var term models.Term
+        if err := r.db.Select("term_id", "parent_id").First(&term, *current).Error; err != nil {
+            return nil, translateError(err)
+        }
+        ids = append(ids, term.TermID)
+        current = term.ParentID
+    }
+    return ids, nil
+}
+
+func (r *GormTermRepository) ContainingDate(schoolYear string, date time.Time) ([]int, error) {
+    ids := []int{}
+    if err := r.db.Model(&models.Term{}).
+        Where("school_year = ? AND start_date <= ? AND end_date >= ?", schoolYear, date, date).
+        Pluck("term_id", &ids).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return ids, nil
+}
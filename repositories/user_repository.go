@@ -0,0 +1,70 @@
+package repositories
+
+import (
+    "gorm.io/gorm"
+
+    "ControlEscolar/models"
+)
+
+// UserRepository define el acceso a datos de cuentas de usuario
+type UserRepository interface {
+    Create(user *models.User) error
+    List(opts ListOptions) ([]models.User, int64, error)
+    FindByID(id int) (*models.User, error)
+    FindByUsername(username string) (*models.User, error)
+    Count() (int64, error)
+}
+
+// GormUserRepository implementa UserRepository sobre GORM
+type GormUserRepository struct {
+    db *gorm.DB
+}
+
+// NewGormUserRepository crea un repositorio de usuarios respaldado por la base de datos
+func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+    return &GormUserRepository{db: db}
+}
+
+func (r *GormUserRepository) Create(user *models.User) error {
+    return translateError(r.db.Create(user).Error)
+}
+
+func (r *GormUserRepository) List(opts ListOptions) ([]models.User, int64, error) {
+    opts = opts.Normalize()
+    query := r.db.Model(&models.User{})
+
+    var total int64
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+
+    users := []models.User{}
+    if err := paginate(query, opts, "user_id").Find(&users).Error; err != nil {
+        return nil, 0, translateError(err)
+    }
+    return users, total, nil
+}
+
+func (r *GormUserRepository) FindByID(id int) (*models.User, error) {
+    var user models.User
+    if err := r.db.First(&user, id).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &user, nil
+}
+
+func (r *GormUserRepository) FindByUsername(username string) (*models.User, error) {
+    var user models.User
+    if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+        return nil, translateError(err)
+    }
+    return &user, nil
+}
+
+func (r *GormUserRepository) Count() (int64, error) {
+    var total int64
+    if err := r.db.Model(&models.User{}).Count(&total).Error; err != nil {
+        return 0, translateError(err)
+    }
+    return total, nil
+}
@@ -2,42 +2,272 @@ package routes
 
 import (
     "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+	"ControlEscolar/auth"
+	"ControlEscolar/config"
 	"ControlEscolar/handlers"
+	"ControlEscolar/models"
+	"ControlEscolar/repositories"
 	
 )
 
-// SetupRoutes configura todas las rutas de la API
-func SetupRoutes(router *gin.Engine) {
+// Repositories agrupa los repositorios con los que se construyen los handlers
+type Repositories struct {
+    Students      repositories.StudentRepository
+    Subjects      repositories.SubjectRepository
+    Grades        repositories.GradeRepository
+    Users         repositories.UserRepository
+    Terms         repositories.TermRepository
+    Groups        repositories.GroupRepository
+    Teachers      repositories.TeacherRepository
+    Guardians     repositories.GuardianRepository
+    Assignments   repositories.AssignmentRepository
+    Enrollments   repositories.EnrollmentRepository
+    Analytics     repositories.AnalyticsRepository
+    Exports       repositories.ExportRepository
+    GradeLocks    repositories.GradeLockRepository
+    Attendance    repositories.AttendanceRepository
+    Evaluations   repositories.EvaluationRepository
+    GradingScales repositories.GradingScaleRepository
+}
+
+// NewGormRepositories crea los repositorios respaldados por la base de datos
+func NewGormRepositories(db *gorm.DB) Repositories {
+    return Repositories{
+        Students:      repositories.NewGormStudentRepository(db),
+        Subjects:      repositories.NewGormSubjectRepository(db),
+        Grades:        repositories.NewGormGradeRepository(db),
+        Users:         repositories.NewGormUserRepository(db),
+        Terms:         repositories.NewGormTermRepository(db),
+        Groups:        repositories.NewGormGroupRepository(db),
+        Teachers:      repositories.NewGormTeacherRepository(db),
+        Guardians:     repositories.NewGormGuardianRepository(db),
+        Assignments:   repositories.NewGormAssignmentRepository(db),
+        Enrollments:   repositories.NewGormEnrollmentRepository(db),
+        Analytics:     repositories.NewGormAnalyticsRepository(db),
+        Exports:       repositories.NewGormExportRepository(db),
+        GradeLocks:    repositories.NewGormGradeLockRepository(db),
+        Attendance:    repositories.NewGormAttendanceRepository(db),
+        Evaluations:   repositories.NewGormEvaluationRepository(db),
+        GradingScales: repositories.NewGormGradingScaleRepository(db),
+    }
+}
+
+// SetupRoutes configura todas las rutas de la API con los repositorios de la base de datos
+func SetupRoutes(router *gin.Engine, db *gorm.DB, tokens *auth.TokenService, grading config.GradingConfig) {
+    RegisterRoutes(router, NewGormRepositories(db), tokens, grading)
+}
+
+// RegisterRoutes configura todas las rutas de la API con los repositorios indicados. Las pruebas
+// lo usan para sustituir algunos repositorios por los de memoria sin cambiar rutas ni roles.
+func RegisterRoutes(router *gin.Engine, repos Repositories, tokens *auth.TokenService, grading config.GradingConfig) {
+    // Handlers con sus dependencias
+    authHandler := handlers.NewAuthHandler(repos.Users, repos.Students, tokens)
+    studentHandler := handlers.NewStudentHandler(repos.Students, repos.Groups)
+    groupHandler := handlers.NewGroupHandler(repos.Groups, repos.Teachers)
+    teacherHandler := handlers.NewTeacherHandler(repos.Teachers, repos.Users)
+    guardianHandler := handlers.NewGuardianHandler(repos.Guardians, repos.Users, repos.Students)
+    assignmentHandler := handlers.NewAssignmentHandler(repos.Assignments, repos.Teachers, repos.Subjects, repos.Groups, repos.Terms)
+    enrollmentHandler := handlers.NewEnrollmentHandler(repos.Enrollments, repos.Students, repos.Subjects, repos.Groups, repos.Terms)
+    subjectHandler := handlers.NewSubjectHandler(repos.Subjects)
+    termHandler := handlers.NewTermHandler(repos.Terms)
+    gradeHandler := handlers.NewGradeHandler(repos.Grades, repos.Students, repos.Subjects, repos.Terms, repos.Teachers, repos.Assignments, repos.Enrollments, repos.GradeLocks, repos.Evaluations, repos.GradingScales, grading.PassingGrade)
+    evaluationHandler := handlers.NewEvaluationHandler(repos.Evaluations, repos.Grades, repos.Students, repos.Subjects, repos.Terms, repos.Teachers, repos.Assignments, repos.GradeLocks)
+    gradingScaleHandler := handlers.NewGradingScaleHandler(repos.GradingScales, repos.Subjects)
+    gradeLockHandler := handlers.NewGradeLockHandler(repos.GradeLocks, repos.Subjects, repos.Groups, repos.Terms, repos.Teachers, repos.Assignments)
+    reportHandler := handlers.NewReportHandler(repos.Students, repos.Subjects, repos.Grades, repos.Terms, repos.Attendance, repos.GradingScales, grading.PassingGrade, grading.MaxAbsencePercentage)
+    attendanceHandler := handlers.NewAttendanceHandler(repos.Attendance, repos.Students, repos.Subjects, repos.Groups, repos.Terms, repos.Teachers, repos.Assignments, grading.MaxAbsencePercentage)
+    exportHandler := handlers.NewExportHandler(repos.Exports, repos.Groups, repos.Subjects, repos.Terms)
+    analyticsHandler := handlers.NewAnalyticsHandler(repos.Analytics, repos.Groups, repos.Subjects, repos.Terms, grading.PassingGrade)
+    
+    // Reglas de autorización por rol
+    requireAuth := auth.RequireAuth(tokens)
+    adminOnly := auth.RequireRoles(models.RoleAdmin)
+    teacherOnly := auth.RequireRoles(models.RoleTeacher)
+    staff := auth.RequireRoles(models.RoleAdmin, models.RoleTeacher)
+    parentOnly := auth.RequireRoles(models.RoleParent)
+    // Los alumnos solo pueden consultar su propio student_id
+    ownStudent := auth.RequireOwnStudent("student_id", models.RoleAdmin, models.RoleTeacher)
+    
     // Grupo de rutas API
     api := router.Group("/api")
     {
+        // Rutas de autenticación
+        authRoutes := api.Group("/auth")
+        {
+            authRoutes.POST("/login", authHandler.Login)
+            authRoutes.GET("/me", requireAuth, authHandler.GetCurrentUser)
+        }
+        
+        // El resto de las rutas requiere un token válido
+        protected := api.Group("", requireAuth)
+        
+        // Rutas de cuentas de usuario
+        users := protected.Group("/users", adminOnly)
+        {
+            users.POST("", authHandler.CreateUser)
+            users.GET("", authHandler.GetAllUsers)
+        }
+        
         // Rutas de estudiantes
-        students := api.Group("/students")
+        students := protected.Group("/students")
+        {
+            students.POST("", adminOnly, studentHandler.CreateStudent)
+            students.POST("/import", adminOnly, studentHandler.ImportStudents)
+            students.GET("", staff, studentHandler.GetAllStudents)
+            students.GET("/export", staff, exportHandler.ExportStudents)
+            students.GET("/:student_id", ownStudent, studentHandler.GetStudent)
+            students.PUT("/:student_id", adminOnly, studentHandler.UpdateStudent)
+            students.DELETE("/:student_id", adminOnly, studentHandler.DeleteStudent)
+            students.POST("/:student_id/restore", adminOnly, studentHandler.RestoreStudent)
+            students.GET("/:student_id/report-card", ownStudent, reportHandler.GetReportCard)
+            students.GET("/:student_id/summary", ownStudent, reportHandler.GetStudentSummary)
+            students.GET("/:student_id/attendance", ownStudent, attendanceHandler.GetStudentAttendance)
+            students.GET("/:student_id/guardians", staff, guardianHandler.GetStudentGuardians)
+        }
+        
+        // Rutas de grupos
+        groups := protected.Group("/groups")
+        {
+            groups.POST("", adminOnly, groupHandler.CreateGroup)
+            groups.GET("", groupHandler.GetAllGroups)
+            groups.GET("/:group_id", groupHandler.GetGroup)
+            groups.PUT("/:group_id", adminOnly, groupHandler.UpdateGroup)
+            groups.DELETE("/:group_id", adminOnly, groupHandler.DeleteGroup)
+        }
+        
+        // Rutas de maestros
+        teachers := protected.Group("/teachers", staff)
+        {
+            teachers.POST("", adminOnly, teacherHandler.CreateTeacher)
+            teachers.GET("", teacherHandler.GetAllTeachers)
+            teachers.GET("/:teacher_id", teacherHandler.GetTeacher)
+            teachers.PUT("/:teacher_id", adminOnly, teacherHandler.UpdateTeacher)
+            teachers.DELETE("/:teacher_id", adminOnly, teacherHandler.DeleteTeacher)
+        }
+        
+        // Rutas de tutores
+        guardians := protected.Group("/guardians")
+        {
+            guardians.POST("", adminOnly, guardianHandler.CreateGuardian)
+            guardians.GET("", staff, guardianHandler.GetAllGuardians)
+            guardians.GET("/:guardian_id", staff, guardianHandler.GetGuardian)
+            guardians.PUT("/:guardian_id", adminOnly, guardianHandler.UpdateGuardian)
+            guardians.DELETE("/:guardian_id", adminOnly, guardianHandler.DeleteGuardian)
+            guardians.POST("/:guardian_id/students", adminOnly, guardianHandler.LinkStudent)
+            guardians.DELETE("/:guardian_id/students/:student_id", adminOnly, guardianHandler.UnlinkStudent)
+        }
+        
+        // Portal de padres: cada tutor solo consulta a los estudiantes vinculados a su cuenta
+        portal := protected.Group("/guardians/me", parentOnly)
+        {
+            portal.GET("", guardianHandler.GetMyGuardian)
+            portal.GET("/students/:student_id/grades", guardianHandler.RequireOwnChild, gradeHandler.GetStudentGrades)
+            portal.GET("/students/:student_id/attendance", guardianHandler.RequireOwnChild, attendanceHandler.GetStudentAttendance)
+        }
+        
+        // Rutas de asignaciones de maestros a materias y grupos
+        assignments := protected.Group("/assignments", staff)
+        {
+            assignments.POST("", adminOnly, assignmentHandler.CreateAssignment)
+            assignments.GET("", assignmentHandler.GetAllAssignments)
+            assignments.DELETE("/:assignment_id", adminOnly, assignmentHandler.DeleteAssignment)
+        }
+        
+        // Rutas de inscripciones de estudiantes en materias
+        enrollments := protected.Group("/enrollments", staff)
         {
-            students.POST("", handlers.CreateStudent)
-            students.GET("", handlers.GetAllStudents)
-            students.GET("/:student_id", handlers.GetStudent)
-            students.PUT("/:student_id", handlers.UpdateStudent)
-            students.DELETE("/:student_id", handlers.DeleteStudent)
+            enrollments.POST("", adminOnly, enrollmentHandler.CreateEnrollment)
+            enrollments.GET("", enrollmentHandler.GetAllEnrollments)
+            enrollments.DELETE("/:enrollment_id", adminOnly, enrollmentHandler.DeleteEnrollment)
+            enrollments.POST("/group", adminOnly, enrollmentHandler.EnrollGroup)
+            enrollments.DELETE("/group", adminOnly, enrollmentHandler.UnenrollGroup)
         }
         
         // Rutas de materias
-        subjects := api.Group("/subjects")
+        subjects := protected.Group("/subjects")
         {
-            subjects.POST("", handlers.CreateSubject)
-            subjects.GET("/:subject_id", handlers.GetSubject)
-            subjects.PUT("/:subject_id", handlers.UpdateSubject)
-            subjects.DELETE("/:subject_id", handlers.DeleteSubject)
+            subjects.POST("", adminOnly, subjectHandler.CreateSubject)
+            subjects.GET("", subjectHandler.GetAllSubjects)
+            subjects.GET("/export", staff, exportHandler.ExportSubjects)
+            subjects.GET("/:subject_id", subjectHandler.GetSubject)
+            subjects.PUT("/:subject_id", adminOnly, subjectHandler.UpdateSubject)
+            subjects.DELETE("/:subject_id", adminOnly, subjectHandler.DeleteSubject)
+            subjects.POST("/:subject_id/restore", adminOnly, subjectHandler.RestoreSubject)
+        }
+        
+        // Rutas de periodos académicos
+        terms := protected.Group("/terms")
+        {
+            terms.POST("", adminOnly, termHandler.CreateTerm)
+            terms.GET("", termHandler.GetAllTerms)
+            terms.GET("/:term_id", termHandler.GetTerm)
+            terms.PUT("/:term_id", adminOnly, termHandler.UpdateTerm)
+            terms.DELETE("/:term_id", adminOnly, termHandler.DeleteTerm)
         }
         
         // Rutas de calificaciones
-        grades := api.Group("/grades")
+        grades := protected.Group("/grades")
+        {
+            grades.POST("", teacherOnly, gradeHandler.CreateGrade)
+            grades.POST("/batch", teacherOnly, gradeHandler.CreateGradesBatch)
+            grades.POST("/components", teacherOnly, gradeHandler.RecordComponentScores)
+            grades.GET("/export", staff, exportHandler.ExportGrades)
+            grades.GET("/:grade_id/history", staff, gradeHandler.GetGradeHistory)
+            grades.PUT("/:grade_id", teacherOnly, gradeHandler.UpdateGrade)
+            grades.PUT("/student/:student_id/subject/:subject_id/term/:term_id", teacherOnly, gradeHandler.UpsertGrade)
+            grades.DELETE("/:grade_id", staff, gradeHandler.DeleteGrade)
+            grades.POST("/:grade_id/restore", staff, gradeHandler.RestoreGrade)
+            grades.GET("/:grade_id/student/:student_id", ownStudent, gradeHandler.GetGradeByStudentAndSubject)
+            grades.GET("/student/:student_id", ownStudent, gradeHandler.GetStudentGrades)
+            grades.GET("/student/:student_id/subject/:subject_id/term/:term_id/components", ownStudent, evaluationHandler.GetGradeBreakdown)
+        }
+        
+        // Rutas de criterios de evaluación; los maestros solo definen los de sus materias
+        evaluationCriteria := protected.Group("/evaluation-criteria/subject/:subject_id/term/:term_id")
+        {
+            evaluationCriteria.GET("", evaluationHandler.GetCriteria)
+            evaluationCriteria.PUT("", staff, evaluationHandler.ReplaceCriteria)
+            evaluationCriteria.DELETE("", staff, evaluationHandler.DeleteCriteria)
+        }
+        
+        // Rutas de escalas de calificación; solo los administradores las definen y asignan
+        gradingScales := protected.Group("/grading-scales")
+        {
+            gradingScales.POST("", adminOnly, gradingScaleHandler.CreateGradingScale)
+            gradingScales.GET("", gradingScaleHandler.GetAllGradingScales)
+            gradingScales.POST("/assignments", adminOnly, gradingScaleHandler.AssignGradingScale)
+            gradingScales.GET("/assignments", gradingScaleHandler.GetGradingScaleAssignments)
+            gradingScales.DELETE("/assignments/:assignment_id", adminOnly, gradingScaleHandler.UnassignGradingScale)
+            gradingScales.GET("/:scale_id", gradingScaleHandler.GetGradingScale)
+            gradingScales.PUT("/:scale_id", adminOnly, gradingScaleHandler.UpdateGradingScale)
+            gradingScales.DELETE("/:scale_id", adminOnly, gradingScaleHandler.DeleteGradingScale)
+        }
+        
+        // Rutas de cierre de calificaciones; solo los administradores pueden reabrir
+        gradeLocks := protected.Group("/grade-locks", staff)
+        {
+            gradeLocks.POST("", gradeLockHandler.CloseGrades)
+            gradeLocks.GET("", gradeLockHandler.GetAllGradeLocks)
+            gradeLocks.GET("/events", adminOnly, gradeLockHandler.GetGradeLockEvents)
+            gradeLocks.POST("/:lock_id/reopen", adminOnly, gradeLockHandler.ReopenGrades)
+        }
+        
+        // Rutas de asistencia; los maestros solo toman lista de sus materias o de su grupo titular
+        attendance := protected.Group("/attendance", staff)
+        {
+            attendance.POST("/roll-call", attendanceHandler.TakeRollCall)
+            attendance.GET("", attendanceHandler.GetAllAttendance)
+            attendance.PUT("/:attendance_id", attendanceHandler.UpdateAttendance)
+            attendance.DELETE("/:attendance_id", adminOnly, attendanceHandler.DeleteAttendance)
+        }
+        
+        // Rutas de estadísticas por grupo
+        analytics := protected.Group("/analytics", staff)
         {
-            grades.POST("", handlers.CreateGrade)
-            grades.PUT("/:grade_id", handlers.UpdateGrade)
-            grades.DELETE("/:grade_id", handlers.DeleteGrade)
-            grades.GET("/:grade_id/student/:student_id", handlers.GetGradeByStudentAndSubject)
-            grades.GET("/student/:student_id", handlers.GetStudentGrades)
+            analytics.GET("/groups", analyticsHandler.GetGroupSubjectStats)
+            analytics.GET("/ranking", analyticsHandler.GetRanking)
+            analytics.GET("/distribution", analyticsHandler.GetDistribution)
         }
     }
 }
@@ -0,0 +1,111 @@
+package spreadsheet
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/csv"
+    "io"
+    "path/filepath"
+    "strings"
+
+    "github.com/xuri/excelize/v2"
+
+    "ControlEscolar/i18n"
+)
+
+// Formatos de archivo soportados; NDJSON solo se usa para exportar
+const (
+    FormatCSV    = "csv"
+    FormatXLSX   = "xlsx"
+    FormatNDJSON = "ndjson"
+)
+
+// ErrUnsupportedFormat indica que la extensión del archivo no es CSV ni XLSX
+var ErrUnsupportedFormat error = i18n.NewMessage("spreadsheet.unsupported_format")
+
+// utf8BOM es la marca con la que Excel inicia los CSV guardados como UTF-8
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// FormatOf devuelve el formato de un archivo según su extensión
+func FormatOf(filename string) (string, error) {
+    switch strings.ToLower(filepath.Ext(filename)) {
+    case ".csv":
+        return FormatCSV, nil
+    case ".xlsx":
+        return FormatXLSX, nil
+    default:
+        return "", ErrUnsupportedFormat
+    }
+}
+
+// ReadRows lee todas las filas de un archivo CSV o de la primera hoja de un XLSX.
+// Las celdas se devuelven sin espacios al inicio ni al final.
+func ReadRows(r io.Reader, format string) ([][]string, error) {
+    var rows [][]string
+    var err error
+    switch format {
+    case FormatCSV:
+        rows, err = readCSV(r)
+    case FormatXLSX:
+        rows, err = readXLSX(r)
+    default:
+        return nil, ErrUnsupportedFormat
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    for _, row := range rows {
+        for i := range row {
+            row[i] = strings.TrimSpace(row[i])
+        }
+    }
+    return rows, nil
+}
+
+// readCSV lee un CSV separado por comas o por punto y coma, como lo exporta Excel
+// en configuraciones regionales que usan la coma decimal
+func readCSV(r io.Reader) ([][]string, error) {
+    buffered := bufio.NewReader(r)
+    if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+        buffered.Discard(len(utf8BOM))
+    }
+
+    // El separador se deduce del encabezado
+    header, _ := buffered.Peek(buffered.Size())
+    if i := bytes.IndexByte(header, '\n'); i >= 0 {
+        header = header[:i]
+    }
+
+    reader := csv.NewReader(buffered)
+    if bytes.Count(header, []byte{';'}) > bytes.Count(header, []byte{','}) {
+        reader.Comma = ';'
+    }
+    reader.FieldsPerRecord = -1
+
+    rows, err := reader.ReadAll()
+    if err != nil {
+        return nil, i18n.NewMessage("spreadsheet.invalid_csv", err)
+    }
+    return rows, nil
+}
+
+// readXLSX lee la primera hoja del libro
+func readXLSX(r io.Reader) ([][]string, error) {
+    book, err := excelize.OpenReader(r)
+    if err != nil {
+        return nil, i18n.NewMessage("spreadsheet.invalid_xlsx", err)
+    }
+    defer book.Close()
+
+    sheets := book.GetSheetList()
+    if len(sheets) == 0 {
+        return nil, i18n.NewMessage("spreadsheet.no_sheets")
+    }
+
+    rows, err := book.GetRows(sheets[0])
+    if err != nil {
+        return nil, i18n.NewMessage("spreadsheet.invalid_xlsx", err)
+    }
+    return rows, nil
+}
@@ -0,0 +1,205 @@
+package spreadsheet
+
+import (
+    "bufio"
+    "encoding/csv"
+    "encoding/json"
+    "fmt"
+    "io"
+    "reflect"
+    "strconv"
+    "strings"
+
+    "github.com/xuri/excelize/v2"
+)
+
+// ContentTypes asocia cada formato de exportación con su tipo MIME
+var ContentTypes = map[string]string{
+    FormatCSV:    "text/csv; charset=utf-8",
+    FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+    FormatNDJSON: "application/x-ndjson",
+}
+
+// Writer escribe una tabla fila por fila sin conservar las filas ya escritas
+type Writer interface {
+    // WriteRow escribe una fila con un valor por columna; nil y los punteros nulos quedan vacíos
+    WriteRow(values []interface{}) error
+    // Close termina el archivo; en XLSX es cuando se escribe el libro completo
+    Close() error
+}
+
+// NewWriter crea un Writer del formato indicado que escribe en w. En CSV y XLSX la primera
+// fila son las columnas; en NDJSON cada fila es un objeto con las columnas como llaves.
+// title se usa como nombre de la hoja en XLSX.
+func NewWriter(w io.Writer, format, title string, columns []string) (Writer, error) {
+    switch format {
+    case FormatCSV:
+        return newCSVWriter(w, columns)
+    case FormatXLSX:
+        return newXLSXWriter(w, title, columns)
+    case FormatNDJSON:
+        return &ndjsonWriter{out: bufio.NewWriter(w), columns: columns}, nil
+    default:
+        return nil, ErrUnsupportedFormat
+    }
+}
+
+// csvWriter escribe CSV con marca BOM para que Excel reconozca el UTF-8
+type csvWriter struct {
+    out *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+    if _, err := w.Write(utf8BOM); err != nil {
+        return nil, err
+    }
+    writer := &csvWriter{out: csv.NewWriter(w)}
+    if err := writer.out.Write(columns); err != nil {
+        return nil, err
+    }
+    return writer, nil
+}
+
+func (w *csvWriter) WriteRow(values []interface{}) error {
+    record := make([]string, len(values))
+    for i, value := range values {
+        record[i] = formatCell(value)
+    }
+    return w.out.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+    w.out.Flush()
+    return w.out.Error()
+}
+
+// xlsxWriter usa el StreamWriter de excelize, que guarda las filas en un archivo temporal
+// en lugar de mantenerlas en memoria
+type xlsxWriter struct {
+    out    io.Writer
+    book   *excelize.File
+    stream *excelize.StreamWriter
+    row    int
+}
+
+func newXLSXWriter(w io.Writer, title string, columns []string) (*xlsxWriter, error) {
+    book := excelize.NewFile()
+    sheet := book.GetSheetName(0)
+    if title != "" {
+        if err := book.SetSheetName(sheet, title); err != nil {
+            return nil, err
+        }
+        sheet = title
+    }
+
+    stream, err := book.NewStreamWriter(sheet)
+    if err != nil {
+        book.Close()
+        return nil, err
+    }
+
+    writer := &xlsxWriter{out: w, book: book, stream: stream}
+    header := make([]interface{}, len(columns))
+    for i, column := range columns {
+        header[i] = column
+    }
+    if err := writer.WriteRow(header); err != nil {
+        book.Close()
+        return nil, err
+    }
+    return writer, nil
+}
+
+func (w *xlsxWriter) WriteRow(values []interface{}) error {
+    w.row++
+    cell, err := excelize.CoordinatesToCellName(1, w.row)
+    if err != nil {
+        return err
+    }
+    row := make([]interface{}, len(values))
+    for i, value := range values {
+        row[i] = dereference(value)
+    }
+    return w.stream.SetRow(cell, row)
+}
+
+func (w *xlsxWriter) Close() error {
+    defer w.book.Close()
+    if err := w.stream.Flush(); err != nil {
+        return err
+    }
+    return w.book.Write(w.out)
+}
+
+// ndjsonWriter escribe un objeto JSON por línea conservando el orden de las columnas
+type ndjsonWriter struct {
+    out     *bufio.Writer
+    columns []string
+}
+
+func (w *ndjsonWriter) WriteRow(values []interface{}) error {
+    w.out.WriteByte('{')
+    for i, column := range w.columns {
+        if i > 0 {
+            w.out.WriteByte(',')
+        }
+        key, _ := json.Marshal(column)
+        w.out.Write(key)
+        w.out.WriteByte(':')
+
+        var value interface{}
+        if i < len(values) {
+            value = dereference(values[i])
+        }
+        encoded, err := json.Marshal(value)
+        if err != nil {
+            return err
+        }
+        w.out.Write(encoded)
+    }
+    _, err := w.out.WriteString("}\n")
+    return err
+}
+
+func (w *ndjsonWriter) Close() error {
+    return w.out.Flush()
+}
+
+// dereference devuelve el valor al que apunta un puntero, o nil si es nulo
+func dereference(value interface{}) interface{} {
+    v := reflect.ValueOf(value)
+    for v.Kind() == reflect.Ptr {
+        if v.IsNil() {
+            return nil
+        }
+        v = v.Elem()
+    }
+    if !v.IsValid() {
+        return nil
+    }
+    return v.Interface()
+}
+
+// formatCell convierte un valor en el texto de una celda CSV
+func formatCell(value interface{}) string {
+    switch v := dereference(value).(type) {
+    case nil:
+        return ""
+    case string:
+        return escapeFormula(v)
+    case float64:
+        return strconv.FormatFloat(v, 'f', -1, 64)
+    default:
+        return fmt.Sprint(v)
+    }
+}
+
+// escapeFormula antepone un apóstrofo al texto que una hoja de cálculo interpretaría como
+// fórmula al abrir el CSV, como un nombre que empieza con "=". En XLSX no hace falta porque el
+// texto se guarda en celdas de tipo cadena, que nunca se evalúan.
+func escapeFormula(value string) string {
+    if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+        return "'" + value
+    }
+    return value
+}
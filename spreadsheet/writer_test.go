@@ -0,0 +1,73 @@
+package spreadsheet
+
+import (
+    "bytes"
+    "encoding/csv"
+    "testing"
+
+    "github.com/xuri/excelize/v2"
+)
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+    var out bytes.Buffer
+    writer, err := NewWriter(&out, FormatCSV, "", []string{"name", "grade"})
+    if err != nil {
+        t.Fatalf("NewWriter: %v", err)
+    }
+
+    names := []string{"=HYPERLINK(\"http://example.com\")", "+52 555", "-1+1", "@SUM(A1)", "\tAna", "\rAna", "Ana-María", ""}
+    for _, name := range names {
+        if err := writer.WriteRow([]interface{}{name, -5.5}); err != nil {
+            t.Fatalf("WriteRow: %v", err)
+        }
+    }
+    if err := writer.Close(); err != nil {
+        t.Fatalf("Close: %v", err)
+    }
+
+    records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(out.Bytes(), utf8BOM))).ReadAll()
+    if err != nil {
+        t.Fatalf("leer CSV: %v", err)
+    }
+    want := []string{"'=HYPERLINK(\"http://example.com\")", "'+52 555", "'-1+1", "'@SUM(A1)", "'\tAna", "'\rAna", "Ana-María", ""}
+    for i, name := range want {
+        if got := records[i+1][0]; got != name {
+            t.Errorf("fila %d: name = %q, se esperaba %q", i+1, got, name)
+        }
+        // Los números negativos no son texto y se escriben sin cambios
+        if got := records[i+1][1]; got != "-5.5" {
+            t.Errorf("fila %d: grade = %q, se esperaba -5.5", i+1, got)
+        }
+    }
+}
+
+func TestXLSXWriterStoresFormulasAsText(t *testing.T) {
+    var out bytes.Buffer
+    writer, err := NewWriter(&out, FormatXLSX, "Estudiantes", []string{"name"})
+    if err != nil {
+        t.Fatalf("NewWriter: %v", err)
+    }
+    if err := writer.WriteRow([]interface{}{"=1+1"}); err != nil {
+        t.Fatalf("WriteRow: %v", err)
+    }
+    if err := writer.Close(); err != nil {
+        t.Fatalf("Close: %v", err)
+    }
+
+    book, err := excelize.OpenReader(&out)
+    if err != nil {
+        t.Fatalf("abrir XLSX: %v", err)
+    }
+    defer book.Close()
+    formula, err := book.GetCellFormula("Estudiantes", "A2")
+    if err != nil {
+        t.Fatalf("GetCellFormula: %v", err)
+    }
+    value, err := book.GetCellValue("Estudiantes", "A2")
+    if err != nil {
+        t.Fatalf("GetCellValue: %v", err)
+    }
+    if formula != "" || value != "=1+1" {
+        t.Errorf("celda A2: fórmula %q, valor %q; se esperaba el texto =1+1 sin fórmula", formula, value)
+    }
+}
@@ -0,0 +1,81 @@
+package utils
+
+// Códigos estables de error. Los clientes pueden depender de ellos: un código no cambia de
+// significado aunque cambie el mensaje, y los nuevos errores se agregan con códigos nuevos.
+const (
+    // Peticiones inválidas
+    CodeValidationFailed   = "VALIDATION_FAILED"
+    CodeMalformedBody      = "MALFORMED_BODY"
+    CodeInvalidID          = "INVALID_ID"
+    CodeInvalidParameter   = "INVALID_PARAMETER"
+    CodeInvalidFile        = "INVALID_FILE"
+    CodeFileTooLarge       = "FILE_TOO_LARGE"
+    CodeNotAcceptable      = "NOT_ACCEPTABLE"
+    CodeTermGroupYear      = "TERM_GROUP_YEAR_MISMATCH"
+    CodeNotEnrolled        = "NOT_ENROLLED"
+    CodeBatchRejected      = "BATCH_REJECTED"
+    CodeStudentNotInGroup  = "STUDENT_NOT_IN_GROUP"
+    CodeCriteriaWeights    = "CRITERIA_WEIGHTS_INVALID"
+    CodeDuplicateCriterion = "DUPLICATE_CRITERION"
+    CodeGradeOutOfScale    = "GRADE_OUT_OF_SCALE"
+    
+    // Autenticación y permisos
+    CodeAuthRequired       = "AUTH_REQUIRED"
+    CodeInvalidToken       = "INVALID_TOKEN"
+    CodeInvalidCredentials = "INVALID_CREDENTIALS"
+    CodeForbidden          = "FORBIDDEN"
+    CodeOwnDataOnly        = "OWN_DATA_ONLY"
+    CodeTeacherNotLinked   = "TEACHER_NOT_LINKED"
+    CodeTeacherNotAssigned = "TEACHER_NOT_ASSIGNED"
+    CodeGuardianNotLinked  = "GUARDIAN_NOT_LINKED"
+    
+    // Registros inexistentes
+    CodeRouteNotFound           = "ROUTE_NOT_FOUND"
+    CodeStudentNotFound         = "STUDENT_NOT_FOUND"
+    CodeSubjectNotFound         = "SUBJECT_NOT_FOUND"
+    CodeTermNotFound            = "TERM_NOT_FOUND"
+    CodeGroupNotFound           = "GROUP_NOT_FOUND"
+    CodeTeacherNotFound         = "TEACHER_NOT_FOUND"
+    CodeUserNotFound            = "USER_NOT_FOUND"
+    CodeGradeNotFound           = "GRADE_NOT_FOUND"
+    CodeEnrollmentNotFound      = "ENROLLMENT_NOT_FOUND"
+    CodeAssignmentNotFound      = "ASSIGNMENT_NOT_FOUND"
+    CodeGradeLockNotFound       = "GRADE_LOCK_NOT_FOUND"
+    CodeAttendanceNotFound      = "ATTENDANCE_NOT_FOUND"
+    CodeCriterionNotFound       = "CRITERION_NOT_FOUND"
+    CodeCriteriaNotFound        = "CRITERIA_NOT_FOUND"
+    CodeScaleNotFound           = "GRADING_SCALE_NOT_FOUND"
+    CodeScaleAssignmentNotFound = "SCALE_ASSIGNMENT_NOT_FOUND"
+    CodeGuardianNotFound        = "GUARDIAN_NOT_FOUND"
+    CodeGuardianLinkNotFound    = "GUARDIAN_LINK_NOT_FOUND"
+    
+    // Conflictos con el estado actual
+    CodeDuplicateEmail           = "DUPLICATE_EMAIL"
+    CodeDuplicateSubjectName     = "DUPLICATE_SUBJECT_NAME"
+    CodeDuplicateUsername        = "DUPLICATE_USERNAME"
+    CodeDuplicateGroup           = "DUPLICATE_GROUP"
+    CodeDuplicateTeacher         = "DUPLICATE_TEACHER"
+    CodeDuplicateAssignment      = "DUPLICATE_ASSIGNMENT"
+    CodeDuplicateEnrollment      = "DUPLICATE_ENROLLMENT"
+    CodeDuplicateGrade           = "DUPLICATE_GRADE"
+    CodeDuplicateGuardian        = "DUPLICATE_GUARDIAN"
+    CodeDuplicateGuardianLink    = "DUPLICATE_GUARDIAN_LINK"
+    CodeDuplicateAttendance      = "DUPLICATE_ATTENDANCE"
+    CodeGradesAlreadyLocked      = "GRADES_ALREADY_LOCKED"
+    CodeGroupInUse               = "GROUP_IN_USE"
+    CodeTermInUse                = "TERM_IN_USE"
+    CodeEnrollmentHasGrades      = "ENROLLMENT_HAS_GRADES"
+    CodeParentDeleted            = "PARENT_DELETED"
+    CodeGradesLocked             = "GRADES_LOCKED"
+    CodeGradeComputed            = "GRADE_COMPUTED"
+    CodeCriterionHasScores       = "CRITERION_HAS_SCORES"
+    CodeManualGrades             = "MANUAL_GRADES_EXIST"
+    CodeDuplicateScaleName       = "DUPLICATE_SCALE_NAME"
+    CodeDuplicateScaleAssignment = "DUPLICATE_SCALE_ASSIGNMENT"
+    CodeScaleInUse               = "GRADING_SCALE_IN_USE"
+    CodeDeletedStudentEmail      = "DELETED_STUDENT_EMAIL"
+    CodeDeletedSubjectName       = "DELETED_SUBJECT_NAME"
+    
+    // Fallas del servidor
+    CodeInternalError = "INTERNAL_ERROR"
+)
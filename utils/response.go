@@ -1,13 +1,36 @@
 package utils
 
 import (
+    "net/http"
+    "net/url"
+    "strconv"
+    
     "github.com/gin-gonic/gin"
+    "ControlEscolar/i18n"
 )
 
-// ErrorResponse estructura para respuestas de error
+// ProblemContentType es el tipo de contenido de las respuestas de error (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// ErrorResponse es una respuesta de error con el formato problem+json de RFC 7807.
+// Code identifica el error de forma estable para los programas; Detail es el mensaje para las personas.
 type ErrorResponse struct {
-    Error   string `json:"error"`
-    Message string `json:"message,omitempty"`
+    Type     string       `json:"type" example:"about:blank"`
+    Title    string       `json:"title" example:"Not Found"`
+    Status   int          `json:"status" example:"404"`
+    Code     string       `json:"code" example:"STUDENT_NOT_FOUND"`
+    Detail   string       `json:"detail,omitempty" example:"Estudiante no encontrado"`
+    Instance string       `json:"instance,omitempty" example:"/api/students/99"`
+    // Errors enumera los campos que no pasaron la validación
+    Errors   []FieldError `json:"errors,omitempty"`
+    Details  interface{}  `json:"details,omitempty"`
+}
+
+// FieldError describe un campo que no pasó la validación
+type FieldError struct {
+    Field   string `json:"field" example:"grade"`
+    Rule    string `json:"rule" example:"max"`
+    Message string `json:"message" example:"grade debe ser 100 o menos"`
 }
 
 // SuccessResponse estructura para respuestas exitosas
@@ -16,18 +39,140 @@ type SuccessResponse struct {
     Data    interface{} `json:"data,omitempty"`
 }
 
-// RespondWithError envía una respuesta de error
-func RespondWithError(c *gin.Context, code int, message string) {
-    c.JSON(code, ErrorResponse{
-        Error:   "Error",
-        Message: message,
+// languageKey es la llave del contexto de Gin donde se guarda el idioma de la petición
+const languageKey = "i18n.language"
+
+// Language devuelve el idioma de los mensajes de la respuesta, elegido con el header Accept-Language
+func Language(c *gin.Context) string {
+    if lang := c.GetString(languageKey); lang != "" {
+        return lang
+    }
+    lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+    c.Set(languageKey, lang)
+    return lang
+}
+
+// Translate devuelve el mensaje de la llave en el idioma de la petición e indica el idioma en
+// el header Content-Language de la respuesta
+func Translate(c *gin.Context, key string, args ...interface{}) string {
+    lang := Language(c)
+    c.Header("Content-Language", lang)
+    return i18n.T(lang, key, args...)
+}
+
+// RespondWithError envía una respuesta de error con el código estable indicado. key es la llave
+// del mensaje en el catálogo de i18n y args sus argumentos.
+func RespondWithError(c *gin.Context, status int, code string, key string, args ...interface{}) {
+    respondProblem(c, ErrorResponse{
+        Status: status,
+        Code:   code,
+        Detail: Translate(c, key, args...),
     })
 }
 
-// RespondWithSuccess envía una respuesta exitosa
-func RespondWithSuccess(c *gin.Context, code int, message string, data interface{}) {
+// RespondWithErrorDetails envía una respuesta de error con información adicional para el cliente
+func RespondWithErrorDetails(c *gin.Context, status int, code string, key string, details interface{}) {
+    respondProblem(c, ErrorResponse{
+        Status:  status,
+        Code:    code,
+        Detail:  Translate(c, key),
+        Details: details,
+    })
+}
+
+// RespondWithValidationErrors envía un 400 VALIDATION_FAILED con los campos que no pasaron la validación
+func RespondWithValidationErrors(c *gin.Context, key string, errors []FieldError) {
+    respondProblem(c, ErrorResponse{
+        Status: http.StatusBadRequest,
+        Code:   CodeValidationFailed,
+        Detail: Translate(c, key),
+        Errors: errors,
+    })
+}
+
+// RespondRouteNotFound responde 404 ROUTE_NOT_FOUND a las rutas que no existen
+func RespondRouteNotFound(c *gin.Context) {
+    RespondWithError(c, http.StatusNotFound, CodeRouteNotFound, "request.route_not_found", c.Request.Method, c.Request.URL.Path)
+}
+
+// RespondPanic responde 500 INTERNAL_ERROR cuando un handler entra en pánico; el detalle
+// del pánico solo queda en el log del servidor
+func RespondPanic(c *gin.Context, recovered interface{}) {
+    RespondWithError(c, http.StatusInternalServerError, CodeInternalError, "request.internal_error")
+    c.Abort()
+}
+
+// respondProblem completa el tipo, el título y la ruta del error y lo envía como problem+json
+func respondProblem(c *gin.Context, problem ErrorResponse) {
+    problem.Type = "about:blank"
+    problem.Title = http.StatusText(problem.Status)
+    problem.Instance = c.Request.URL.Path
+    
+    c.Header("Content-Type", ProblemContentType)
+    c.JSON(problem.Status, problem)
+}
+
+// RespondWithSuccess envía una respuesta exitosa con el mensaje de la llave key
+func RespondWithSuccess(c *gin.Context, code int, key string, data interface{}) {
     c.JSON(code, SuccessResponse{
-        Message: message,
+        Message: Translate(c, key),
         Data:    data,
     })
-}
\ No newline at end of file
+}
+
+// PaginationMeta describe la página devuelta en un listado
+type PaginationMeta struct {
+    Page       int    `json:"page" example:"1"`
+    Limit      int    `json:"limit" example:"20"`
+    Total      int64  `json:"total" example:"135"`
+    TotalPages int    `json:"total_pages" example:"7"`
+    Next       string `json:"next,omitempty" example:"/api/students?limit=20&page=2"`
+    Prev       string `json:"prev,omitempty"`
+}
+
+// PaginatedResponse estructura para respuestas de listados paginados
+type PaginatedResponse struct {
+    Message    string         `json:"message"`
+    Data       interface{}    `json:"data"`
+    Pagination PaginationMeta `json:"pagination"`
+}
+
+// RespondWithPage envía una página de resultados con el mensaje traducido, el total y los enlaces a las páginas vecinas
+func RespondWithPage(c *gin.Context, key string, data interface{}, page, limit int, total int64) {
+    totalPages := 0
+    if limit > 0 {
+        totalPages = int((total + int64(limit) - 1) / int64(limit))
+    }
+    
+    meta := PaginationMeta{
+        Page:       page,
+        Limit:      limit,
+        Total:      total,
+        TotalPages: totalPages,
+    }
+    if page < totalPages {
+        meta.Next = pageLink(c, page+1, limit)
+    }
+    if page > 1 {
+        meta.Prev = pageLink(c, min(page-1, max(totalPages, 1)), limit)
+    }
+    
+    c.JSON(http.StatusOK, PaginatedResponse{
+        Message:    Translate(c, key),
+        Data:       data,
+        Pagination: meta,
+    })
+}
+
+// pageLink construye la URL de otra página conservando los filtros de la petición
+func pageLink(c *gin.Context, page, limit int) string {
+    query := c.Request.URL.Query()
+    query.Set("page", strconv.Itoa(page))
+    query.Set("limit", strconv.Itoa(limit))
+    
+    link := url.URL{
+        Path:     c.Request.URL.Path,
+        RawQuery: query.Encode(),
+    }
+    return link.String()
+}